@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -27,24 +30,438 @@ const (
 	ConditionTypeFileSystemCreated   = "FileSystemCreated"
 	ConditionTypeStorageClassCreated = "StorageClassCreated"
 	ConditionTypeDeletionBlocked     = "DeletionBlocked"
+	ConditionTypeExpanding           = "Expanding"
+	ConditionTypeCapacityWarning     = "CapacityWarning"
+	ConditionTypeAdopted             = "Adopted"
 	ConditionTypeReady               = "Ready"
 )
 
+// AdoptFilesystemAnnotation is set on a new FileSystemClaim to adopt the existing Filesystem it names,
+// together with its LocalDisks and StorageClass, instead of creating new ones
+const AdoptFilesystemAnnotation = "fusion.storage.openshift.io/adopt-filesystem"
+
+// Default layout values for the generated Filesystem
+const (
+	DefaultFileSystemBlockSize   = "4M"
+	DefaultFileSystemReplication = "1-way"
+	SystemPoolName               = "system"
+)
+
+// Default thresholds of the CapacityWarning condition, in percent of the total capacity
+const (
+	DefaultCapacityWarningPercent  = 80
+	DefaultCapacityCriticalPercent = 95
+)
+
 // FileSystemClaimSpec defines the desired state of FileSystemClaim.
 type FileSystemClaimSpec struct {
-	// Devices is a list of device paths to be used for the file system. For example, ["/dev/sda", "/dev/sdb"]
+	// Devices is a list of devices to be used for the file system. A device is referenced by its kernel path,
+	// its persistent /dev/disk/by-id path or its WWN, and is resolved on each storage node from the
+	// LocalVolumeDiscoveryResults. For example, ["/dev/sda", "/dev/disk/by-id/wwn-0x5000c500a1b2c3d4", "0x5000c500a1b2c3d5"]
+	// Once the LocalDisks are created, new devices can only be appended to grow the file system online.
 	Devices []string `json:"devices,omitempty"`
+
+	// DeviceSelector selects the devices of the file system from the discovered devices instead of listing
+	// them in spec.devices. Only devices visible on all storage nodes are selected. The selector is resolved
+	// once and the resulting devices are recorded in status.resolvedDevices.
+	// Mutually exclusive with spec.devices.
+	// +optional
+	DeviceSelector *DeviceSelector `json:"deviceSelector,omitempty"`
+
+	// Filesystem configures the layout of the generated Filesystem. When omitted, a 4M block size,
+	// 1-way replication and a single "system" pool with all devices are used.
+	// +optional
+	Filesystem *FileSystemLayout `json:"filesystem,omitempty"`
+
+	// NodePlacement controls which storage nodes serve the LocalDisks of the claim. When omitted, each
+	// LocalDisk is placed on the storage node with the fewest LocalDisks.
+	// +optional
+	NodePlacement *NodePlacement `json:"nodePlacement,omitempty"`
+
+	// CapacityThresholds configures when the CapacityWarning condition is raised. When omitted, a warning
+	// is raised at 80% and a critical warning at 95% of the total capacity.
+	// +optional
+	CapacityThresholds *CapacityThresholds `json:"capacityThresholds,omitempty"`
+
+	// RemoveDevices is a list of devices from spec.devices to drain and remove from the Filesystem.
+	// Data is migrated off each device before its LocalDisk is deleted. Once a device reports the
+	// Removed phase in status.deviceRemovals, it can be dropped from both spec.devices and this list.
+	// +optional
+	RemoveDevices []string `json:"removeDevices,omitempty"`
+
+	// StorageClass configures the StorageClass generated for the Filesystem. When omitted, the StorageClass
+	// is named after the claim, uses Immediate binding with a Delete reclaim policy and is marked as the
+	// default KubeVirt virtualization class.
+	// +optional
+	StorageClass *StorageClassTemplate `json:"storageClass,omitempty"`
+}
+
+// DeviceSelector matches discovered devices by their attributes. All set fields must match.
+type DeviceSelector struct {
+	// Vendor matches the device vendor, ignoring case and surrounding whitespace
+	// +optional
+	Vendor string `json:"vendor,omitempty"`
+
+	// Model matches the device model, ignoring case and surrounding whitespace
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// MinSize is the minimum size of the device
+	// +optional
+	MinSize *resource.Quantity `json:"minSize,omitempty"`
+
+	// MaxSize is the maximum size of the device
+	// +optional
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+
+	// Types restricts the selection to the given device types
+	// +kubebuilder:validation:items:Enum=disk;mpath
+	// +optional
+	Types []DiscoveredDeviceType `json:"types,omitempty"`
+
+	// WWNPrefix matches the beginning of the device WWN, ignoring case. For example, "0x6005076"
+	// +optional
+	WWNPrefix string `json:"wwnPrefix,omitempty"`
+}
+
+// NodePlacementStrategy selects the storage node serving each LocalDisk
+// +kubebuilder:validation:Enum=LeastLoaded;RoundRobin
+type NodePlacementStrategy string
+
+const (
+	// NodePlacementLeastLoaded places each LocalDisk on the candidate node with the fewest LocalDisks
+	NodePlacementLeastLoaded NodePlacementStrategy = "LeastLoaded"
+	// NodePlacementRoundRobin places the LocalDisks of the claim on the candidate nodes in turn
+	NodePlacementRoundRobin NodePlacementStrategy = "RoundRobin"
+)
+
+// NodePlacement defines the strategy and the candidate storage nodes used to place LocalDisks.
+type NodePlacement struct {
+	// Strategy is the placement strategy (default: LeastLoaded)
+	// +optional
+	Strategy NodePlacementStrategy `json:"strategy,omitempty"`
+
+	// Nodes restricts the candidate nodes to the listed storage nodes
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+
+	// NodeSelector restricts the candidate nodes to the storage nodes with all of these labels
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+}
+
+// CapacityThresholds defines the used capacity, in percent of the total capacity, at which the
+// CapacityWarning condition is raised.
+type CapacityThresholds struct {
+	// WarningPercent is the used capacity that raises a warning (default: 80)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	WarningPercent *int32 `json:"warningPercent,omitempty"`
+
+	// CriticalPercent is the used capacity that raises a critical warning (default: 95)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	CriticalPercent *int32 `json:"criticalPercent,omitempty"`
+}
+
+// FileSystemLayout defines the block size, replication and pools of the generated Filesystem.
+// The allowed values mirror the filesystems.scale.spectrum.ibm.com CRD.
+type FileSystemLayout struct {
+	// BlockSize is the block size of the file system (default: 4M)
+	// +kubebuilder:validation:Enum="64k";"128k";"256k";"512k";"1m";"2m";"4m";"8m";"16m";"256K";"512K";"1M";"2M";"4M";"8M";"16M"
+	// +optional
+	BlockSize string `json:"blockSize,omitempty"`
+
+	// Replication is the number of replicas kept for each data/metadata block (default: 1-way)
+	// +kubebuilder:validation:Enum="1-way";"2-way";"3-way"
+	// +optional
+	Replication string `json:"replication,omitempty"`
+
+	// Pools is a list of named storage pools and the devices that make them up.
+	// Devices from spec.devices that are not listed in any pool are placed in the "system" pool.
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	Pools []FileSystemPool `json:"pools,omitempty"`
+}
+
+// StorageClassTemplate defines the user-controlled fields of the generated StorageClass.
+type StorageClassTemplate struct {
+	// Name is the name of the StorageClass (default: the FileSystemClaim name)
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ReclaimPolicy is the reclaim policy of dynamically provisioned PersistentVolumes (default: Delete)
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +optional
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy,omitempty"`
+
+	// VolumeBindingMode controls when volume binding and provisioning happen (default: Immediate)
+	// +kubebuilder:validation:Enum=Immediate;WaitForFirstConsumer
+	// +optional
+	VolumeBindingMode *storagev1.VolumeBindingMode `json:"volumeBindingMode,omitempty"`
+
+	// DefaultVirtClass marks the StorageClass as the default KubeVirt virtualization class (default: true)
+	// +optional
+	DefaultVirtClass *bool `json:"defaultVirtClass,omitempty"`
+
+	// Parameters are extra IBM Storage Scale CSI parameters passed to the StorageClass
+	// +optional
+	Parameters *StorageClassParameters `json:"parameters,omitempty"`
+
+	// Labels are extra labels added to the StorageClass
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations are extra annotations added to the StorageClass
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// StorageClassParameters are the IBM Storage Scale CSI parameters that can be set on the StorageClass.
+type StorageClassParameters struct {
+	// UID is the owner uid of the volume directory
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	// +optional
+	UID string `json:"uid,omitempty"`
+
+	// GID is the owner gid of the volume directory
+	// +kubebuilder:validation:Pattern=`^[0-9]+$`
+	// +optional
+	GID string `json:"gid,omitempty"`
+
+	// Permissions are the octal permissions of the volume directory. For example, "777"
+	// +kubebuilder:validation:Pattern=`^[0-7]{3,4}$`
+	// +optional
+	Permissions string `json:"permissions,omitempty"`
+
+	// InodeLimit is the maximum number of inodes of the volume fileset
+	// +kubebuilder:validation:Pattern=`^[0-9]+[KMG]?$`
+	// +optional
+	InodeLimit string `json:"inodeLimit,omitempty"`
+}
+
+// FileSystemPool is a named storage pool of the generated Filesystem.
+type FileSystemPool struct {
+	// Name is the name of the storage pool
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Devices is a list of device paths from spec.devices that belong to this pool
+	// +kubebuilder:validation:MinItems=1
+	Devices []string `json:"devices"`
+}
+
+// DeviceRemovalPhase is the phase of a device listed in spec.removeDevices
+// +kubebuilder:validation:Enum=Pending;Emptying;Migrating;Deleting;Removed
+type DeviceRemovalPhase string
+
+const (
+	// DeviceRemovalPending means the removal has not started yet
+	DeviceRemovalPending DeviceRemovalPhase = "Pending"
+	// DeviceRemovalEmptying means a DiskJob is emptying the disk so no new data is placed on it
+	DeviceRemovalEmptying DeviceRemovalPhase = "Emptying"
+	// DeviceRemovalMigrating means a RestripeFSJob is migrating the data off the disk
+	DeviceRemovalMigrating DeviceRemovalPhase = "Migrating"
+	// DeviceRemovalDeleting means a DiskJob is deleting the disk from the Filesystem
+	DeviceRemovalDeleting DeviceRemovalPhase = "Deleting"
+	// DeviceRemovalRemoved means the disk and its LocalDisk are gone
+	DeviceRemovalRemoved DeviceRemovalPhase = "Removed"
+)
+
+// DeviceRemovalStatus reports the progress of a device listed in spec.removeDevices
+type DeviceRemovalStatus struct {
+	// Device is the device path from spec.removeDevices
+	Device string `json:"device"`
+
+	// LocalDisk is the name of the LocalDisk backing the device
+	// +optional
+	LocalDisk string `json:"localDisk,omitempty"`
+
+	// Phase is the current removal phase
+	Phase DeviceRemovalPhase `json:"phase"`
+
+	// Message is a human readable description of the current phase
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// DevicePlacement records the storage node chosen to serve the LocalDisk of a device
+type DevicePlacement struct {
+	// Device is the device reference from spec.devices or status.resolvedDevices
+	Device string `json:"device"`
+
+	// Node is the storage node set in the LocalDisk
+	Node string `json:"node"`
+}
+
+// LocalDiskState is the summarized state of a LocalDisk backing the claim
+// +kubebuilder:validation:Enum=Pending;Ready;InUse;Failed
+type LocalDiskState string
+
+const (
+	// LocalDiskStatePending means the LocalDisk is not Ready yet
+	LocalDiskStatePending LocalDiskState = "Pending"
+	// LocalDiskStateReady means the LocalDisk is Ready but not used by a Filesystem yet
+	LocalDiskStateReady LocalDiskState = "Ready"
+	// LocalDiskStateInUse means the LocalDisk is used by the Filesystem of the claim
+	LocalDiskStateInUse LocalDiskState = "InUse"
+	// LocalDiskStateFailed means the LocalDisk is unusable, for example used by another Filesystem
+	LocalDiskStateFailed LocalDiskState = "Failed"
+)
+
+// DeviceInventory reports a device of the claim and the LocalDisk backing it
+type DeviceInventory struct {
+	// Device is the device reference from spec.devices or status.resolvedDevices
+	Device string `json:"device"`
+
+	// WWN is the World Wide Name of the disk
+	// +optional
+	WWN string `json:"wwn,omitempty"`
+
+	// Node is the storage node serving the LocalDisk
+	// +optional
+	Node string `json:"node,omitempty"`
+
+	// LocalDisk is the name of the LocalDisk
+	LocalDisk string `json:"localDisk"`
+
+	// Pool is the Filesystem pool the LocalDisk belongs to
+	// +optional
+	Pool string `json:"pool,omitempty"`
+
+	// Size is the size of the LocalDisk
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// State is the summarized state of the LocalDisk
+	State LocalDiskState `json:"state"`
+
+	// Message explains the state when the LocalDisk is not usable yet
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// FilesystemInventory reports the Filesystem of the claim
+type FilesystemInventory struct {
+	// Name is the name of the Filesystem
+	Name string `json:"name"`
+
+	// MountPoint is where the Filesystem is mounted on the storage nodes
+	// +optional
+	MountPoint string `json:"mountPoint,omitempty"`
+}
+
+// CapacityStatus reports the capacity of the claim
+type CapacityStatus struct {
+	// Total is the disk capacity of the Filesystem pools, or the sum of the sizes of the LocalDisks
+	// used by the Filesystem when the Filesystem does not report it yet
+	// +optional
+	Total *resource.Quantity `json:"total,omitempty"`
+
+	// Used is the capacity of the PersistentVolumes provisioned from the StorageClass
+	// +optional
+	Used *resource.Quantity `json:"used,omitempty"`
+
+	// UsedPercent is Used in percent of Total
+	// +optional
+	UsedPercent *int32 `json:"usedPercent,omitempty"`
+
+	// Pools reports the disk capacity of each Filesystem pool
+	// +optional
+	Pools []PoolCapacity `json:"pools,omitempty"`
+}
+
+// PoolCapacity reports the disk capacity of a Filesystem pool
+type PoolCapacity struct {
+	// Name is the name of the pool
+	Name string `json:"name"`
+
+	// Total is the sum of the disk sizes in the pool, divided by the number of replicas of a replicated
+	// Filesystem
+	// +optional
+	Total *resource.Quantity `json:"total,omitempty"`
+
+	// Disks is the number of LocalDisks in the pool
+	// +optional
+	Disks int32 `json:"disks,omitempty"`
+}
+
+// AdoptionStatus reports the existing resources adopted by a claim with the adopt-filesystem annotation
+type AdoptionStatus struct {
+	// Filesystem is the name of the adopted Filesystem
+	Filesystem string `json:"filesystem"`
+
+	// LocalDisks are the names of the adopted LocalDisks used by the Filesystem
+	// +optional
+	LocalDisks []string `json:"localDisks,omitempty"`
+
+	// StorageClass is the name of the adopted StorageClass, empty when the StorageClass did not exist
+	// +optional
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// AdoptedAt is when the claim took ownership of the resources
+	// +optional
+	AdoptedAt *metav1.Time `json:"adoptedAt,omitempty"`
 }
 
 // FileSystemClaimStatus defines the observed state of FileSystemClaim.
 type FileSystemClaimStatus struct {
+	// ObservedGeneration is the generation of the claim last processed by the controller
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Overall conditions
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Devices is the inventory of the devices of the claim and their LocalDisks
+	// +optional
+	Devices []DeviceInventory `json:"devices,omitempty"`
+
+	// Filesystem reports the Filesystem created for the claim
+	// +optional
+	Filesystem *FilesystemInventory `json:"filesystem,omitempty"`
+
+	// StorageClassName is the name of the StorageClass created for the claim
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// Capacity reports the total and used capacity of the claim
+	// +optional
+	Capacity *CapacityStatus `json:"capacity,omitempty"`
+
+	// DeviceRemovals reports the progress of each device listed in spec.removeDevices
+	// +optional
+	DeviceRemovals []DeviceRemovalStatus `json:"deviceRemovals,omitempty"`
+
+	// ResolvedDevices are the WWNs of the devices selected by spec.deviceSelector
+	// +optional
+	ResolvedDevices []string `json:"resolvedDevices,omitempty"`
+
+	// Placements records the storage node chosen for each device
+	// +optional
+	Placements []DevicePlacement `json:"placements,omitempty"`
+
+	// Adoption reports the resources adopted through the adopt-filesystem annotation
+	// +optional
+	Adoption *AdoptionStatus `json:"adoption,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:shortName=fsc
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,priority=1
+// +kubebuilder:printcolumn:name="Filesystem",type=string,JSONPath=`.status.filesystem.name`
+// +kubebuilder:printcolumn:name="StorageClass",type=string,JSONPath=`.status.storageClassName`
+// +kubebuilder:printcolumn:name="Total",type=string,JSONPath=`.status.capacity.total`
+// +kubebuilder:printcolumn:name="Used",type=string,JSONPath=`.status.capacity.used`
+// +kubebuilder:printcolumn:name="Used%",type=integer,JSONPath=`.status.capacity.usedPercent`,priority=1
+// +kubebuilder:printcolumn:name="Mount Point",type=string,JSONPath=`.status.filesystem.mountPoint`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 //nolint:lll
 // +kubebuilder:webhook:verbs=create;update,path=/validate-fusion-storage-openshift-io-v1alpha1-filesystemclaim,mutating=false,failurePolicy=fail,groups=fusion.storage.openshift.io,resources=filesystemclaims,versions=v1alpha1,name=vfilesystemclaim.kb.io,admissionReviewVersions=v1,sideEffects=None
 
@@ -66,6 +483,15 @@ type FileSystemClaimList struct {
 	Items           []FileSystemClaim `json:"items"`
 }
 
+// StorageClassNameOf returns the name of the StorageClass of a FileSystemClaim: spec.storageClass.name, else the
+// name of the claim
+func StorageClassNameOf(fsc *FileSystemClaim) string {
+	if fsc.Spec.StorageClass != nil && fsc.Spec.StorageClass.Name != "" {
+		return fsc.Spec.StorageClass.Name
+	}
+	return fsc.Name
+}
+
 func init() {
 	SchemeBuilder.Register(&FileSystemClaim{}, &FileSystemClaimList{})
 }
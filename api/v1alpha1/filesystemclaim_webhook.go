@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -32,6 +33,9 @@ import (
 // log is for logging in this package.
 var logger = logf.Log.WithName("filesystemclaim-resource")
 
+// maxFilesystemPools is the maximum number of pools accepted by the Filesystem CRD
+const maxFilesystemPools = 8
+
 // +kubebuilder:object:generate=false
 // +k8s:deepcopy-gen=false
 // +k8s:openapi-gen=false
@@ -61,7 +65,32 @@ func (v *FileSystemClaimValidator) ValidateCreate(_ context.Context, obj runtime
 
 	logger.Info("validate create", "name", fsc.Name, "namespace", fsc.Namespace, "devices", fsc.Spec.Devices)
 
-	// Allow all creates - device validation will be performed by the controller
+	if err := validateFilesystemLayout(&fsc.Spec); err != nil {
+		logger.Info("rejecting create", "name", fsc.Name, "reason", err.Error())
+		return nil, err
+	}
+
+	if err := validateDeviceSelector(nil, fsc); err != nil {
+		logger.Info("rejecting create", "name", fsc.Name, "reason", err.Error())
+		return nil, err
+	}
+
+	if err := validateCapacityThresholds(&fsc.Spec); err != nil {
+		logger.Info("rejecting create", "name", fsc.Name, "reason", err.Error())
+		return nil, err
+	}
+
+	if err := validateAdoption(nil, fsc); err != nil {
+		logger.Info("rejecting create", "name", fsc.Name, "reason", err.Error())
+		return nil, err
+	}
+
+	if len(fsc.Spec.RemoveDevices) > 0 {
+		logger.Info("rejecting create", "name", fsc.Name, "reason", "removeDevices set")
+		return nil, errors.New("spec.removeDevices can only be set after the Filesystem is created")
+	}
+
+	// Device existence validation will be performed by the controller
 	return nil, nil
 }
 
@@ -85,6 +114,49 @@ func (v *FileSystemClaimValidator) ValidateUpdate(_ context.Context, oldObj, new
 		"oldDevices", oldFSC.Spec.Devices,
 		"newDevices", newFSC.Spec.Devices)
 
+	if err := validateFilesystemLayout(&newFSC.Spec); err != nil {
+		logger.Info("rejecting update", "name", newFSC.Name, "reason", err.Error())
+		return nil, err
+	}
+
+	if err := validateDeviceSelector(oldFSC, newFSC); err != nil {
+		logger.Info("rejecting update", "name", newFSC.Name, "reason", err.Error())
+		return nil, err
+	}
+
+	if err := validateCapacityThresholds(&newFSC.Spec); err != nil {
+		logger.Info("rejecting update", "name", newFSC.Name, "reason", err.Error())
+		return nil, err
+	}
+
+	if err := validateAdoption(oldFSC, newFSC); err != nil {
+		logger.Info("rejecting update", "name", newFSC.Name, "reason", err.Error())
+		return nil, err
+	}
+
+	if err := validateRemoveDevices(oldFSC, newFSC); err != nil {
+		logger.Info("rejecting update", "name", newFSC.Name, "reason", err.Error())
+		return nil, err
+	}
+
+	// The Filesystem layout cannot be changed once the Filesystem is created, except for
+	// assigning appended devices to pools
+	if !reflect.DeepEqual(oldFSC.Spec.Filesystem, newFSC.Spec.Filesystem) &&
+		meta.IsStatusConditionTrue(oldFSC.Status.Conditions, ConditionTypeFileSystemCreated) &&
+		!isFilesystemLayoutExpansion(&oldFSC.Spec, &newFSC.Spec) {
+		logger.Info("blocking filesystem layout update", "name", newFSC.Name, "reason", "FileSystemCreated=True")
+		return nil, errors.New("spec.filesystem cannot be modified after the Filesystem is created, " +
+			"except for adding appended devices to pools. " +
+			"To use a different layout, delete this FileSystemClaim and create a new one.")
+	}
+
+	// Renaming the StorageClass would orphan the existing one and the PVs provisioned from it
+	if StorageClassNameOf(oldFSC) != StorageClassNameOf(newFSC) &&
+		meta.IsStatusConditionTrue(oldFSC.Status.Conditions, ConditionTypeStorageClassCreated) {
+		logger.Info("blocking storage class rename", "name", newFSC.Name, "reason", "StorageClassCreated=True")
+		return nil, fmt.Errorf("spec.storageClass.name cannot be modified after the StorageClass %q is created", StorageClassNameOf(oldFSC))
+	}
+
 	// Check if spec.devices changed
 	if reflect.DeepEqual(oldFSC.Spec.Devices, newFSC.Spec.Devices) {
 		// No change to devices, allow the update
@@ -110,10 +182,25 @@ func (v *FileSystemClaimValidator) ValidateUpdate(_ context.Context, oldObj, new
 		return nil, nil
 	}
 
-	// LocalDiskCreated is True - block the update
+	// LocalDiskCreated is True - devices whose removal completed can be dropped
+	keptDevices := withoutRemovedDevices(oldFSC, newFSC.Spec.Devices)
+	if reflect.DeepEqual(keptDevices, newFSC.Spec.Devices) {
+		logger.Info("removed devices dropped, allowing update", "name", newFSC.Name)
+		return nil, nil
+	}
+
+	// Otherwise only allow appending devices to grow the Filesystem online
+	if isDeviceAppend(keptDevices, newFSC.Spec.Devices) {
+		logger.Info("devices appended, allowing expansion",
+			"name", newFSC.Name,
+			"added", newFSC.Spec.Devices[len(keptDevices):])
+		return nil, nil
+	}
+
 	timestamp := localDiskCreatedCond.LastTransitionTime.Format("2006-01-02 15:04:05 MST")
 	errMsg := fmt.Sprintf(
-		"spec.devices cannot be modified after LocalDisks are successfully created. "+
+		"spec.devices cannot be modified after LocalDisks are successfully created, "+
+			"new devices can only be appended to the end of the list. "+
 			"Current devices: %v. "+
 			"LocalDisks were created at %s. "+
 			"To use different devices, delete this FileSystemClaim and create a new one.",
@@ -139,6 +226,319 @@ func (v *FileSystemClaimValidator) ValidateDelete(_ context.Context, obj runtime
 	return nil, nil
 }
 
+// validateDeviceSelector checks that spec.deviceSelector is not combined with spec.devices or pools,
+// that it sets at least one criterion with a consistent size range, and that it is not changed once
+// status.resolvedDevices is recorded. oldFSC is nil on create.
+func validateDeviceSelector(oldFSC, newFSC *FileSystemClaim) error {
+	selector := newFSC.Spec.DeviceSelector
+
+	if oldFSC != nil && len(oldFSC.Status.ResolvedDevices) > 0 &&
+		!reflect.DeepEqual(oldFSC.Spec.DeviceSelector, selector) {
+		return fmt.Errorf("spec.deviceSelector cannot be modified after devices are resolved, resolved devices: %v",
+			oldFSC.Status.ResolvedDevices)
+	}
+
+	if selector == nil {
+		return nil
+	}
+
+	if len(newFSC.Spec.Devices) > 0 {
+		return errors.New("spec.devices and spec.deviceSelector are mutually exclusive")
+	}
+
+	if newFSC.Spec.Filesystem != nil && len(newFSC.Spec.Filesystem.Pools) > 0 {
+		return fmt.Errorf("spec.filesystem.pools cannot be used with spec.deviceSelector, "+
+			"all selected devices are placed in the %q pool", SystemPoolName)
+	}
+
+	if selector.Vendor == "" && selector.Model == "" && selector.MinSize == nil && selector.MaxSize == nil &&
+		len(selector.Types) == 0 && selector.WWNPrefix == "" {
+		return errors.New("spec.deviceSelector must set at least one criterion")
+	}
+
+	if selector.MinSize != nil && selector.MaxSize != nil && selector.MinSize.Cmp(*selector.MaxSize) > 0 {
+		return fmt.Errorf("spec.deviceSelector.minSize %s is greater than maxSize %s",
+			selector.MinSize.String(), selector.MaxSize.String())
+	}
+
+	return nil
+}
+
+// validateCapacityThresholds checks that the warning threshold is below the critical threshold once
+// both are defaulted
+func validateCapacityThresholds(spec *FileSystemClaimSpec) error {
+	if spec.CapacityThresholds == nil {
+		return nil
+	}
+
+	warning, critical := int32(DefaultCapacityWarningPercent), int32(DefaultCapacityCriticalPercent)
+	if spec.CapacityThresholds.WarningPercent != nil {
+		warning = *spec.CapacityThresholds.WarningPercent
+	}
+	if spec.CapacityThresholds.CriticalPercent != nil {
+		critical = *spec.CapacityThresholds.CriticalPercent
+	}
+	if warning >= critical {
+		return fmt.Errorf("spec.capacityThresholds.warningPercent (%d) must be lower than criticalPercent (%d)", warning, critical)
+	}
+	return nil
+}
+
+// validateFilesystemLayout checks that the pools in spec.filesystem only reference devices from
+// spec.devices, that no device is assigned twice and that the system pool is not left empty.
+// Block size and replication are validated by the CRD enums.
+func validateFilesystemLayout(spec *FileSystemClaimSpec) error {
+	if spec.Filesystem == nil {
+		return nil
+	}
+
+	devices := make(map[string]struct{}, len(spec.Devices))
+	for _, device := range spec.Devices {
+		devices[device] = struct{}{}
+	}
+
+	poolNames := make(map[string]struct{}, len(spec.Filesystem.Pools))
+	assigned := make(map[string]string)
+	for _, pool := range spec.Filesystem.Pools {
+		if _, dup := poolNames[pool.Name]; dup {
+			return fmt.Errorf("spec.filesystem.pools: duplicate pool name %q", pool.Name)
+		}
+		poolNames[pool.Name] = struct{}{}
+
+		for _, device := range pool.Devices {
+			if _, ok := devices[device]; !ok {
+				return fmt.Errorf("spec.filesystem.pools[%s]: device %s is not listed in spec.devices", pool.Name, device)
+			}
+			if other, ok := assigned[device]; ok {
+				return fmt.Errorf("spec.filesystem.pools[%s]: device %s is already assigned to pool %q", pool.Name, device, other)
+			}
+			assigned[device] = pool.Name
+		}
+	}
+
+	if _, hasSystem := poolNames[SystemPoolName]; !hasSystem {
+		if len(poolNames) >= maxFilesystemPools {
+			return fmt.Errorf("spec.filesystem.pools: at most %d pools are supported including the implicit %q pool",
+				maxFilesystemPools, SystemPoolName)
+		}
+		if len(devices) > 0 && len(assigned) == len(devices) {
+			return fmt.Errorf("spec.filesystem.pools: at least one device must be left for the %q pool", SystemPoolName)
+		}
+	}
+
+	return nil
+}
+
+// isDeviceAppend reports whether newDevices keeps every device of oldDevices in the same order
+// and only adds new devices at the end.
+func isDeviceAppend(oldDevices, newDevices []string) bool {
+	if len(newDevices) <= len(oldDevices) {
+		return false
+	}
+	if !reflect.DeepEqual(oldDevices, newDevices[:len(oldDevices)]) {
+		return false
+	}
+	seen := make(map[string]struct{}, len(newDevices))
+	for _, device := range newDevices {
+		if _, dup := seen[device]; dup {
+			return false
+		}
+		seen[device] = struct{}{}
+	}
+	return true
+}
+
+// isFilesystemLayoutExpansion reports whether the only change between the old and new spec.filesystem is
+// the assignment of newly appended devices to existing or new pools. Block size, replication and the pool
+// of every existing device must be unchanged.
+func isFilesystemLayoutExpansion(oldSpec, newSpec *FileSystemClaimSpec) bool {
+	oldLayout, newLayout := oldSpec.Filesystem, newSpec.Filesystem
+	if oldLayout == nil {
+		oldLayout = &FileSystemLayout{}
+	}
+	if newLayout == nil {
+		newLayout = &FileSystemLayout{}
+	}
+	if oldLayout.BlockSize != newLayout.BlockSize || oldLayout.Replication != newLayout.Replication {
+		return false
+	}
+
+	oldPoolOf := make(map[string]string)
+	for _, pool := range oldLayout.Pools {
+		for _, device := range pool.Devices {
+			oldPoolOf[device] = pool.Name
+		}
+	}
+	newPoolOf := make(map[string]string)
+	for _, pool := range newLayout.Pools {
+		for _, device := range pool.Devices {
+			newPoolOf[device] = pool.Name
+		}
+	}
+
+	poolOf := func(assigned map[string]string, device string) string {
+		if name, ok := assigned[device]; ok {
+			return name
+		}
+		return SystemPoolName
+	}
+	newDevices := make(map[string]struct{}, len(newSpec.Devices))
+	for _, device := range newSpec.Devices {
+		newDevices[device] = struct{}{}
+	}
+	for _, device := range oldSpec.Devices {
+		if _, kept := newDevices[device]; !kept {
+			// Dropping a removed device is validated with spec.devices
+			continue
+		}
+		if poolOf(oldPoolOf, device) != poolOf(newPoolOf, device) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateRemoveDevices checks that spec.removeDevices only lists devices from spec.devices, leaves at least
+// one device in every pool, is only extended once the Filesystem exists and does not cancel a removal that
+// is already draining a disk.
+func validateRemoveDevices(oldFSC, newFSC *FileSystemClaim) error {
+	newSpec := &newFSC.Spec
+
+	devices := make(map[string]struct{}, len(newSpec.Devices))
+	for _, device := range newSpec.Devices {
+		devices[device] = struct{}{}
+	}
+	removing := make(map[string]struct{}, len(newSpec.RemoveDevices))
+	for _, device := range newSpec.RemoveDevices {
+		if _, ok := devices[device]; !ok {
+			return fmt.Errorf("spec.removeDevices: device %s is not listed in spec.devices", device)
+		}
+		if _, dup := removing[device]; dup {
+			return fmt.Errorf("spec.removeDevices: device %s is listed more than once", device)
+		}
+		removing[device] = struct{}{}
+	}
+
+	// Every pool, including the implicit system pool, must keep at least one device
+	remaining := make(map[string]int)
+	poolOf := make(map[string]string)
+	if newSpec.Filesystem != nil {
+		for _, pool := range newSpec.Filesystem.Pools {
+			remaining[pool.Name] = 0
+			for _, device := range pool.Devices {
+				poolOf[device] = pool.Name
+			}
+		}
+	}
+	remaining[SystemPoolName] = 0
+	for _, device := range newSpec.Devices {
+		pool, ok := poolOf[device]
+		if !ok {
+			pool = SystemPoolName
+		}
+		if _, ok := removing[device]; !ok {
+			remaining[pool]++
+		}
+	}
+	for pool, count := range remaining {
+		if count == 0 && len(newSpec.RemoveDevices) > 0 {
+			return fmt.Errorf("spec.removeDevices: at least one device must be kept in pool %q", pool)
+		}
+	}
+
+	oldRemoving := make(map[string]struct{}, len(oldFSC.Spec.RemoveDevices))
+	for _, device := range oldFSC.Spec.RemoveDevices {
+		oldRemoving[device] = struct{}{}
+	}
+	for _, device := range newSpec.RemoveDevices {
+		if _, ok := oldRemoving[device]; !ok &&
+			!meta.IsStatusConditionTrue(oldFSC.Status.Conditions, ConditionTypeFileSystemCreated) {
+			return fmt.Errorf("spec.removeDevices: device %s cannot be removed before the Filesystem is created, "+
+				"remove it from spec.devices instead", device)
+		}
+	}
+	for _, removal := range oldFSC.Status.DeviceRemovals {
+		if _, ok := removing[removal.Device]; ok {
+			continue
+		}
+		if removal.Phase != DeviceRemovalPending && removal.Phase != DeviceRemovalRemoved {
+			return fmt.Errorf("spec.removeDevices: removal of device %s cannot be cancelled while in phase %s",
+				removal.Device, removal.Phase)
+		}
+	}
+
+	return nil
+}
+
+// validateAdoption checks the adopt-filesystem annotation. It can only be set when the claim is created, and
+// the adopted Filesystem keeps its own layout and disks: spec.deviceSelector and spec.filesystem cannot be
+// used, devices cannot be appended to spec.devices, and spec.devices and spec.removeDevices cannot be changed
+// once the adoption succeeded. oldFSC is nil on create.
+func validateAdoption(oldFSC, newFSC *FileSystemClaim) error {
+	fsName, adopting := newFSC.Annotations[AdoptFilesystemAnnotation]
+
+	if oldFSC != nil && oldFSC.Annotations[AdoptFilesystemAnnotation] != fsName {
+		return fmt.Errorf("the %s annotation can only be set when the FileSystemClaim is created", AdoptFilesystemAnnotation)
+	}
+
+	if !adopting {
+		return nil
+	}
+
+	if fsName == "" {
+		return fmt.Errorf("the %s annotation must name the Filesystem to adopt", AdoptFilesystemAnnotation)
+	}
+
+	if newFSC.Spec.DeviceSelector != nil {
+		return errors.New("spec.deviceSelector cannot be used when adopting a Filesystem, " +
+			"the devices are taken from its LocalDisks")
+	}
+
+	if newFSC.Spec.Filesystem != nil {
+		return errors.New("spec.filesystem cannot be used when adopting a Filesystem, its layout is kept as is")
+	}
+
+	if oldFSC != nil && meta.IsStatusConditionTrue(oldFSC.Status.Conditions, ConditionTypeAdopted) {
+		if !reflect.DeepEqual(oldFSC.Spec.Devices, newFSC.Spec.Devices) {
+			return fmt.Errorf("spec.devices cannot be modified on a FileSystemClaim that adopted Filesystem %q", fsName)
+		}
+		if len(newFSC.Spec.RemoveDevices) > 0 {
+			return fmt.Errorf("spec.removeDevices is not supported on a FileSystemClaim that adopted Filesystem %q", fsName)
+		}
+	}
+
+	// The LocalDisks of appended devices would be created but never added to the adopted Filesystem
+	if oldFSC != nil && len(oldFSC.Spec.Devices) > 0 && isDeviceAppend(oldFSC.Spec.Devices, newFSC.Spec.Devices) {
+		return fmt.Errorf("devices cannot be appended to a FileSystemClaim that adopts Filesystem %q, "+
+			"its disks are not managed by the claim", fsName)
+	}
+
+	return nil
+}
+
+// withoutRemovedDevices returns the devices of oldFSC without the ones that finished their removal and are
+// no longer listed in newDevices.
+func withoutRemovedDevices(oldFSC *FileSystemClaim, newDevices []string) []string {
+	listed := make(map[string]struct{}, len(newDevices))
+	for _, device := range newDevices {
+		listed[device] = struct{}{}
+	}
+	removed := make(map[string]struct{})
+	for _, removal := range oldFSC.Status.DeviceRemovals {
+		if _, ok := listed[removal.Device]; !ok && removal.Phase == DeviceRemovalRemoved {
+			removed[removal.Device] = struct{}{}
+		}
+	}
+
+	kept := make([]string, 0, len(oldFSC.Spec.Devices))
+	for _, device := range oldFSC.Spec.Devices {
+		if _, ok := removed[device]; !ok {
+			kept = append(kept, device)
+		}
+	}
+	return kept
+}
+
 func convertToFileSystemClaim(obj runtime.Object) (*FileSystemClaim, error) {
 	fsc, ok := obj.(*FileSystemClaim)
 	if !ok {
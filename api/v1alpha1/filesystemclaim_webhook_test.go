@@ -21,7 +21,9 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 var _ = Describe("FileSystemClaim Webhook", func() {
@@ -186,9 +188,9 @@ var _ = Describe("FileSystemClaim Webhook", func() {
 					errorSubstrings: []string{"spec.devices cannot be modified"},
 				},
 			),
-			Entry("reject adding device when LocalDiskCreated=True",
+			Entry("allow appending device when LocalDiskCreated=True",
 				updateTestCase{
-					description: "should reject adding device when LocalDiskCreated=True",
+					description: "should allow appending device when LocalDiskCreated=True",
 					oldDevices:  []string{"/dev/nvme1n1"},
 					newDevices:  []string{"/dev/nvme1n1", "/dev/nvme2n2"},
 					oldConditions: []metav1.Condition{
@@ -199,6 +201,39 @@ var _ = Describe("FileSystemClaim Webhook", func() {
 							LastTransitionTime: metav1.Now(),
 						},
 					},
+					expectError: false,
+				},
+			),
+			Entry("reject inserting device before existing devices when LocalDiskCreated=True",
+				updateTestCase{
+					description: "should reject inserting device before existing devices when LocalDiskCreated=True",
+					oldDevices:  []string{"/dev/nvme1n1"},
+					newDevices:  []string{"/dev/nvme2n2", "/dev/nvme1n1"},
+					oldConditions: []metav1.Condition{
+						{
+							Type:               "LocalDiskCreated",
+							Status:             metav1.ConditionTrue,
+							Reason:             "LocalDiskCreationSucceeded",
+							LastTransitionTime: metav1.Now(),
+						},
+					},
+					expectError:     true,
+					errorSubstrings: []string{"spec.devices cannot be modified", "only be appended"},
+				},
+			),
+			Entry("reject appending duplicate device when LocalDiskCreated=True",
+				updateTestCase{
+					description: "should reject appending duplicate device when LocalDiskCreated=True",
+					oldDevices:  []string{"/dev/nvme1n1"},
+					newDevices:  []string{"/dev/nvme1n1", "/dev/nvme1n1"},
+					oldConditions: []metav1.Condition{
+						{
+							Type:               "LocalDiskCreated",
+							Status:             metav1.ConditionTrue,
+							Reason:             "LocalDiskCreationSucceeded",
+							LastTransitionTime: metav1.Now(),
+						},
+					},
 					expectError:     true,
 					errorSubstrings: []string{"spec.devices cannot be modified"},
 				},
@@ -291,6 +326,471 @@ var _ = Describe("FileSystemClaim Webhook", func() {
 		})
 	})
 
+	Describe("spec.filesystem validation", func() {
+		DescribeTable("validateFilesystemLayout",
+			func(devices []string, layout *FileSystemLayout, errorSubstring string) {
+				err := validateFilesystemLayout(&FileSystemClaimSpec{Devices: devices, Filesystem: layout})
+				if errorSubstring == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring(errorSubstring))
+				}
+			},
+			Entry("no layout", []string{"/dev/sda"}, nil, ""),
+			Entry("block size and replication only", []string{"/dev/sda"},
+				&FileSystemLayout{BlockSize: "1M", Replication: "2-way"}, ""),
+			Entry("named pool with remaining devices in system", []string{"/dev/sda", "/dev/sdb"},
+				&FileSystemLayout{Pools: []FileSystemPool{{Name: "data", Devices: []string{"/dev/sdb"}}}}, ""),
+			Entry("explicit system pool", []string{"/dev/sda", "/dev/sdb"},
+				&FileSystemLayout{Pools: []FileSystemPool{
+					{Name: "system", Devices: []string{"/dev/sda"}},
+					{Name: "data", Devices: []string{"/dev/sdb"}},
+				}}, ""),
+			Entry("device not in spec.devices", []string{"/dev/sda", "/dev/sdb"},
+				&FileSystemLayout{Pools: []FileSystemPool{{Name: "data", Devices: []string{"/dev/sdc"}}}},
+				"not listed in spec.devices"),
+			Entry("device in two pools", []string{"/dev/sda", "/dev/sdb"},
+				&FileSystemLayout{Pools: []FileSystemPool{
+					{Name: "data", Devices: []string{"/dev/sdb"}},
+					{Name: "fast", Devices: []string{"/dev/sdb"}},
+				}}, "already assigned to pool"),
+			Entry("duplicate pool names", []string{"/dev/sda", "/dev/sdb", "/dev/sdc"},
+				&FileSystemLayout{Pools: []FileSystemPool{
+					{Name: "data", Devices: []string{"/dev/sdb"}},
+					{Name: "data", Devices: []string{"/dev/sdc"}},
+				}}, "duplicate pool name"),
+			Entry("no device left for system pool", []string{"/dev/sda"},
+				&FileSystemLayout{Pools: []FileSystemPool{{Name: "data", Devices: []string{"/dev/sda"}}}},
+				"at least one device must be left"),
+		)
+
+		It("should reject layout changes after the Filesystem is created", func() {
+			oldFSC := &FileSystemClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-fsc", Namespace: "ibm-spectrum-scale"},
+				Spec: FileSystemClaimSpec{
+					Devices:    []string{"/dev/nvme1n1"},
+					Filesystem: &FileSystemLayout{BlockSize: "4M"},
+				},
+				Status: FileSystemClaimStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:               ConditionTypeFileSystemCreated,
+							Status:             metav1.ConditionTrue,
+							Reason:             "FileSystemCreationSucceeded",
+							LastTransitionTime: metav1.Now(),
+						},
+					},
+				},
+			}
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.Filesystem.BlockSize = "1M"
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.filesystem cannot be modified"))
+		})
+
+		It("should allow assigning appended devices to pools after the Filesystem is created", func() {
+			oldFSC := &FileSystemClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-fsc", Namespace: "ibm-spectrum-scale"},
+				Spec: FileSystemClaimSpec{
+					Devices: []string{"/dev/nvme1n1", "/dev/nvme2n2"},
+					Filesystem: &FileSystemLayout{
+						Pools: []FileSystemPool{{Name: "data", Devices: []string{"/dev/nvme2n2"}}},
+					},
+				},
+				Status: FileSystemClaimStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:               ConditionTypeLocalDiskCreated,
+							Status:             metav1.ConditionTrue,
+							Reason:             "LocalDiskCreationSucceeded",
+							LastTransitionTime: metav1.Now(),
+						},
+						{
+							Type:               ConditionTypeFileSystemCreated,
+							Status:             metav1.ConditionTrue,
+							Reason:             "FileSystemCreationSucceeded",
+							LastTransitionTime: metav1.Now(),
+						},
+					},
+				},
+			}
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.Devices = append(newFSC.Spec.Devices, "/dev/nvme3n3")
+			newFSC.Spec.Filesystem.Pools[0].Devices = append(newFSC.Spec.Filesystem.Pools[0].Devices, "/dev/nvme3n3")
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("rejecting moving an existing device to another pool")
+			moved := oldFSC.DeepCopy()
+			moved.Spec.Filesystem.Pools[0].Devices = []string{"/dev/nvme1n1", "/dev/nvme2n2"}
+			moved.Spec.Devices = append(moved.Spec.Devices, "/dev/nvme3n3")
+
+			_, err = validator.ValidateUpdate(ctx, oldFSC, moved)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.filesystem cannot be modified"))
+		})
+
+		It("should allow layout changes before the Filesystem is created", func() {
+			oldFSC := &FileSystemClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-fsc", Namespace: "ibm-spectrum-scale"},
+				Spec: FileSystemClaimSpec{
+					Devices:    []string{"/dev/nvme1n1"},
+					Filesystem: &FileSystemLayout{BlockSize: "4M"},
+				},
+			}
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.Filesystem.BlockSize = "1M"
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("spec.storageClass validation", func() {
+		var oldFSC *FileSystemClaim
+
+		BeforeEach(func() {
+			oldFSC = &FileSystemClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-fsc", Namespace: "ibm-spectrum-scale"},
+				Spec: FileSystemClaimSpec{
+					Devices:      []string{"/dev/nvme1n1"},
+					StorageClass: &StorageClassTemplate{Name: "team-a"},
+				},
+			}
+		})
+
+		It("should reject renaming the StorageClass after it is created", func() {
+			oldFSC.Status.Conditions = []metav1.Condition{
+				{
+					Type:               ConditionTypeStorageClassCreated,
+					Status:             metav1.ConditionTrue,
+					Reason:             "StorageClassCreationSucceeded",
+					LastTransitionTime: metav1.Now(),
+				},
+			}
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.StorageClass.Name = "team-b"
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.storageClass.name cannot be modified"))
+		})
+
+		It("should allow renaming the StorageClass before it is created", func() {
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.StorageClass = nil
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("spec.removeDevices validation", func() {
+		var oldFSC *FileSystemClaim
+
+		BeforeEach(func() {
+			oldFSC = &FileSystemClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-fsc", Namespace: "ibm-spectrum-scale"},
+				Spec: FileSystemClaimSpec{
+					Devices: []string{"/dev/nvme1n1", "/dev/nvme2n2"},
+				},
+				Status: FileSystemClaimStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:               ConditionTypeLocalDiskCreated,
+							Status:             metav1.ConditionTrue,
+							Reason:             "LocalDiskCreationSucceeded",
+							LastTransitionTime: metav1.Now(),
+						},
+						{
+							Type:               ConditionTypeFileSystemCreated,
+							Status:             metav1.ConditionTrue,
+							Reason:             "FileSystemCreationSucceeded",
+							LastTransitionTime: metav1.Now(),
+						},
+					},
+				},
+			}
+		})
+
+		It("should reject removeDevices on create", func() {
+			fsc := oldFSC.DeepCopy()
+			fsc.Spec.RemoveDevices = []string{"/dev/nvme2n2"}
+
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("only be set after the Filesystem is created"))
+		})
+
+		It("should allow removing a device once the Filesystem is created", func() {
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.RemoveDevices = []string{"/dev/nvme2n2"}
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject removing a device before the Filesystem is created", func() {
+			oldFSC.Status.Conditions = oldFSC.Status.Conditions[:1]
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.RemoveDevices = []string{"/dev/nvme2n2"}
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot be removed before the Filesystem is created"))
+		})
+
+		It("should reject devices that are not in spec.devices", func() {
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.RemoveDevices = []string{"/dev/nvme3n3"}
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("not listed in spec.devices"))
+		})
+
+		It("should reject removing every device of a pool", func() {
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.RemoveDevices = []string{"/dev/nvme1n1", "/dev/nvme2n2"}
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`at least one device must be kept in pool "system"`))
+		})
+
+		It("should reject cancelling a removal that is draining the disk", func() {
+			oldFSC.Spec.RemoveDevices = []string{"/dev/nvme2n2"}
+			oldFSC.Status.DeviceRemovals = []DeviceRemovalStatus{
+				{Device: "/dev/nvme2n2", Phase: DeviceRemovalMigrating},
+			}
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.RemoveDevices = nil
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot be cancelled while in phase Migrating"))
+		})
+
+		It("should allow dropping a removed device from spec.devices", func() {
+			oldFSC.Spec.RemoveDevices = []string{"/dev/nvme2n2"}
+			oldFSC.Status.DeviceRemovals = []DeviceRemovalStatus{
+				{Device: "/dev/nvme2n2", Phase: DeviceRemovalRemoved},
+			}
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.Devices = []string{"/dev/nvme1n1"}
+			newFSC.Spec.RemoveDevices = nil
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject dropping a device whose removal is not complete", func() {
+			oldFSC.Spec.RemoveDevices = []string{"/dev/nvme2n2"}
+			oldFSC.Status.DeviceRemovals = []DeviceRemovalStatus{
+				{Device: "/dev/nvme2n2", Phase: DeviceRemovalPending},
+			}
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.Devices = []string{"/dev/nvme1n1"}
+			newFSC.Spec.RemoveDevices = nil
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("spec.devices cannot be modified"))
+		})
+	})
+
+	Describe("spec.deviceSelector validation", func() {
+		var fsc *FileSystemClaim
+
+		BeforeEach(func() {
+			fsc = &FileSystemClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-fsc", Namespace: "ibm-spectrum-scale"},
+				Spec: FileSystemClaimSpec{
+					DeviceSelector: &DeviceSelector{Vendor: "IBM"},
+				},
+			}
+		})
+
+		It("should allow a selector without spec.devices", func() {
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a selector combined with spec.devices", func() {
+			fsc.Spec.Devices = []string{"/dev/nvme1n1"}
+
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("mutually exclusive"))
+		})
+
+		It("should reject a selector combined with pools", func() {
+			fsc.Spec.Filesystem = &FileSystemLayout{
+				Pools: []FileSystemPool{{Name: "data", Devices: []string{"/dev/nvme1n1"}}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject an empty selector", func() {
+			fsc.Spec.DeviceSelector = &DeviceSelector{}
+
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("at least one criterion"))
+		})
+
+		It("should reject minSize greater than maxSize", func() {
+			fsc.Spec.DeviceSelector = &DeviceSelector{
+				MinSize: ptr.To(resource.MustParse("2Ti")),
+				MaxSize: ptr.To(resource.MustParse("1Ti")),
+			}
+
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("greater than maxSize"))
+		})
+
+		It("should reject changing the selector once devices are resolved", func() {
+			oldFSC := fsc.DeepCopy()
+			oldFSC.Status.ResolvedDevices = []string{"0x6005076810810261f800000000000a1b"}
+			newFSC := oldFSC.DeepCopy()
+			newFSC.Spec.DeviceSelector.Vendor = "NETAPP"
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, newFSC)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot be modified after devices are resolved"))
+		})
+
+		It("should allow changing the selector before devices are resolved", func() {
+			newFSC := fsc.DeepCopy()
+			newFSC.Spec.DeviceSelector.Vendor = "NETAPP"
+
+			_, err := validator.ValidateUpdate(ctx, fsc, newFSC)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("spec.capacityThresholds validation", func() {
+		var fsc *FileSystemClaim
+
+		BeforeEach(func() {
+			fsc = &FileSystemClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-fsc", Namespace: "ibm-spectrum-scale"},
+				Spec:       FileSystemClaimSpec{Devices: []string{"/dev/nvme1n1"}},
+			}
+		})
+
+		It("should allow a warning threshold below the critical threshold", func() {
+			fsc.Spec.CapacityThresholds = &CapacityThresholds{WarningPercent: ptr.To[int32](60), CriticalPercent: ptr.To[int32](70)}
+
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a warning threshold above the default critical threshold", func() {
+			fsc.Spec.CapacityThresholds = &CapacityThresholds{WarningPercent: ptr.To[int32](98)}
+
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must be lower than criticalPercent (95)"))
+		})
+
+		It("should reject equal thresholds on update", func() {
+			newFSC := fsc.DeepCopy()
+			newFSC.Spec.CapacityThresholds = &CapacityThresholds{WarningPercent: ptr.To[int32](90), CriticalPercent: ptr.To[int32](90)}
+
+			_, err := validator.ValidateUpdate(ctx, fsc, newFSC)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("adopt-filesystem annotation validation", func() {
+		var fsc *FileSystemClaim
+
+		BeforeEach(func() {
+			fsc = &FileSystemClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-fsc",
+					Namespace:   "ibm-spectrum-scale",
+					Annotations: map[string]string{AdoptFilesystemAnnotation: "legacy-fs"},
+				},
+			}
+		})
+
+		It("should allow adopting a Filesystem without spec.devices", func() {
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject an empty Filesystem name", func() {
+			fsc.Annotations[AdoptFilesystemAnnotation] = ""
+
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("must name the Filesystem"))
+		})
+
+		It("should reject a layout for the adopted Filesystem", func() {
+			fsc.Spec.Filesystem = &FileSystemLayout{BlockSize: "1M"}
+
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("layout is kept as is"))
+		})
+
+		It("should reject a device selector", func() {
+			fsc.Spec.DeviceSelector = &DeviceSelector{Vendor: "IBM"}
+
+			_, err := validator.ValidateCreate(ctx, fsc)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should reject adding the annotation to an existing claim", func() {
+			oldFSC := fsc.DeepCopy()
+			oldFSC.Annotations = nil
+
+			_, err := validator.ValidateUpdate(ctx, oldFSC, fsc)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("can only be set when the FileSystemClaim is created"))
+		})
+
+		It("should allow the controller to fill spec.devices before the adoption", func() {
+			newFSC := fsc.DeepCopy()
+			newFSC.Spec.Devices = []string{"/dev/nvme1n1"}
+
+			_, err := validator.ValidateUpdate(ctx, fsc, newFSC)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject appending devices to a claim adopting a Filesystem", func() {
+			fsc.Spec.Devices = []string{"/dev/nvme1n1"}
+			fsc.Status.Conditions = []metav1.Condition{{Type: ConditionTypeLocalDiskCreated, Status: metav1.ConditionTrue}}
+			newFSC := fsc.DeepCopy()
+			newFSC.Spec.Devices = []string{"/dev/nvme1n1", "/dev/nvme2n1"}
+
+			_, err := validator.ValidateUpdate(ctx, fsc, newFSC)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cannot be appended to a FileSystemClaim that adopts Filesystem"))
+		})
+
+		It("should reject changing spec.devices once the Filesystem is adopted", func() {
+			fsc.Spec.Devices = []string{"/dev/nvme1n1"}
+			fsc.Status.Conditions = []metav1.Condition{{Type: ConditionTypeAdopted, Status: metav1.ConditionTrue}}
+			newFSC := fsc.DeepCopy()
+			newFSC.Spec.Devices = []string{"/dev/nvme1n1", "/dev/nvme2n1"}
+
+			_, err := validator.ValidateUpdate(ctx, fsc, newFSC)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("adopted Filesystem"))
+		})
+	})
+
 	Describe("ValidateDelete", func() {
 		It("should allow deletion", func() {
 			fsc := &FileSystemClaim{
@@ -30,7 +30,8 @@ type FusionAccessSpec struct {
 	// NOTE(bandini): If you change anything in the following three lines you need to update
 	// ./scripts/update-cnsa-versions-metadata.sh
 
-	// Version of IBM Fusion installation manifest
+	// Version of IBM Fusion installation manifest, one of the versions bundled with the operator.
+	// The latest bundled version is used when it is empty.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="IBM Storage Scale Version",order=2,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:v5.2.3.5-2025.11.03.15.59.23"}
 	StorageScaleVersion StorageScaleVersions `json:"storageScaleVersion,omitempty"`
 
@@ -39,10 +40,100 @@ type FusionAccessSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=4,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
 	// +kubebuilder:validation:Format=uri
 	ExternalManifestURL string `json:"externalManifestURL,omitempty"`
+
+	// TeardownOnDelete removes the resources created by the operator when the FusionAccess is deleted:
+	// the device discovery, the console plugin, the kernel module, the Storage Scale manifest and the
+	// entitlement secrets. The deletion waits until no FileSystemClaim is left.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remove Storage Scale on delete",order=5,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +optional
+	TeardownOnDelete bool `json:"teardownOnDelete,omitempty"`
+
+	// ApprovedStorageScaleVersion approves the upgrade of IBM Storage Scale to this version. An upgrade to a
+	// version bundled with the operator waits until it passes its preflight checks and is approved here.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Approved IBM Storage Scale Upgrade",order=6,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +optional
+	ApprovedStorageScaleVersion StorageScaleVersions `json:"approvedStorageScaleVersion,omitempty"`
+
+	// ManifestOverrides customizes the IBM Storage Scale install manifest before it is applied
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Manifest Overrides",order=7,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden"}
+	// +optional
+	ManifestOverrides *ManifestOverrides `json:"manifestOverrides,omitempty"`
+}
+
+// ManifestOverrides customizes the objects of the IBM Storage Scale install manifest. The patches are applied
+// first, then the images are replaced and the labels added.
+type ManifestOverrides struct {
+	// Patches are applied in order to the objects they target
+	// +optional
+	Patches []ManifestPatch `json:"patches,omitempty"`
+	// Images replaces image references, for instance to pull from a mirrored registry. They apply to the
+	// containers of the objects and to the images of the IBM Storage Scale manager configuration.
+	// +optional
+	Images []ImageOverride `json:"images,omitempty"`
+	// Labels are added to every object of the manifest
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ManifestPatchType is the format of a manifest patch
+// +kubebuilder:validation:Enum=StrategicMerge;Merge;JSON
+type ManifestPatchType string
+
+const (
+	// StrategicMergePatch is a Kubernetes strategic merge patch, a JSON merge patch for custom resources
+	StrategicMergePatch ManifestPatchType = "StrategicMerge"
+	// MergePatch is a JSON merge patch (RFC 7386)
+	MergePatch ManifestPatchType = "Merge"
+	// JSONPatch is a JSON patch (RFC 6902)
+	JSONPatch ManifestPatchType = "JSON"
+)
+
+// ManifestPatch patches the objects of the manifest matching its target
+type ManifestPatch struct {
+	// Target selects the objects to patch
+	Target ManifestPatchTarget `json:"target"`
+	// Type of the patch
+	// +kubebuilder:default=StrategicMerge
+	// +optional
+	Type ManifestPatchType `json:"type,omitempty"`
+	// Patch is the patch, in YAML or JSON
+	// +kubebuilder:validation:MinLength=1
+	Patch string `json:"patch"`
+}
+
+// ManifestPatchTarget selects objects of the manifest by group, version, kind, namespace and name. The empty
+// fields match any value.
+type ManifestPatchTarget struct {
+	// +optional
+	Group string `json:"group,omitempty"`
+	// +optional
+	Version string `json:"version,omitempty"`
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// ImageOverride replaces an image, or every image under a repository prefix
+type ImageOverride struct {
+	// Source is the image or the repository prefix to replace, such as quay.io/openshift-storage-scale
+	// +kubebuilder:validation:MinLength=1
+	Source string `json:"source"`
+	// Mirror replaces Source in the image references
+	// +kubebuilder:validation:MinLength=1
+	Mirror string `json:"mirror"`
 }
 type StorageDeviceDiscovery struct {
 	// +kubebuilder:default:=true
 	Create bool `json:"create,omitempty"`
+	// InclusionSpec selects the devices to discover
+	// +optional
+	InclusionSpec *DeviceInclusionSpec `json:"inclusionSpec,omitempty"`
+	// ExclusionSpec drops devices from the discovery
+	// +optional
+	ExclusionSpec *DeviceExclusionSpec `json:"exclusionSpec,omitempty"`
 }
 
 // FusionAccessStatus defines the observed state of FusionAccess
@@ -58,6 +149,96 @@ type FusionAccessStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// Show the general status of the fusion access object (this can be shown nicely on ocp console UI)
 	Status string `json:"status,omitempty"`
+	// SupportedStorageScaleVersions are the IBM Storage Scale versions bundled with the operator, oldest first.
+	// spec.storageScaleVersion selects one of them, the latest is installed when it is empty or not bundled.
+	// +optional
+	SupportedStorageScaleVersions []string `json:"supportedStorageScaleVersions,omitempty"`
+	// ManifestApply reports the last apply of the IBM Storage Scale install manifest
+	// +optional
+	ManifestApply *ManifestApplyStatus `json:"manifestApply,omitempty"`
+	// Upgrade reports the upgrade of IBM Storage Scale to a newer version bundled with the operator
+	// +optional
+	Upgrade *StorageScaleUpgradeStatus `json:"upgrade,omitempty"`
+}
+
+// ManifestApplyStatus reports an apply of the IBM Storage Scale install manifest
+type ManifestApplyStatus struct {
+	// Version is the IBM Storage Scale version of the manifest, empty for an external manifest
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Applied is the number of objects applied because their manifest changed or they were missing
+	Applied int32 `json:"applied"`
+	// Unchanged is the number of objects skipped because they were already applied
+	Unchanged int32 `json:"unchanged"`
+	// Pruned is the number of objects deleted because they are no longer in the manifest
+	Pruned int32 `json:"pruned"`
+	// Failures lists per kind the objects that could not be applied or pruned
+	// +optional
+	Failures []ManifestApplyFailure `json:"failures,omitempty"`
+	// LastApplyTime is when the manifest was last applied
+	// +optional
+	LastApplyTime *metav1.Time `json:"lastApplyTime,omitempty"`
+}
+
+// ManifestApplyFailure gathers the objects of one kind that could not be applied or pruned
+type ManifestApplyFailure struct {
+	// Kind of the objects
+	Kind string `json:"kind"`
+	// Objects are the namespaced names of the objects
+	Objects []string `json:"objects"`
+	// Message is the error of the first object
+	Message string `json:"message"`
+}
+
+// StorageScaleUpgradePhase is the phase of an IBM Storage Scale upgrade
+// +kubebuilder:validation:Enum=Blocked;PreflightFailed;AwaitingApproval;Upgrading;Completed
+type StorageScaleUpgradePhase string
+
+const (
+	// UpgradePhaseBlocked means the upgrade path from the installed version is not supported
+	UpgradePhaseBlocked StorageScaleUpgradePhase = "Blocked"
+	// UpgradePhasePreflightFailed means at least one preflight check failed, they are run again periodically
+	UpgradePhasePreflightFailed StorageScaleUpgradePhase = "PreflightFailed"
+	// UpgradePhaseAwaitingApproval means the preflight checks passed and the upgrade waits for
+	// spec.approvedStorageScaleVersion
+	UpgradePhaseAwaitingApproval StorageScaleUpgradePhase = "AwaitingApproval"
+	// UpgradePhaseUpgrading means the manifest of the new version is applied and the kernel module is rebuilt
+	UpgradePhaseUpgrading StorageScaleUpgradePhase = "Upgrading"
+	// UpgradePhaseCompleted means the new version is installed and its kernel module was built
+	UpgradePhaseCompleted StorageScaleUpgradePhase = "Completed"
+)
+
+// UpgradePreflightCheck is the result of a check run before an upgrade
+type UpgradePreflightCheck struct {
+	// Name of the check
+	Name string `json:"name"`
+	// Passed reports whether the check passed
+	Passed bool `json:"passed"`
+	// Message explains the result
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// StorageScaleUpgradeStatus reports an IBM Storage Scale upgrade
+type StorageScaleUpgradeStatus struct {
+	// FromVersion is the version installed before the upgrade
+	FromVersion string `json:"fromVersion"`
+	// ToVersion is the version bundled with the operator
+	ToVersion string `json:"toVersion"`
+	// Phase of the upgrade
+	Phase StorageScaleUpgradePhase `json:"phase"`
+	// Message describes the phase
+	// +optional
+	Message string `json:"message,omitempty"`
+	// PreflightChecks are the results of the last preflight run
+	// +optional
+	PreflightChecks []UpgradePreflightCheck `json:"preflightChecks,omitempty"`
+	// StartTime is when the approved upgrade started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is when the upgrade completed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -18,12 +18,18 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
+	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
 	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,11 +50,30 @@ var fusionaccesslog = logf.Log.WithName("fusionaccess-resource")
 // NOTE: The +kubebuilder:object:generate=false and +k8s:deepcopy-gen=false marker prevents controller-gen from generating DeepCopy methods,
 // as it is used only for temporary operations and does not need to be deeply copied.
 type FusionAccessValidator struct {
-	Client       client.Client
-	config       *rest.Config
-	configClient configclient.Interface
+	Client client.Client
+	// CompatibilityMode decides what happens when the IBM Storage Scale version does not support the cluster
+	CompatibilityMode CompatibilityMode
+	config            *rest.Config
+	configClient      configclient.Interface
 }
 
+// CompatibilityMode is how the webhook handles an IBM Storage Scale version that does not support the
+// OpenShift version or the node architectures of the cluster
+type CompatibilityMode string
+
+const (
+	// CompatibilityModeWarn admits the FusionAccess with admission warnings, so that upcoming OpenShift
+	// versions can be tested before IBM supports them
+	CompatibilityModeWarn CompatibilityMode = "warn"
+	// CompatibilityModeEnforce rejects the FusionAccess
+	CompatibilityModeEnforce CompatibilityMode = "enforce"
+
+	// CompatibilityModeEnvVar is the operator environment variable setting the compatibility mode
+	CompatibilityModeEnvVar = "COMPATIBILITY_MODE"
+
+	workerNodeRoleLabel = "node-role.kubernetes.io/worker"
+)
+
 // FIXME(bandini): This needs to be reviewed more in detail. I added sideEffects=none to get it passing but not 100% sure about it
 //nolint:lll
 // +kubebuilder:webhook:verbs=create;update,path=/validate-fusion-storage-openshift-io-v1alpha1-fusionaccess,mutating=false,failurePolicy=fail,groups=fusion.storage.openshift.io,resources=fusionaccesses,versions=v1alpha1,name=fusion.storage.openshift.io,admissionReviewVersions=v1,sideEffects=none
@@ -59,6 +84,12 @@ var _ webhook.CustomValidator = &FusionAccessValidator{}
 func (r *FusionAccessValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	r.Client = mgr.GetClient()
 	r.config = mgr.GetConfig()
+	switch r.CompatibilityMode {
+	case CompatibilityModeWarn, CompatibilityModeEnforce:
+	default:
+		fusionaccesslog.Info("unknown compatibility mode, warning only", "mode", r.CompatibilityMode)
+		r.CompatibilityMode = CompatibilityModeWarn
+	}
 	var err error
 	if r.configClient, err = configclient.NewForConfig(r.config); err != nil {
 		return err
@@ -85,29 +116,67 @@ func (r *FusionAccessValidator) ValidateCreate(ctx context.Context, obj runtime.
 	if len(fusionaccesses.Items) > 0 {
 		return nil, fmt.Errorf("only one FusionAccess resource is allowed")
 	}
+	if err := validateManifestOverrides(p.Spec.ManifestOverrides); err != nil {
+		return nil, err
+	}
+	if err := validateDiscoveryFilters(p.Spec.LocalVolumeDiscovery); err != nil {
+		return nil, err
+	}
+
+	return r.validateCompatibility(ctx, p.Name, string(p.Spec.StorageScaleVersion))
+}
 
-	clusterVersions, err := r.configClient.ConfigV1().ClusterVersions().Get(context.Background(), "version", metav1.GetOptions{})
+// validateCompatibility checks that the IBM Storage Scale version that gets installed supports the OpenShift
+// version and the architectures of the worker nodes, and warns or rejects according to the compatibility mode
+func (r *FusionAccessValidator) validateCompatibility(ctx context.Context, name, version string) (admission.Warnings, error) {
+	// The latest bundled version is installed when the version is empty or not bundled
+	if cnsaVersion, _, err := utils.GetStorageScaleVersion(version); err == nil {
+		version = cnsaVersion
+	}
+
+	clusterVersions, err := r.configClient.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list ClusterVersions: %v", err)
 	}
-
-	// Check if the IBM version we are running is an allowed one
 	ocpVersion, err := utils.GetCurrentClusterVersion(clusterVersions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current cluster version: %v", err)
 	}
-	if !utils.IsOpenShiftSupported(string(p.Spec.StorageScaleVersion), *ocpVersion) {
-		// FIXME(bandini): we currently only log this so QE can test on upcoming versions that are not yet supported by IBM
-		fusionaccesslog.Info("IBM Storage Scale version not supported", "OCP Version", ocpVersion, "IBM Storage ScaleVersion", p.Spec.StorageScaleVersion)
-		// FIXME(bandini): return nil, fmt.Errorf("IBM Storage Scale version %s is not supported", ocpVersion)
-	} else {
-		fusionaccesslog.Info("validate create", "name", p.Name, "OCP Version", ocpVersion, "IBM Storage Scale Version", p.Spec.StorageScaleVersion)
+
+	var nodes corev1.NodeList
+	if err := r.Client.List(ctx, &nodes, client.HasLabels{workerNodeRoleLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %v", err)
 	}
-	return nil, nil
+	var architectures []string
+	for _, node := range nodes.Items {
+		if arch := node.Status.NodeInfo.Architecture; arch != "" && !slices.Contains(architectures, arch) {
+			architectures = append(architectures, arch)
+		}
+	}
+
+	problems := utils.StorageScaleCompatibilityProblems(version, *ocpVersion, architectures)
+	fusionaccesslog.Info("validate compatibility", "name", name, "OCP Version", ocpVersion,
+		"IBM Storage Scale Version", version, "architectures", architectures, "problems", problems)
+	return r.CompatibilityMode.admit(version, problems)
+}
+
+// admit turns compatibility problems into admission warnings, or into a rejection in enforce mode
+func (m CompatibilityMode) admit(version string, problems []string) (admission.Warnings, error) {
+	if len(problems) == 0 {
+		return nil, nil
+	}
+	if m == CompatibilityModeEnforce {
+		return nil, fmt.Errorf("IBM Storage Scale %s is not supported on this cluster: %s", version, strings.Join(problems, "; "))
+	}
+	warnings := make(admission.Warnings, 0, len(problems))
+	for _, problem := range problems {
+		warnings = append(warnings, fmt.Sprintf("IBM Storage Scale %s: %s", version, problem))
+	}
+	return warnings, nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
-func (r *FusionAccessValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (r *FusionAccessValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	p, err := convertToFusionAccess(oldObj)
 	if err != nil {
 		fusionaccesslog.Error(err, "validate update", "name", p.Name)
@@ -119,12 +188,6 @@ func (r *FusionAccessValidator) ValidateUpdate(_ context.Context, oldObj, newObj
 		return nil, err
 	}
 
-	// FIXME(bandini): IBM CNSA version cannot be updated for now
-	// FIXME(bandini): Maybe here we could introduce code to double check which upgrades paths we allow
-	// but for now we just log things
-	// if pNew.Spec.IbmCnsaVersion != p.Spec.IbmCnsaVersion {
-	// 	return nil, fmt.Errorf("IBM CNSA version cannot be updated")
-	// }
 	fusionaccesslog.Info(
 		"validate update",
 		"name",
@@ -134,8 +197,92 @@ func (r *FusionAccessValidator) ValidateUpdate(_ context.Context, oldObj, newObj
 		"old version",
 		p.Spec.StorageScaleVersion,
 	)
+	if err := validateStorageScaleVersionUpdate(p, pNew); err != nil {
+		return nil, err
+	}
+	if err := validateManifestOverrides(pNew.Spec.ManifestOverrides); err != nil {
+		return nil, err
+	}
+	if err := validateDiscoveryFilters(pNew.Spec.LocalVolumeDiscovery); err != nil {
+		return nil, err
+	}
 
-	return nil, nil
+	// Check the version about to be installed: the approved upgrade, else the selected version
+	version := pNew.Spec.StorageScaleVersion
+	if pNew.Spec.ApprovedStorageScaleVersion != "" {
+		version = pNew.Spec.ApprovedStorageScaleVersion
+	}
+	if version == p.Spec.StorageScaleVersion || version == p.Spec.ApprovedStorageScaleVersion {
+		return nil, nil
+	}
+	return r.validateCompatibility(ctx, pNew.Name, string(version))
+}
+
+// validateStorageScaleVersionUpdate only lets storageScaleVersion and approvedStorageScaleVersion move along
+// the supported upgrade paths. An installed storageScaleVersion only changes to the approved version, once the
+// operator started that upgrade: editing it directly would skip the preflight checks and the approval.
+func validateStorageScaleVersionUpdate(oldObj, newObj *FusionAccess) error {
+	oldVersion := string(oldObj.Spec.StorageScaleVersion)
+	newVersion := string(newObj.Spec.StorageScaleVersion)
+	if oldVersion != "" && newVersion != oldVersion {
+		if err := utils.IsStorageScaleUpgradeAllowed(oldVersion, newVersion); err != nil {
+			return fmt.Errorf("spec.storageScaleVersion cannot be changed from %s to %s: %w", oldVersion, newVersion, err)
+		}
+		if newVersion != string(newObj.Spec.ApprovedStorageScaleVersion) {
+			return fmt.Errorf("spec.storageScaleVersion cannot be changed from %s to %s: "+
+				"set spec.approvedStorageScaleVersion to %s and the operator upgrades once the preflight checks pass",
+				oldVersion, newVersion, newVersion)
+		}
+		upgrade := oldObj.Status.Upgrade
+		if upgrade == nil || upgrade.Phase != UpgradePhaseUpgrading || upgrade.FromVersion != oldVersion || upgrade.ToVersion != newVersion {
+			return fmt.Errorf("spec.storageScaleVersion cannot be changed from %s to %s: "+
+				"the operator did not start this upgrade, it is changed once the preflight checks pass", oldVersion, newVersion)
+		}
+	}
+
+	approved := string(newObj.Spec.ApprovedStorageScaleVersion)
+	if approved == "" || approved == newVersion || newVersion == "" {
+		return nil
+	}
+	if err := utils.IsStorageScaleUpgradeAllowed(newVersion, approved); err != nil {
+		return fmt.Errorf("spec.approvedStorageScaleVersion %s cannot be approved from %s: %w", approved, newVersion, err)
+	}
+	return nil
+}
+
+// validateManifestOverrides checks that the manifest patches can be decoded, so that a broken patch is
+// rejected instead of failing every reconcile
+func validateManifestOverrides(overrides *ManifestOverrides) error {
+	if overrides == nil {
+		return nil
+	}
+	for i, patch := range overrides.Patches {
+		field := fmt.Sprintf("spec.manifestOverrides.patches[%d].patch", i)
+		patchJSON, err := yaml.ToJSON([]byte(patch.Patch))
+		if err != nil {
+			return fmt.Errorf("%s is not valid YAML or JSON: %w", field, err)
+		}
+		if patch.Type == JSONPatch {
+			if _, err := jsonpatch.DecodePatch(patchJSON); err != nil {
+				return fmt.Errorf("%s is not a valid JSON patch: %w", field, err)
+			}
+			continue
+		}
+		var obj map[string]any
+		if err := json.Unmarshal(patchJSON, &obj); err != nil {
+			return fmt.Errorf("%s must be an object: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// validateDiscoveryFilters checks the expressions and the patterns of the device discovery filters, which
+// the discovery would otherwise run without
+func validateDiscoveryFilters(discovery StorageDeviceDiscovery) error {
+	if err := ValidateDeviceFilters(discovery.InclusionSpec, discovery.ExclusionSpec); err != nil {
+		return fmt.Errorf("spec.storageDeviceDiscovery.%w", err)
+	}
+	return nil
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -17,7 +17,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/Masterminds/semver/v3"
 	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
 )
 
 var _ = Describe("FusionAccess Webhook", func() {
@@ -44,4 +49,146 @@ var _ = Describe("FusionAccess Webhook", func() {
 		})
 	})
 
+	Context("When updating the IBM Storage Scale version under Validating Webhook", func() {
+		const (
+			installed = "v5.2.3.1"
+			bundled   = "v5.2.3.5-2025.11.03.15.59.23"
+		)
+
+		newFusionAccess := func(version, approved string) *FusionAccess {
+			return &FusionAccess{Spec: FusionAccessSpec{
+				StorageScaleVersion:         StorageScaleVersions(version),
+				ApprovedStorageScaleVersion: StorageScaleVersions(approved),
+			}}
+		}
+
+		upgrading := func(fa *FusionAccess) *FusionAccess {
+			fa.Status.Upgrade = &StorageScaleUpgradeStatus{
+				FromVersion: string(fa.Spec.StorageScaleVersion),
+				ToVersion:   string(fa.Spec.ApprovedStorageScaleVersion),
+				Phase:       UpgradePhaseUpgrading,
+			}
+			return fa
+		}
+
+		It("Should admit the approval of a supported upgrade", func() {
+			Expect(validateStorageScaleVersionUpdate(newFusionAccess(installed, ""), newFusionAccess(installed, bundled))).To(Succeed())
+			Expect(validateStorageScaleVersionUpdate(upgrading(newFusionAccess(installed, bundled)), newFusionAccess(bundled, bundled))).To(Succeed())
+		})
+
+		It("Should deny changing the version to one that was not approved", func() {
+			Expect(validateStorageScaleVersionUpdate(newFusionAccess(installed, ""), newFusionAccess(bundled, ""))).
+				To(MatchError(ContainSubstring("set spec.approvedStorageScaleVersion")))
+		})
+
+		It("Should deny changing the version before the operator started the approved upgrade", func() {
+			Expect(validateStorageScaleVersionUpdate(newFusionAccess(installed, bundled), newFusionAccess(bundled, bundled))).
+				To(MatchError(ContainSubstring("the operator did not start this upgrade")))
+		})
+
+		It("Should deny a downgrade", func() {
+			Expect(validateStorageScaleVersionUpdate(newFusionAccess(bundled, ""), newFusionAccess(installed, ""))).NotTo(Succeed())
+			Expect(validateStorageScaleVersionUpdate(newFusionAccess(bundled, ""), newFusionAccess(bundled, installed))).NotTo(Succeed())
+		})
+
+		It("Should deny an unsupported upgrade path", func() {
+			Expect(validateStorageScaleVersionUpdate(newFusionAccess("v5.2.2.1", ""), newFusionAccess(bundled, ""))).NotTo(Succeed())
+		})
+
+		It("Should admit setting the version of a fresh install", func() {
+			Expect(validateStorageScaleVersionUpdate(newFusionAccess("", ""), newFusionAccess(bundled, ""))).To(Succeed())
+		})
+	})
+
+	Context("When the IBM Storage Scale version does not support the cluster", func() {
+		problems := []string{"OpenShift 4.22.0 is not supported, supported levels are [4.19]"}
+
+		It("Should admit with warnings in warn mode", func() {
+			warnings, err := CompatibilityModeWarn.admit("v5.2.3.5", problems)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("OpenShift 4.22.0")))
+		})
+
+		It("Should deny in enforce mode", func() {
+			warnings, err := CompatibilityModeEnforce.admit("v5.2.3.5", problems)
+			Expect(err).To(MatchError(ContainSubstring("OpenShift 4.22.0")))
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should admit a supported version in enforce mode", func() {
+			warnings, err := CompatibilityModeEnforce.admit("v5.2.3.5", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+	})
+
+	Context("When checking the compatibility of the IBM Storage Scale releases", func() {
+		DescribeTable("Should admit only the OpenShift versions and architectures the release supports",
+			func(version, ocpVersion string, architectures []string, rejection string) {
+				ocp, err := semver.NewVersion(ocpVersion)
+				Expect(err).NotTo(HaveOccurred())
+
+				problems := utils.StorageScaleCompatibilityProblems(version, *ocp, architectures)
+				_, err = CompatibilityModeEnforce.admit(version, problems)
+				if rejection == "" {
+					Expect(err).NotTo(HaveOccurred())
+				} else {
+					Expect(err).To(MatchError(ContainSubstring(rejection)))
+				}
+			},
+			Entry("a supported OpenShift version", "v5.2.3.1", "4.19.3", []string{"amd64"}, ""),
+			Entry("a supported OpenShift version on every architecture", "v5.2.2.1", "4.15.20", []string{"amd64", "s390x", "ppc64le"}, ""),
+			Entry("an OpenShift version that is too recent", "v5.2.2.0", "4.18.1", []string{"amd64"},
+				"OpenShift 4.18.1 is not supported, supported levels are [4.15 4.16 4.17]"),
+			Entry("an OpenShift version that is too old", "v5.2.3.0", "4.15.10", []string{"amd64"}, "OpenShift 4.15.10 is not supported"),
+			Entry("an unsupported architecture", "v5.2.3.1", "4.18.2", []string{"arm64"}, "architecture aarch64 is not supported"),
+			Entry("an unknown release", "v5.1.9.0", "4.18.2", []string{"amd64"}, "no compatibility data for IBM Storage Scale v5.1.9.0"),
+		)
+	})
+
+	Context("When validating the manifest overrides", func() {
+		target := ManifestPatchTarget{Kind: "Deployment", Name: "ibm-spectrum-scale-controller-manager"}
+
+		It("Should admit valid patches", func() {
+			Expect(validateManifestOverrides(&ManifestOverrides{Patches: []ManifestPatch{
+				{Target: target, Type: StrategicMergePatch, Patch: "spec:\n  replicas: 2\n"},
+				{Target: target, Type: JSONPatch, Patch: `[{"op": "add", "path": "/spec/paused", "value": true}]`},
+			}})).To(Succeed())
+			Expect(validateManifestOverrides(nil)).To(Succeed())
+		})
+
+		It("Should deny a patch that cannot be decoded", func() {
+			Expect(validateManifestOverrides(&ManifestOverrides{Patches: []ManifestPatch{
+				{Target: target, Type: JSONPatch, Patch: `{"op": "add"}`},
+			}})).To(MatchError(ContainSubstring("patches[0].patch is not a valid JSON patch")))
+			Expect(validateManifestOverrides(&ManifestOverrides{Patches: []ManifestPatch{
+				{Target: target, Type: MergePatch, Patch: "- replicas: 2"},
+			}})).To(MatchError(ContainSubstring("patches[0].patch must be an object")))
+		})
+	})
+
+	Context("When validating the device discovery filters", func() {
+		It("Should admit valid filters", func() {
+			minSize, maxSize := resource.MustParse("100Gi"), resource.MustParse("2Ti")
+			Expect(validateDiscoveryFilters(StorageDeviceDiscovery{
+				InclusionSpec: &DeviceInclusionSpec{MinSize: &minSize, MaxSize: &maxSize, Vendor: "^(IBM|NETAPP)$"},
+				ExclusionSpec: &DeviceExclusionSpec{DeviceIDs: []string{"/dev/disk/by-id/wwn-0x6005*"}},
+			})).To(Succeed())
+			Expect(validateDiscoveryFilters(StorageDeviceDiscovery{Create: true})).To(Succeed())
+		})
+
+		It("Should deny invalid filters", func() {
+			Expect(validateDiscoveryFilters(StorageDeviceDiscovery{
+				InclusionSpec: &DeviceInclusionSpec{Model: "(unclosed"},
+			})).To(MatchError(ContainSubstring("inclusionSpec.model is not a valid regular expression")))
+			Expect(validateDiscoveryFilters(StorageDeviceDiscovery{
+				ExclusionSpec: &DeviceExclusionSpec{DeviceIDs: []string{"/dev/disk/by-id/[wwn"}},
+			})).To(MatchError(ContainSubstring("exclusionSpec.deviceIDs has an invalid pattern")))
+			minSize, maxSize := resource.MustParse("2Ti"), resource.MustParse("100Gi")
+			Expect(validateDiscoveryFilters(StorageDeviceDiscovery{
+				InclusionSpec: &DeviceInclusionSpec{MinSize: &minSize, MaxSize: &maxSize},
+			})).To(MatchError(ContainSubstring("minSize is larger than maxSize")))
+		})
+	})
+
 })
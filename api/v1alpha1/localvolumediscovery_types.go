@@ -17,7 +17,12 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -55,6 +60,56 @@ type LocalVolumeDiscoverySpec struct {
 	// LocalVolumeDiscovery Daemon
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// InclusionSpec selects the devices to discover. Read-only, removable, suspended and mounted devices,
+	// devices without WWN and devices with a filesystem or partitions are never discovered.
+	// +optional
+	InclusionSpec *DeviceInclusionSpec `json:"inclusionSpec,omitempty"`
+	// ExclusionSpec drops devices matched by the inclusion spec, for instance LUNs reserved for another product
+	// +optional
+	ExclusionSpec *DeviceExclusionSpec `json:"exclusionSpec,omitempty"`
+}
+
+// DeviceInclusionSpec lists the conditions a device must meet to be discovered. The empty fields match any
+// device.
+type DeviceInclusionSpec struct {
+	// DeviceTypes are the types of the devices to discover, disk and mpath when empty
+	// +kubebuilder:validation:items:Enum=disk;part;lvm;mpath
+	// +optional
+	DeviceTypes []DiscoveredDeviceType `json:"deviceTypes,omitempty"`
+	// MinSize is the minimum size of the devices
+	// +optional
+	MinSize *resource.Quantity `json:"minSize,omitempty"`
+	// MaxSize is the maximum size of the devices
+	// +optional
+	MaxSize *resource.Quantity `json:"maxSize,omitempty"`
+	// Vendor is a regular expression the vendor of the devices must match
+	// +optional
+	Vendor string `json:"vendor,omitempty"`
+	// Model is a regular expression the model of the devices must match
+	// +optional
+	Model string `json:"model,omitempty"`
+	// DeviceIDs are shell patterns one of which the persistent name of the devices must match, for
+	// instance /dev/disk/by-id/wwn-0x6005*
+	// +optional
+	DeviceIDs []string `json:"deviceIDs,omitempty"`
+}
+
+// DeviceExclusionSpec lists the conditions that exclude a device from discovery. A device matching any of
+// them is excluded.
+type DeviceExclusionSpec struct {
+	// DeviceTypes are the types of the devices to exclude
+	// +kubebuilder:validation:items:Enum=disk;part;lvm;mpath
+	// +optional
+	DeviceTypes []DiscoveredDeviceType `json:"deviceTypes,omitempty"`
+	// Vendor is a regular expression matching the vendor of the devices to exclude
+	// +optional
+	Vendor string `json:"vendor,omitempty"`
+	// Model is a regular expression matching the model of the devices to exclude
+	// +optional
+	Model string `json:"model,omitempty"`
+	// DeviceIDs are shell patterns matching the persistent name of the devices to exclude
+	// +optional
+	DeviceIDs []string `json:"deviceIDs,omitempty"`
 }
 
 // LocalVolumeDiscoveryStatus defines the observed state of LocalVolumeDiscovery
@@ -93,6 +148,38 @@ type LocalVolumeDiscoveryList struct {
 	Items           []LocalVolumeDiscovery `json:"items"`
 }
 
+// ValidateDeviceFilters checks the size range, the regular expressions and the shell patterns of an inclusion
+// and an exclusion spec, both optional. The errors name the invalid field relative to the spec.
+func ValidateDeviceFilters(inclusion *DeviceInclusionSpec, exclusion *DeviceExclusionSpec) error {
+	var regexps [][2]string
+	patterns := map[string][]string{}
+	if inclusion != nil {
+		if inclusion.MinSize != nil && inclusion.MaxSize != nil && inclusion.MinSize.Cmp(*inclusion.MaxSize) > 0 {
+			return fmt.Errorf("inclusionSpec.minSize is larger than maxSize")
+		}
+		regexps = append(regexps, [2]string{"inclusionSpec.vendor", inclusion.Vendor}, [2]string{"inclusionSpec.model", inclusion.Model})
+		patterns["inclusionSpec.deviceIDs"] = inclusion.DeviceIDs
+	}
+	if exclusion != nil {
+		regexps = append(regexps, [2]string{"exclusionSpec.vendor", exclusion.Vendor}, [2]string{"exclusionSpec.model", exclusion.Model})
+		patterns["exclusionSpec.deviceIDs"] = exclusion.DeviceIDs
+	}
+
+	for _, re := range regexps {
+		if _, err := regexp.Compile(re[1]); err != nil {
+			return fmt.Errorf("%s is not a valid regular expression: %w", re[0], err)
+		}
+	}
+	for name, values := range patterns {
+		for _, pattern := range values {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%s has an invalid pattern %q: %w", name, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&LocalVolumeDiscovery{}, &LocalVolumeDiscoveryList{})
 }
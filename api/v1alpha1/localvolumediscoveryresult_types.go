@@ -36,6 +36,102 @@ type DiscoveredDevice struct {
 	Size int64 `json:"size"`
 	// WWN defines the WWN value of the device.
 	WWN string `json:"WWN"`
+	// Property tells whether the device is rotational
+	// +optional
+	Property DeviceMechanicalProperty `json:"property,omitempty"`
+	// Transport of the device, such as fc, iscsi, nvme or sas
+	// +optional
+	Transport string `json:"transport,omitempty"`
+	// Serial number of the device
+	// +optional
+	Serial string `json:"serial,omitempty"`
+	// LogicalSectorSize of the device in bytes
+	// +optional
+	LogicalSectorSize int64 `json:"logicalSectorSize,omitempty"`
+	// PhysicalSectorSize of the device in bytes
+	// +optional
+	PhysicalSectorSize int64 `json:"physicalSectorSize,omitempty"`
+	// DiscardSupported tells whether the device supports discard (TRIM/UNMAP)
+	// +optional
+	DiscardSupported bool `json:"discardSupported,omitempty"`
+	// MultipathPaths lists the paths of a multipath device with their state. A path that is not running
+	// degrades the multipath device.
+	// +optional
+	MultipathPaths []MultipathPath `json:"multipathPaths,omitempty"`
+	// InUse is set when Storage Scale already uses the device, see DeviceUsage
+	// +optional
+	InUse *DeviceUsage `json:"inUse,omitempty"`
+}
+
+// DeviceUsage tells what already uses a device in Storage Scale
+type DeviceUsage struct {
+	// LocalDisk is the LocalDisk created on the device
+	// +optional
+	LocalDisk string `json:"localDisk,omitempty"`
+	// Filesystem is the Storage Scale Filesystem the LocalDisk belongs to
+	// +optional
+	Filesystem string `json:"filesystem,omitempty"`
+	// FileSystemClaim is the namespace/name of the FileSystemClaim owning the LocalDisk
+	// +optional
+	FileSystemClaim string `json:"fileSystemClaim,omitempty"`
+	// NSDSignature reports that the header of the device carries a GPFS NSD descriptor: the device is, or
+	// was, an NSD of a Storage Scale cluster
+	// +optional
+	NSDSignature bool `json:"nsdSignature,omitempty"`
+}
+
+// MultipathPath is a member path of a multipath device
+type MultipathPath struct {
+	// Path of the member device. For eg, /dev/sdb
+	Path string `json:"path"`
+	// State of the member device as reported by the kernel, such as running or offline
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+// DeviceIgnoredReason tells why discovery does not report a device
+type DeviceIgnoredReason string
+
+const (
+	// IgnoredReadOnly is a read-only device
+	IgnoredReadOnly DeviceIgnoredReason = "ReadOnly"
+	// IgnoredRemovable is a removable device
+	IgnoredRemovable DeviceIgnoredReason = "Removable"
+	// IgnoredHasFilesystem is a device, or a child of the device, with a filesystem
+	IgnoredHasFilesystem DeviceIgnoredReason = "HasFilesystem"
+	// IgnoredHasPartitions is a device with partitions
+	IgnoredHasPartitions DeviceIgnoredReason = "HasPartitions"
+	// IgnoredNoWWN is a device without WWN
+	IgnoredNoWWN DeviceIgnoredReason = "NoWWN"
+	// IgnoredMounted is a device with a mountpoint
+	IgnoredMounted DeviceIgnoredReason = "Mounted"
+	// IgnoredSuspended is a suspended device
+	IgnoredSuspended DeviceIgnoredReason = "Suspended"
+	// IgnoredUnsupportedType is a device of a type that is not discovered
+	IgnoredUnsupportedType DeviceIgnoredReason = "UnsupportedType"
+	// IgnoredBootDevice is a device with a BIOS boot partition
+	IgnoredBootDevice DeviceIgnoredReason = "BootDevice"
+	// IgnoredZeroSize is a device with no capacity
+	IgnoredZeroSize DeviceIgnoredReason = "ZeroSize"
+	// IgnoredExcluded is a device left out by the inclusionSpec or the exclusionSpec of the LocalVolumeDiscovery
+	IgnoredExcluded DeviceIgnoredReason = "Excluded"
+)
+
+// IgnoredDevice is a block device of the node that discovery does not report, with the reason
+type IgnoredDevice struct {
+	// Path represents the device path. For eg, /dev/sdb
+	Path string `json:"path"`
+	// DeviceID represents the persistent name of the device, when it has one
+	// +optional
+	DeviceID string `json:"deviceID,omitempty"`
+	// WWN of the device, when it has one
+	// +optional
+	WWN string `json:"WWN,omitempty"`
+	// Reason is the machine-readable reason the device is ignored
+	Reason DeviceIgnoredReason `json:"reason"`
+	// Message explains the reason
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // LocalVolumeDiscoveryResultSpec defines the desired state of LocalVolumeDiscoveryResult
@@ -59,6 +155,9 @@ type LocalVolumeDiscoveryResultStatus struct {
 	// - it should have a WWN value
 	// +optional
 	DiscoveredDevices []DiscoveredDevice `json:"discoveredDevices"`
+	// IgnoredDevices lists the block devices of the node which are not usable, with the reason
+	// +optional
+	IgnoredDevices []IgnoredDevice `json:"ignoredDevices,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MigrationReportSpec defines the v1.0 to v1.1 migration run requested by the report.
+type MigrationReportSpec struct {
+	// DryRun only reports what would be migrated, without changing any resource
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// MigrationPhase is the phase of a migration run
+// +kubebuilder:validation:Enum=Pending;Running;Completed;Failed
+type MigrationPhase string
+
+const (
+	// MigrationPhasePending means the migration has not started yet
+	MigrationPhasePending MigrationPhase = "Pending"
+	// MigrationPhaseRunning means the migration is in progress
+	MigrationPhaseRunning MigrationPhase = "Running"
+	// MigrationPhaseCompleted means every group was processed, see the outcome of each group
+	MigrationPhaseCompleted MigrationPhase = "Completed"
+	// MigrationPhaseFailed means the legacy resources could not be discovered
+	MigrationPhaseFailed MigrationPhase = "Failed"
+)
+
+// MigrationOutcome is the outcome of the migration of a group of legacy resources
+// +kubebuilder:validation:Enum=Migrated;WouldMigrate;Skipped;Failed
+type MigrationOutcome string
+
+const (
+	// MigrationOutcomeMigrated means a FileSystemClaim now owns the resources of the group
+	MigrationOutcomeMigrated MigrationOutcome = "Migrated"
+	// MigrationOutcomeWouldMigrate means the group is valid and would be migrated outside of a dry run
+	MigrationOutcomeWouldMigrate MigrationOutcome = "WouldMigrate"
+	// MigrationOutcomeSkipped means the group did not pass validation and was left untouched
+	MigrationOutcomeSkipped MigrationOutcome = "Skipped"
+	// MigrationOutcomeFailed means the migration of a valid group failed part way
+	MigrationOutcomeFailed MigrationOutcome = "Failed"
+)
+
+// MigrationGroupResult reports the migration of the LocalDisks used by one Filesystem
+type MigrationGroupResult struct {
+	// Filesystem is the name of the Filesystem using the LocalDisks
+	Filesystem string `json:"filesystem"`
+
+	// Outcome is the result of the migration of the group
+	Outcome MigrationOutcome `json:"outcome"`
+
+	// Reason explains why the group was skipped or failed
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// LocalDisks are the names of the legacy LocalDisks of the group
+	// +optional
+	LocalDisks []string `json:"localDisks,omitempty"`
+
+	// Devices are the device paths of the LocalDisks
+	// +optional
+	Devices []string `json:"devices,omitempty"`
+
+	// FileSystemClaim is the FileSystemClaim that owns the group once migrated
+	// +optional
+	FileSystemClaim string `json:"fileSystemClaim,omitempty"`
+
+	// ProcessedAt is when the group was processed
+	ProcessedAt metav1.Time `json:"processedAt"`
+}
+
+// MigrationReportStatus defines the observed state of MigrationReport.
+type MigrationReportStatus struct {
+	// Phase is the phase of the migration run
+	// +optional
+	Phase MigrationPhase `json:"phase,omitempty"`
+
+	// Message describes the phase, for example why the run failed
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// DryRun reports whether the run was a dry run
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// StartTime is when the run started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the run completed or failed
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// TotalLocalDisks is the number of legacy LocalDisks found
+	// +optional
+	TotalLocalDisks int32 `json:"totalLocalDisks,omitempty"`
+
+	// TotalGroups is the number of Filesystems the legacy LocalDisks belong to
+	// +optional
+	TotalGroups int32 `json:"totalGroups,omitempty"`
+
+	// SuccessfulGroups is the number of groups migrated, or that would be migrated in a dry run
+	// +optional
+	SuccessfulGroups int32 `json:"successfulGroups,omitempty"`
+
+	// FailedGroups is the number of groups skipped or failed
+	// +optional
+	FailedGroups int32 `json:"failedGroups,omitempty"`
+
+	// Groups reports the outcome of each group
+	// +optional
+	Groups []MigrationGroupResult `json:"groups,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=mr
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Dry Run",type=boolean,JSONPath=`.spec.dryRun`
+// +kubebuilder:printcolumn:name="Groups",type=integer,JSONPath=`.status.totalGroups`
+// +kubebuilder:printcolumn:name="Successful",type=integer,JSONPath=`.status.successfulGroups`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedGroups`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// MigrationReport records a v1.0 to v1.1 migration run. The operator creates one for each startup run that
+// finds legacy LocalDisks; creating one in the ibm-spectrum-scale namespace runs the migration again.
+type MigrationReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MigrationReportSpec   `json:"spec,omitempty"`
+	Status MigrationReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MigrationReportList contains a list of MigrationReport.
+type MigrationReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MigrationReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MigrationReport{}, &MigrationReportList{})
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SharedDeviceInventoryName is the name of the SharedDeviceInventory maintained by the operator
+const SharedDeviceInventoryName = "shared-devices"
+
+// SharedDeviceInventorySpec is empty, the inventory is maintained by the operator.
+type SharedDeviceInventorySpec struct{}
+
+// DeviceNodePath is the view of a device from one node
+type DeviceNodePath struct {
+	// Node is the name of the node that sees the device
+	Node string `json:"node"`
+
+	// Path is the kernel path of the device on the node, e.g. /dev/sdb
+	Path string `json:"path"`
+
+	// DeviceID is the /dev/disk/by-id path of the device on the node
+	// +optional
+	DeviceID string `json:"deviceID,omitempty"`
+}
+
+// SharedDevice is a device discovered on at least one node, identified by its WWN
+type SharedDevice struct {
+	// WWN is the World Wide Name of the device
+	WWN string `json:"WWN"`
+
+	// Size of the device in bytes
+	Size int64 `json:"size"`
+
+	// Model of the device
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// Vendor of the device
+	// +optional
+	Vendor string `json:"vendor,omitempty"`
+
+	// Type of the device, disk or mpath
+	// +optional
+	Type DiscoveredDeviceType `json:"type,omitempty"`
+
+	// Nodes are the nodes that see the device with their own path to it, sorted by node name
+	Nodes []DeviceNodePath `json:"nodes"`
+
+	// Shared reports whether every storage node sees the device, which is required to use it in a
+	// FileSystemClaim
+	Shared bool `json:"shared"`
+
+	// UsedBy is set when a LocalDisk already uses the device, or when a node found a GPFS NSD signature on it
+	// +optional
+	UsedBy *DeviceUsage `json:"usedBy,omitempty"`
+}
+
+// SharedDeviceInventoryStatus aggregates the LocalVolumeDiscoveryResults of all nodes.
+type SharedDeviceInventoryStatus struct {
+	// StorageNodes are the nodes with both the worker and the storage labels, sorted by name
+	// +optional
+	StorageNodes []string `json:"storageNodes,omitempty"`
+
+	// Devices are the discovered devices with a WWN, sorted by WWN
+	// +optional
+	Devices []SharedDevice `json:"devices,omitempty"`
+
+	// TotalDevices is the number of devices in the inventory
+	// +optional
+	TotalDevices int32 `json:"totalDevices,omitempty"`
+
+	// SharedDevices is the number of devices seen by every storage node
+	// +optional
+	SharedDevices int32 `json:"sharedDevices,omitempty"`
+
+	// UsedDevices is the number of devices already used by Storage Scale
+	// +optional
+	UsedDevices int32 `json:"usedDevices,omitempty"`
+
+	// LastUpdateTime is when the devices or the storage nodes last changed
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=sdi
+// +kubebuilder:printcolumn:name="Devices",type=integer,JSONPath=`.status.totalDevices`
+// +kubebuilder:printcolumn:name="Shared",type=integer,JSONPath=`.status.sharedDevices`
+// +kubebuilder:printcolumn:name="Used",type=integer,JSONPath=`.status.usedDevices`
+// +kubebuilder:printcolumn:name="Updated",type=date,JSONPath=`.status.lastUpdateTime`
+
+// SharedDeviceInventory lists the devices discovered across the cluster, keyed by WWN, with the nodes that see
+// them and whether they are already used. The operator maintains a single inventory named shared-devices.
+type SharedDeviceInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SharedDeviceInventorySpec   `json:"spec,omitempty"`
+	Status SharedDeviceInventoryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SharedDeviceInventoryList contains a list of SharedDeviceInventory.
+type SharedDeviceInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SharedDeviceInventory `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SharedDeviceInventory{}, &SharedDeviceInventoryList{})
+}
@@ -21,129 +21,1026 @@ limitations under the License.
 package v1alpha1
 
 import (
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdoptionStatus) DeepCopyInto(out *AdoptionStatus) {
+	*out = *in
+	if in.LocalDisks != nil {
+		in, out := &in.LocalDisks, &out.LocalDisks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdoptedAt != nil {
+		in, out := &in.AdoptedAt, &out.AdoptedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdoptionStatus.
+func (in *AdoptionStatus) DeepCopy() *AdoptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AdoptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityStatus) DeepCopyInto(out *CapacityStatus) {
+	*out = *in
+	if in.Total != nil {
+		in, out := &in.Total, &out.Total
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.UsedPercent != nil {
+		in, out := &in.UsedPercent, &out.UsedPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]PoolCapacity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityStatus.
+func (in *CapacityStatus) DeepCopy() *CapacityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityThresholds) DeepCopyInto(out *CapacityThresholds) {
+	*out = *in
+	if in.WarningPercent != nil {
+		in, out := &in.WarningPercent, &out.WarningPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CriticalPercent != nil {
+		in, out := &in.CriticalPercent, &out.CriticalPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityThresholds.
+func (in *CapacityThresholds) DeepCopy() *CapacityThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityThresholds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceExclusionSpec) DeepCopyInto(out *DeviceExclusionSpec) {
+	*out = *in
+	if in.DeviceTypes != nil {
+		in, out := &in.DeviceTypes, &out.DeviceTypes
+		*out = make([]DiscoveredDeviceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeviceIDs != nil {
+		in, out := &in.DeviceIDs, &out.DeviceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceExclusionSpec.
+func (in *DeviceExclusionSpec) DeepCopy() *DeviceExclusionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceExclusionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceInclusionSpec) DeepCopyInto(out *DeviceInclusionSpec) {
+	*out = *in
+	if in.DeviceTypes != nil {
+		in, out := &in.DeviceTypes, &out.DeviceTypes
+		*out = make([]DiscoveredDeviceType, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DeviceIDs != nil {
+		in, out := &in.DeviceIDs, &out.DeviceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceInclusionSpec.
+func (in *DeviceInclusionSpec) DeepCopy() *DeviceInclusionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceInclusionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceInventory) DeepCopyInto(out *DeviceInventory) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceInventory.
+func (in *DeviceInventory) DeepCopy() *DeviceInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceNodePath) DeepCopyInto(out *DeviceNodePath) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceNodePath.
+func (in *DeviceNodePath) DeepCopy() *DeviceNodePath {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceNodePath)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevicePlacement) DeepCopyInto(out *DevicePlacement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevicePlacement.
+func (in *DevicePlacement) DeepCopy() *DevicePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(DevicePlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceRemovalStatus) DeepCopyInto(out *DeviceRemovalStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceRemovalStatus.
+func (in *DeviceRemovalStatus) DeepCopy() *DeviceRemovalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceRemovalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceSelector) DeepCopyInto(out *DeviceSelector) {
+	*out = *in
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]DiscoveredDeviceType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceSelector.
+func (in *DeviceSelector) DeepCopy() *DeviceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceUsage) DeepCopyInto(out *DeviceUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceUsage.
+func (in *DeviceUsage) DeepCopy() *DeviceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DiscoveredDevice) DeepCopyInto(out *DiscoveredDevice) {
 	*out = *in
+	if in.MultipathPaths != nil {
+		in, out := &in.MultipathPaths, &out.MultipathPaths
+		*out = make([]MultipathPath, len(*in))
+		copy(*out, *in)
+	}
+	if in.InUse != nil {
+		in, out := &in.InUse, &out.InUse
+		*out = new(DeviceUsage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredDevice.
+func (in *DiscoveredDevice) DeepCopy() *DiscoveredDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(DiscoveredDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSystemClaim) DeepCopyInto(out *FileSystemClaim) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemClaim.
+func (in *FileSystemClaim) DeepCopy() *FileSystemClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSystemClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FileSystemClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSystemClaimList) DeepCopyInto(out *FileSystemClaimList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FileSystemClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemClaimList.
+func (in *FileSystemClaimList) DeepCopy() *FileSystemClaimList {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSystemClaimList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FileSystemClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSystemClaimSpec) DeepCopyInto(out *FileSystemClaimSpec) {
+	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeviceSelector != nil {
+		in, out := &in.DeviceSelector, &out.DeviceSelector
+		*out = new(DeviceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Filesystem != nil {
+		in, out := &in.Filesystem, &out.Filesystem
+		*out = new(FileSystemLayout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodePlacement != nil {
+		in, out := &in.NodePlacement, &out.NodePlacement
+		*out = new(NodePlacement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CapacityThresholds != nil {
+		in, out := &in.CapacityThresholds, &out.CapacityThresholds
+		*out = new(CapacityThresholds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RemoveDevices != nil {
+		in, out := &in.RemoveDevices, &out.RemoveDevices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageClass != nil {
+		in, out := &in.StorageClass, &out.StorageClass
+		*out = new(StorageClassTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemClaimSpec.
+func (in *FileSystemClaimSpec) DeepCopy() *FileSystemClaimSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSystemClaimSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSystemClaimStatus) DeepCopyInto(out *FileSystemClaimStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]DeviceInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Filesystem != nil {
+		in, out := &in.Filesystem, &out.Filesystem
+		*out = new(FilesystemInventory)
+		**out = **in
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(CapacityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeviceRemovals != nil {
+		in, out := &in.DeviceRemovals, &out.DeviceRemovals
+		*out = make([]DeviceRemovalStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResolvedDevices != nil {
+		in, out := &in.ResolvedDevices, &out.ResolvedDevices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Placements != nil {
+		in, out := &in.Placements, &out.Placements
+		*out = make([]DevicePlacement, len(*in))
+		copy(*out, *in)
+	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(AdoptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemClaimStatus.
+func (in *FileSystemClaimStatus) DeepCopy() *FileSystemClaimStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSystemClaimStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSystemLayout) DeepCopyInto(out *FileSystemLayout) {
+	*out = *in
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]FileSystemPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemLayout.
+func (in *FileSystemLayout) DeepCopy() *FileSystemLayout {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSystemLayout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileSystemPool) DeepCopyInto(out *FileSystemPool) {
+	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemPool.
+func (in *FileSystemPool) DeepCopy() *FileSystemPool {
+	if in == nil {
+		return nil
+	}
+	out := new(FileSystemPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilesystemInventory) DeepCopyInto(out *FilesystemInventory) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilesystemInventory.
+func (in *FilesystemInventory) DeepCopy() *FilesystemInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(FilesystemInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccess) DeepCopyInto(out *FusionAccess) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccess.
+func (in *FusionAccess) DeepCopy() *FusionAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FusionAccess) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccessList) DeepCopyInto(out *FusionAccessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FusionAccess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessList.
+func (in *FusionAccessList) DeepCopy() *FusionAccessList {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FusionAccessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccessSpec) DeepCopyInto(out *FusionAccessSpec) {
+	*out = *in
+	in.LocalVolumeDiscovery.DeepCopyInto(&out.LocalVolumeDiscovery)
+	if in.ManifestOverrides != nil {
+		in, out := &in.ManifestOverrides, &out.ManifestOverrides
+		*out = new(ManifestOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessSpec.
+func (in *FusionAccessSpec) DeepCopy() *FusionAccessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FusionAccessStatus) DeepCopyInto(out *FusionAccessStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TotalProvisionedDeviceCount != nil {
+		in, out := &in.TotalProvisionedDeviceCount, &out.TotalProvisionedDeviceCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SupportedStorageScaleVersions != nil {
+		in, out := &in.SupportedStorageScaleVersions, &out.SupportedStorageScaleVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManifestApply != nil {
+		in, out := &in.ManifestApply, &out.ManifestApply
+		*out = new(ManifestApplyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(StorageScaleUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessStatus.
+func (in *FusionAccessStatus) DeepCopy() *FusionAccessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FusionAccessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IgnoredDevice) DeepCopyInto(out *IgnoredDevice) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IgnoredDevice.
+func (in *IgnoredDevice) DeepCopy() *IgnoredDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(IgnoredDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageOverride) DeepCopyInto(out *ImageOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverride.
+func (in *ImageOverride) DeepCopy() *ImageOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalVolumeDiscovery) DeepCopyInto(out *LocalVolumeDiscovery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscovery.
+func (in *LocalVolumeDiscovery) DeepCopy() *LocalVolumeDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalVolumeDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalVolumeDiscovery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalVolumeDiscoveryList) DeepCopyInto(out *LocalVolumeDiscoveryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LocalVolumeDiscovery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryList.
+func (in *LocalVolumeDiscoveryList) DeepCopy() *LocalVolumeDiscoveryList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalVolumeDiscoveryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalVolumeDiscoveryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalVolumeDiscoveryResult) DeepCopyInto(out *LocalVolumeDiscoveryResult) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryResult.
+func (in *LocalVolumeDiscoveryResult) DeepCopy() *LocalVolumeDiscoveryResult {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalVolumeDiscoveryResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalVolumeDiscoveryResult) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalVolumeDiscoveryResultList) DeepCopyInto(out *LocalVolumeDiscoveryResultList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LocalVolumeDiscoveryResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryResultList.
+func (in *LocalVolumeDiscoveryResultList) DeepCopy() *LocalVolumeDiscoveryResultList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalVolumeDiscoveryResultList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalVolumeDiscoveryResultList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalVolumeDiscoveryResultSpec) DeepCopyInto(out *LocalVolumeDiscoveryResultSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryResultSpec.
+func (in *LocalVolumeDiscoveryResultSpec) DeepCopy() *LocalVolumeDiscoveryResultSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalVolumeDiscoveryResultSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalVolumeDiscoveryResultStatus) DeepCopyInto(out *LocalVolumeDiscoveryResultStatus) {
+	*out = *in
+	if in.DiscoveredDevices != nil {
+		in, out := &in.DiscoveredDevices, &out.DiscoveredDevices
+		*out = make([]DiscoveredDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IgnoredDevices != nil {
+		in, out := &in.IgnoredDevices, &out.IgnoredDevices
+		*out = make([]IgnoredDevice, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryResultStatus.
+func (in *LocalVolumeDiscoveryResultStatus) DeepCopy() *LocalVolumeDiscoveryResultStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalVolumeDiscoveryResultStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalVolumeDiscoverySpec) DeepCopyInto(out *LocalVolumeDiscoverySpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.NodeSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InclusionSpec != nil {
+		in, out := &in.InclusionSpec, &out.InclusionSpec
+		*out = new(DeviceInclusionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExclusionSpec != nil {
+		in, out := &in.ExclusionSpec, &out.ExclusionSpec
+		*out = new(DeviceExclusionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoverySpec.
+func (in *LocalVolumeDiscoverySpec) DeepCopy() *LocalVolumeDiscoverySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalVolumeDiscoverySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalVolumeDiscoveryStatus) DeepCopyInto(out *LocalVolumeDiscoveryStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryStatus.
+func (in *LocalVolumeDiscoveryStatus) DeepCopy() *LocalVolumeDiscoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalVolumeDiscoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestApplyFailure) DeepCopyInto(out *ManifestApplyFailure) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveredDevice.
-func (in *DiscoveredDevice) DeepCopy() *DiscoveredDevice {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestApplyFailure.
+func (in *ManifestApplyFailure) DeepCopy() *ManifestApplyFailure {
 	if in == nil {
 		return nil
 	}
-	out := new(DiscoveredDevice)
+	out := new(ManifestApplyFailure)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileSystemClaim) DeepCopyInto(out *FileSystemClaim) {
+func (in *ManifestApplyStatus) DeepCopyInto(out *ManifestApplyStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]ManifestApplyFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastApplyTime != nil {
+		in, out := &in.LastApplyTime, &out.LastApplyTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemClaim.
-func (in *FileSystemClaim) DeepCopy() *FileSystemClaim {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestApplyStatus.
+func (in *ManifestApplyStatus) DeepCopy() *ManifestApplyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FileSystemClaim)
+	out := new(ManifestApplyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FileSystemClaim) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileSystemClaimList) DeepCopyInto(out *FileSystemClaimList) {
+func (in *ManifestOverrides) DeepCopyInto(out *ManifestOverrides) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]FileSystemClaim, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]ManifestPatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemClaimList.
-func (in *FileSystemClaimList) DeepCopy() *FileSystemClaimList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestOverrides.
+func (in *ManifestOverrides) DeepCopy() *ManifestOverrides {
 	if in == nil {
 		return nil
 	}
-	out := new(FileSystemClaimList)
+	out := new(ManifestOverrides)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FileSystemClaimList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestPatch) DeepCopyInto(out *ManifestPatch) {
+	*out = *in
+	out.Target = in.Target
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestPatch.
+func (in *ManifestPatch) DeepCopy() *ManifestPatch {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(ManifestPatch)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileSystemClaimSpec) DeepCopyInto(out *FileSystemClaimSpec) {
+func (in *ManifestPatchTarget) DeepCopyInto(out *ManifestPatchTarget) {
 	*out = *in
-	if in.Devices != nil {
-		in, out := &in.Devices, &out.Devices
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemClaimSpec.
-func (in *FileSystemClaimSpec) DeepCopy() *FileSystemClaimSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestPatchTarget.
+func (in *ManifestPatchTarget) DeepCopy() *ManifestPatchTarget {
 	if in == nil {
 		return nil
 	}
-	out := new(FileSystemClaimSpec)
+	out := new(ManifestPatchTarget)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FileSystemClaimStatus) DeepCopyInto(out *FileSystemClaimStatus) {
+func (in *MigrationGroupResult) DeepCopyInto(out *MigrationGroupResult) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.LocalDisks != nil {
+		in, out := &in.LocalDisks, &out.LocalDisks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
+	in.ProcessedAt.DeepCopyInto(&out.ProcessedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileSystemClaimStatus.
-func (in *FileSystemClaimStatus) DeepCopy() *FileSystemClaimStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationGroupResult.
+func (in *MigrationGroupResult) DeepCopy() *MigrationGroupResult {
 	if in == nil {
 		return nil
 	}
-	out := new(FileSystemClaimStatus)
+	out := new(MigrationGroupResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FusionAccess) DeepCopyInto(out *FusionAccess) {
+func (in *MigrationReport) DeepCopyInto(out *MigrationReport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -151,18 +1048,18 @@ func (in *FusionAccess) DeepCopyInto(out *FusionAccess) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccess.
-func (in *FusionAccess) DeepCopy() *FusionAccess {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationReport.
+func (in *MigrationReport) DeepCopy() *MigrationReport {
 	if in == nil {
 		return nil
 	}
-	out := new(FusionAccess)
+	out := new(MigrationReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FusionAccess) DeepCopyObject() runtime.Object {
+func (in *MigrationReport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -170,31 +1067,31 @@ func (in *FusionAccess) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FusionAccessList) DeepCopyInto(out *FusionAccessList) {
+func (in *MigrationReportList) DeepCopyInto(out *MigrationReportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]FusionAccess, len(*in))
+		*out = make([]MigrationReport, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessList.
-func (in *FusionAccessList) DeepCopy() *FusionAccessList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationReportList.
+func (in *MigrationReportList) DeepCopy() *MigrationReportList {
 	if in == nil {
 		return nil
 	}
-	out := new(FusionAccessList)
+	out := new(MigrationReportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FusionAccessList) DeepCopyObject() runtime.Object {
+func (in *MigrationReportList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -202,109 +1099,139 @@ func (in *FusionAccessList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FusionAccessSpec) DeepCopyInto(out *FusionAccessSpec) {
+func (in *MigrationReportSpec) DeepCopyInto(out *MigrationReportSpec) {
 	*out = *in
-	out.LocalVolumeDiscovery = in.LocalVolumeDiscovery
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessSpec.
-func (in *FusionAccessSpec) DeepCopy() *FusionAccessSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationReportSpec.
+func (in *MigrationReportSpec) DeepCopy() *MigrationReportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FusionAccessSpec)
+	out := new(MigrationReportSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FusionAccessStatus) DeepCopyInto(out *FusionAccessStatus) {
+func (in *MigrationReportStatus) DeepCopyInto(out *MigrationReportStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]MigrationGroupResult, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.TotalProvisionedDeviceCount != nil {
-		in, out := &in.TotalProvisionedDeviceCount, &out.TotalProvisionedDeviceCount
-		*out = new(int32)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FusionAccessStatus.
-func (in *FusionAccessStatus) DeepCopy() *FusionAccessStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationReportStatus.
+func (in *MigrationReportStatus) DeepCopy() *MigrationReportStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FusionAccessStatus)
+	out := new(MigrationReportStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalVolumeDiscovery) DeepCopyInto(out *LocalVolumeDiscovery) {
+func (in *MultipathPath) DeepCopyInto(out *MultipathPath) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscovery.
-func (in *LocalVolumeDiscovery) DeepCopy() *LocalVolumeDiscovery {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipathPath.
+func (in *MultipathPath) DeepCopy() *MultipathPath {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalVolumeDiscovery)
+	out := new(MultipathPath)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocalVolumeDiscovery) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePlacement.
+func (in *NodePlacement) DeepCopy() *NodePlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePlacement)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalVolumeDiscoveryList) DeepCopyInto(out *LocalVolumeDiscoveryList) {
+func (in *PoolCapacity) DeepCopyInto(out *PoolCapacity) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]LocalVolumeDiscovery, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Total != nil {
+		in, out := &in.Total, &out.Total
+		x := (*in).DeepCopy()
+		*out = &x
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryList.
-func (in *LocalVolumeDiscoveryList) DeepCopy() *LocalVolumeDiscoveryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolCapacity.
+func (in *PoolCapacity) DeepCopy() *PoolCapacity {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalVolumeDiscoveryList)
+	out := new(PoolCapacity)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocalVolumeDiscoveryList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedDevice) DeepCopyInto(out *SharedDevice) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]DeviceNodePath, len(*in))
+		copy(*out, *in)
 	}
-	return nil
+	if in.UsedBy != nil {
+		in, out := &in.UsedBy, &out.UsedBy
+		*out = new(DeviceUsage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedDevice.
+func (in *SharedDevice) DeepCopy() *SharedDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedDevice)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalVolumeDiscoveryResult) DeepCopyInto(out *LocalVolumeDiscoveryResult) {
+func (in *SharedDeviceInventory) DeepCopyInto(out *SharedDeviceInventory) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -312,18 +1239,18 @@ func (in *LocalVolumeDiscoveryResult) DeepCopyInto(out *LocalVolumeDiscoveryResu
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryResult.
-func (in *LocalVolumeDiscoveryResult) DeepCopy() *LocalVolumeDiscoveryResult {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedDeviceInventory.
+func (in *SharedDeviceInventory) DeepCopy() *SharedDeviceInventory {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalVolumeDiscoveryResult)
+	out := new(SharedDeviceInventory)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocalVolumeDiscoveryResult) DeepCopyObject() runtime.Object {
+func (in *SharedDeviceInventory) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -331,31 +1258,31 @@ func (in *LocalVolumeDiscoveryResult) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalVolumeDiscoveryResultList) DeepCopyInto(out *LocalVolumeDiscoveryResultList) {
+func (in *SharedDeviceInventoryList) DeepCopyInto(out *SharedDeviceInventoryList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]LocalVolumeDiscoveryResult, len(*in))
+		*out = make([]SharedDeviceInventory, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryResultList.
-func (in *LocalVolumeDiscoveryResultList) DeepCopy() *LocalVolumeDiscoveryResultList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedDeviceInventoryList.
+func (in *SharedDeviceInventoryList) DeepCopy() *SharedDeviceInventoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalVolumeDiscoveryResultList)
+	out := new(SharedDeviceInventoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *LocalVolumeDiscoveryResultList) DeepCopyObject() runtime.Object {
+func (in *SharedDeviceInventoryList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -363,85 +1290,111 @@ func (in *LocalVolumeDiscoveryResultList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalVolumeDiscoveryResultSpec) DeepCopyInto(out *LocalVolumeDiscoveryResultSpec) {
+func (in *SharedDeviceInventorySpec) DeepCopyInto(out *SharedDeviceInventorySpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryResultSpec.
-func (in *LocalVolumeDiscoveryResultSpec) DeepCopy() *LocalVolumeDiscoveryResultSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedDeviceInventorySpec.
+func (in *SharedDeviceInventorySpec) DeepCopy() *SharedDeviceInventorySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalVolumeDiscoveryResultSpec)
+	out := new(SharedDeviceInventorySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalVolumeDiscoveryResultStatus) DeepCopyInto(out *LocalVolumeDiscoveryResultStatus) {
+func (in *SharedDeviceInventoryStatus) DeepCopyInto(out *SharedDeviceInventoryStatus) {
 	*out = *in
-	if in.DiscoveredDevices != nil {
-		in, out := &in.DiscoveredDevices, &out.DiscoveredDevices
-		*out = make([]DiscoveredDevice, len(*in))
+	if in.StorageNodes != nil {
+		in, out := &in.StorageNodes, &out.StorageNodes
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]SharedDevice, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryResultStatus.
-func (in *LocalVolumeDiscoveryResultStatus) DeepCopy() *LocalVolumeDiscoveryResultStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedDeviceInventoryStatus.
+func (in *SharedDeviceInventoryStatus) DeepCopy() *SharedDeviceInventoryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalVolumeDiscoveryResultStatus)
+	out := new(SharedDeviceInventoryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalVolumeDiscoverySpec) DeepCopyInto(out *LocalVolumeDiscoverySpec) {
+func (in *StorageClassParameters) DeepCopyInto(out *StorageClassParameters) {
 	*out = *in
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = new(corev1.NodeSelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoverySpec.
-func (in *LocalVolumeDiscoverySpec) DeepCopy() *LocalVolumeDiscoverySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClassParameters.
+func (in *StorageClassParameters) DeepCopy() *StorageClassParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalVolumeDiscoverySpec)
+	out := new(StorageClassParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LocalVolumeDiscoveryStatus) DeepCopyInto(out *LocalVolumeDiscoveryStatus) {
+func (in *StorageClassTemplate) DeepCopyInto(out *StorageClassTemplate) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.ReclaimPolicy != nil {
+		in, out := &in.ReclaimPolicy, &out.ReclaimPolicy
+		*out = new(v1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.VolumeBindingMode != nil {
+		in, out := &in.VolumeBindingMode, &out.VolumeBindingMode
+		*out = new(storagev1.VolumeBindingMode)
+		**out = **in
+	}
+	if in.DefaultVirtClass != nil {
+		in, out := &in.DefaultVirtClass, &out.DefaultVirtClass
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(StorageClassParameters)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalVolumeDiscoveryStatus.
-func (in *LocalVolumeDiscoveryStatus) DeepCopy() *LocalVolumeDiscoveryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClassTemplate.
+func (in *StorageClassTemplate) DeepCopy() *StorageClassTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(LocalVolumeDiscoveryStatus)
+	out := new(StorageClassTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -449,6 +1402,16 @@ func (in *LocalVolumeDiscoveryStatus) DeepCopy() *LocalVolumeDiscoveryStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageDeviceDiscovery) DeepCopyInto(out *StorageDeviceDiscovery) {
 	*out = *in
+	if in.InclusionSpec != nil {
+		in, out := &in.InclusionSpec, &out.InclusionSpec
+		*out = new(DeviceInclusionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExclusionSpec != nil {
+		in, out := &in.ExclusionSpec, &out.ExclusionSpec
+		*out = new(DeviceExclusionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageDeviceDiscovery.
@@ -460,3 +1423,46 @@ func (in *StorageDeviceDiscovery) DeepCopy() *StorageDeviceDiscovery {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageScaleUpgradeStatus) DeepCopyInto(out *StorageScaleUpgradeStatus) {
+	*out = *in
+	if in.PreflightChecks != nil {
+		in, out := &in.PreflightChecks, &out.PreflightChecks
+		*out = make([]UpgradePreflightCheck, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageScaleUpgradeStatus.
+func (in *StorageScaleUpgradeStatus) DeepCopy() *StorageScaleUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageScaleUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradePreflightCheck) DeepCopyInto(out *UpgradePreflightCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradePreflightCheck.
+func (in *UpgradePreflightCheck) DeepCopy() *UpgradePreflightCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradePreflightCheck)
+	in.DeepCopyInto(out)
+	return out
+}
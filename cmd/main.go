@@ -36,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/go-logr/logr"
@@ -51,6 +52,7 @@ import (
 
 	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/metrics"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/version"
 	//+kubebuilder:scaffold:imports
 )
@@ -80,9 +82,16 @@ func init() {
 }
 
 func main() {
+	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var secureMetrics bool
 	var enableHTTP2 bool
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8443", "The address the metrics endpoint binds to. "+
+		"Use :8443 for HTTPS or :8080 for HTTP, or 0 to disable the metrics service.")
+	flag.BoolVar(&secureMetrics, "metrics-secure", true,
+		"If set, the metrics endpoint is served via HTTPS to the users allowed to get /metrics. "+
+			"Use --metrics-secure=false to use HTTP without authentication instead.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
@@ -118,8 +127,20 @@ func main() {
 		TLSOpts: tlsOpts,
 	})
 
+	metricsServerOptions := metricsserver.Options{
+		BindAddress:   metricsAddr,
+		SecureServing: secureMetrics,
+		TLSOpts:       tlsOpts,
+	}
+	if secureMetrics {
+		// The requests are authenticated with TokenReviews and authorized with SubjectAccessReviews, so the
+		// scrapers need the metrics-reader ClusterRole
+		metricsServerOptions.FilterProvider = metrics.WithAuthenticationAndAuthorization
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
@@ -149,12 +170,15 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (controller.NewFusionAccessReconciler(mgr.GetClient(), mgr.GetScheme())).SetupWithManager(mgr); err != nil {
+	if err = (controller.NewFusionAccessReconciler(mgr.GetClient(), mgr.GetAPIReader(), mgr.GetScheme(),
+		mgr.GetEventRecorderFor("fusionaccess-controller"))).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "FusionAccess")
 		os.Exit(1)
 	}
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
-		if err = (&fusionv1alpha.FusionAccessValidator{}).SetupWebhookWithManager(mgr); err != nil {
+		if err = (&fusionv1alpha.FusionAccessValidator{
+			CompatibilityMode: fusionv1alpha.CompatibilityMode(os.Getenv(fusionv1alpha.CompatibilityModeEnvVar)),
+		}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "FusionAccess")
 			os.Exit(1)
 		}
@@ -164,10 +188,25 @@ func main() {
 		}
 	}
 	if err = (&fsccontroller.FileSystemClaimReconciler{
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Recorder: mgr.GetEventRecorderFor("filesystemclaim-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FileSystemClaim")
+		os.Exit(1)
+	}
+	if err = (&fsccontroller.MigrationReportReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "FileSystemClaim")
+		setupLog.Error(err, "unable to create controller", "controller", "MigrationReport")
+		os.Exit(1)
+	}
+	if err = (&fsccontroller.SharedDeviceInventoryReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SharedDeviceInventory")
 		os.Exit(1)
 	}
 	//+kubebuilder:scaffold:builder
@@ -21,6 +21,10 @@ const (
 	// the value is the node's name
 	DiscoveryNodeLabel = "discovery-result-node"
 
+	// DiscoveryFiltersEnv passes the inclusionSpec and exclusionSpec of the LocalVolumeDiscovery, as JSON, to
+	// the discovery daemonset
+	DiscoveryFiltersEnv = "DISCOVERY_FILTERS"
+
 	DeviceFinderDiscoveryDaemonSetTemplate = "templates/devicefinder-discovery-daemonset.yaml"
 )
 
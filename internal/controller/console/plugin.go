@@ -119,3 +119,33 @@ func EnablePlugin(ctx context.Context, cl client.Client) error {
 	}
 	return nil
 }
+
+// DisablePlugin removes the plugin from the console, the counterpart of EnablePlugin
+func DisablePlugin(ctx context.Context, cl client.Client) error {
+	consoleKey := client.ObjectKey{Namespace: "", Name: "cluster"}
+	consoleObj := &operatorv1.Console{}
+	if err := cl.Get(ctx, consoleKey, consoleObj); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("could not find resource - APIVersion: %s, Kind: %s, Name: %s: %w",
+			consoleObj.APIVersion, consoleObj.Kind, consoleObj.Name, err)
+	}
+
+	if idx := slices.Index(consoleObj.Spec.Plugins, PluginName); idx >= 0 {
+		consoleObj.Spec.Plugins = slices.Delete(consoleObj.Spec.Plugins, idx, idx+1)
+		if err := cl.Update(ctx, consoleObj); err != nil {
+			return fmt.Errorf("could not update resource - APIVersion: %s, Kind: %s, Name: %s: %w",
+				consoleObj.APIVersion, consoleObj.Kind, consoleObj.Name, err)
+		}
+	}
+	return nil
+}
+
+// DeletePlugin deletes the ConsolePlugin created by CreateOrUpdatePlugin
+func DeletePlugin(ctx context.Context, cl client.Client) error {
+	cp := &consolev1.ConsolePlugin{ObjectMeta: metav1.ObjectMeta{Name: PluginName}}
+	if err := cl.Delete(ctx, cp); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("could not delete console plugin: %w", err)
+	}
+	return nil
+}
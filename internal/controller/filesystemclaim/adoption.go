@@ -0,0 +1,303 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+const (
+	// Reason constants for the adoption of an existing Filesystem
+	ReasonAdoptionSucceeded = "AdoptionSucceeded"
+	ReasonAdoptionFailed    = "AdoptionFailed"
+
+	// AdoptionAnnotationTimestamp records when a resource was adopted by a FileSystemClaim
+	AdoptionAnnotationTimestamp = "fusion.storage.openshift.io/adoption-timestamp"
+
+	// adoptionRetryInterval is how long to wait before validating the adopted resources again
+	adoptionRetryInterval = time.Minute
+)
+
+// adoptedFilesystemName returns the Filesystem named by the adopt-filesystem annotation, if any
+func adoptedFilesystemName(fsc *fusionv1alpha1.FileSystemClaim) string {
+	return fsc.Annotations[fusionv1alpha1.AdoptFilesystemAnnotation]
+}
+
+// fileSystemName returns the name of the Filesystem of the FSC: the adopted one or the one named after the claim
+func fileSystemName(fsc *fusionv1alpha1.FileSystemClaim) string {
+	if name := adoptedFilesystemName(fsc); name != "" {
+		return name
+	}
+	return fsc.Name
+}
+
+// ensureAdoption takes ownership of the existing Filesystem named by the adopt-filesystem annotation, with its
+// LocalDisks and StorageClass. The resources are validated like the v1.0 migration groups and only their
+// metadata is changed. Until the adoption succeeds, the reconciliation stops here so no new resources are created.
+// Returns: (requeueAfter time.Duration, changed bool, err error)
+func (r *FileSystemClaimReconciler) ensureAdoption(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim) (time.Duration, bool, error) {
+	logger := log.FromContext(ctx)
+
+	fsName := adoptedFilesystemName(fsc)
+	if fsName == "" || r.isConditionTrue(fsc, fusionv1alpha1.ConditionTypeAdopted) {
+		return 0, false, nil
+	}
+
+	group, err := r.buildAdoptionGroup(ctx, fsc, fsName)
+	if err != nil {
+		logger.Info("Filesystem cannot be adopted", "filesystem", fsName, "reason", err.Error())
+		changed, e := r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeAdopted, metav1.ConditionFalse, ReasonAdoptionFailed,
+			fmt.Sprintf("Cannot adopt Filesystem %s: %v", fsName, err))
+		if e != nil {
+			return 0, false, e
+		}
+		return adoptionRetryInterval, changed, nil
+	}
+
+	// The devices of the claim are the devices of the adopted LocalDisks
+	if len(fsc.Spec.Devices) == 0 {
+		if err := r.patchFSCSpec(ctx, fsc, func(cur *fusionv1alpha1.FileSystemClaim) {
+			cur.Spec.Devices = group.DevicePaths
+		}); err != nil {
+			return 0, false, fmt.Errorf("failed to record adopted devices: %w", err)
+		}
+		logger.Info("Recorded adopted devices", "filesystem", fsName, "devices", group.DevicePaths)
+		return 0, true, nil
+	}
+
+	if err := adoptResourceGroup(ctx, r.Client, fsc, group); err != nil {
+		return 0, false, fmt.Errorf("failed to adopt Filesystem %s: %w", fsName, err)
+	}
+
+	adoption := &fusionv1alpha1.AdoptionStatus{Filesystem: fsName, AdoptedAt: &metav1.Time{Time: time.Now()}}
+	for _, ld := range group.LocalDisks {
+		adoption.LocalDisks = append(adoption.LocalDisks, ld.GetName())
+	}
+	if group.StorageClass != nil {
+		adoption.StorageClass = group.StorageClass.Name
+	}
+
+	msg := fmt.Sprintf("Adopted Filesystem %s with %d LocalDisks", fsName, len(group.LocalDisks))
+	if err := r.patchFSCStatus(ctx, fsc, func(cur *fusionv1alpha1.FileSystemClaim) {
+		cur.Status.Adoption = adoption
+		for _, cond := range adoptedConditions(group) {
+			cur.Status.Conditions = utils.UpdateCondition(cur.Status.Conditions, cond.Type, cond.Status, cond.Reason, cond.Message, cur.Generation)
+		}
+		cur.Status.Conditions = utils.UpdateCondition(cur.Status.Conditions, fusionv1alpha1.ConditionTypeAdopted,
+			metav1.ConditionTrue, ReasonAdoptionSucceeded, msg, cur.Generation)
+	}); err != nil {
+		return 0, false, err
+	}
+	r.reportConditionEvent(fsc, fusionv1alpha1.ConditionTypeAdopted, metav1.ConditionTrue, ReasonAdoptionSucceeded, msg)
+
+	logger.Info("Adopted Filesystem", "filesystem", fsName, "localDisks", adoption.LocalDisks, "storageClass", adoption.StorageClass)
+	return 0, true, nil
+}
+
+// buildAdoptionGroup collects the Filesystem, LocalDisks and StorageClass to adopt and validates them with the
+// checks of the v1.0 migration. The resources must not belong to anything else and spec.devices, when set,
+// must list exactly the devices of the LocalDisks.
+func (r *FileSystemClaimReconciler) buildAdoptionGroup(
+	ctx context.Context,
+	fsc *fusionv1alpha1.FileSystemClaim,
+	fsName string,
+) (*LegacyResourceGroup, error) {
+	ldList := &unstructured.UnstructuredList{}
+	ldList.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   LocalDiskGroup,
+		Version: LocalDiskVersion,
+		Kind:    LocalDiskList,
+	})
+	if err := r.List(ctx, ldList, client.InNamespace(fsc.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list LocalDisks: %w", err)
+	}
+
+	var localDisks []*unstructured.Unstructured
+	for i := range ldList.Items {
+		ld := &ldList.Items[i]
+		if usedBy, _, _ := unstructured.NestedString(ld.Object, "status", "filesystem"); usedBy == fsName {
+			localDisks = append(localDisks, ld)
+		}
+	}
+	sort.Slice(localDisks, func(i, j int) bool { return localDisks[i].GetName() < localDisks[j].GetName() })
+
+	group := groupLocalDisksByFilesystem(localDisks)[fsName]
+	if group == nil {
+		return nil, fmt.Errorf("no LocalDisks are used by the Filesystem")
+	}
+	group.StorageClassName = fusionv1alpha1.StorageClassNameOf(fsc)
+
+	if err := validateResourceGroup(ctx, r.Client, group); err != nil {
+		return nil, err
+	}
+
+	for _, ld := range group.LocalDisks {
+		if isOwnedByOtherThanFSC(ld, fsc.Name) {
+			return nil, fmt.Errorf("LocalDisk %s is owned by another resource", ld.GetName())
+		}
+	}
+	if isOwnedByOtherThanFSC(group.Filesystem, fsc.Name) {
+		return nil, fmt.Errorf("filesystem is owned by another resource")
+	}
+
+	if sc := group.StorageClass; sc != nil {
+		if backend := sc.Parameters["volBackendFs"]; backend != fsName {
+			return nil, fmt.Errorf("StorageClass %s provisions volumes on Filesystem %q", sc.Name, backend)
+		}
+		owner := sc.Labels[FileSystemClaimOwnedByNameLabel]
+		if owner != "" && (owner != fsc.Name || sc.Labels[FileSystemClaimOwnedByNamespaceLabel] != fsc.Namespace) {
+			return nil, fmt.Errorf("StorageClass %s is owned by FileSystemClaim %s", sc.Name, owner)
+		}
+	}
+
+	if len(fsc.Spec.Devices) > 0 && !sameDevices(fsc.Spec.Devices, group.DevicePaths) {
+		return nil, fmt.Errorf("spec.devices %v does not match the devices of the LocalDisks %v", fsc.Spec.Devices, group.DevicePaths)
+	}
+
+	return group, nil
+}
+
+// adoptResourceGroup adds the ownerRef and ownership labels of the FSC to the LocalDisks and the Filesystem, and
+// the ownership labels to the StorageClass. Specs are left untouched.
+func adoptResourceGroup(ctx context.Context, c client.Client, fsc *fusionv1alpha1.FileSystemClaim, group *LegacyResourceGroup) error {
+	timestamp := time.Now().Format(time.RFC3339)
+
+	objs := make([]client.Object, 0, len(group.LocalDisks)+2)
+	for _, ld := range group.LocalDisks {
+		objs = append(objs, ld)
+	}
+	objs = append(objs, group.Filesystem)
+
+	for _, obj := range objs {
+		if hasOwnerRefToFSC(obj, fsc.Name) {
+			continue
+		}
+		orig := obj.DeepCopyObject().(client.Object)
+		if err := recordPreMigrationState(obj); err != nil {
+			return fmt.Errorf("failed to record the state of %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+		if err := addOwnerRefAndStandardLabels(obj, fsc, c.Scheme()); err != nil {
+			return fmt.Errorf("failed to add ownerRef to %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+		setAdoptionTimestamp(obj, timestamp)
+		if err := c.Patch(ctx, obj, client.MergeFrom(orig)); err != nil {
+			return fmt.Errorf("failed to patch %s %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, obj.GetName(), err)
+		}
+	}
+
+	if sc := group.StorageClass; sc != nil && !isStorageClassOwnedByFSC(sc, fsc) {
+		orig := sc.DeepCopy()
+		if err := recordPreMigrationState(sc); err != nil {
+			return fmt.Errorf("failed to record the state of StorageClass %s: %w", sc.Name, err)
+		}
+		labels := sc.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[FileSystemClaimOwnedByNameLabel] = fsc.Name
+		labels[FileSystemClaimOwnedByNamespaceLabel] = fsc.Namespace
+		sc.SetLabels(labels)
+		setAdoptionTimestamp(sc, timestamp)
+		if err := c.Patch(ctx, sc, client.MergeFrom(orig)); err != nil {
+			return fmt.Errorf("failed to patch StorageClass %s: %w", sc.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// adoptedConditions returns the conditions marking the adopted resources as created, so the claim manages
+// them like the resources it creates
+func adoptedConditions(group *LegacyResourceGroup) []metav1.Condition {
+	conds := []metav1.Condition{
+		{
+			Type:    fusionv1alpha1.ConditionTypeDeviceValidated,
+			Status:  metav1.ConditionTrue,
+			Reason:  ReasonAdoptionSucceeded,
+			Message: "Devices of the adopted LocalDisks",
+		},
+		{
+			Type:    fusionv1alpha1.ConditionTypeLocalDiskCreated,
+			Status:  metav1.ConditionTrue,
+			Reason:  ReasonAdoptionSucceeded,
+			Message: fmt.Sprintf("Adopted %d existing LocalDisks", len(group.LocalDisks)),
+		},
+		{
+			Type:    fusionv1alpha1.ConditionTypeFileSystemCreated,
+			Status:  metav1.ConditionTrue,
+			Reason:  ReasonAdoptionSucceeded,
+			Message: fmt.Sprintf("Adopted existing Filesystem %s", group.FilesystemName),
+		},
+	}
+	if group.StorageClass != nil {
+		conds = append(conds, metav1.Condition{
+			Type:    fusionv1alpha1.ConditionTypeStorageClassCreated,
+			Status:  metav1.ConditionTrue,
+			Reason:  ReasonAdoptionSucceeded,
+			Message: fmt.Sprintf("Adopted existing StorageClass %s", group.StorageClass.Name),
+		})
+	}
+	return conds
+}
+
+// isOwnedByOtherThanFSC reports whether obj has an ownerRef to anything but the given FSC
+func isOwnedByOtherThanFSC(obj client.Object, fscName string) bool {
+	for _, ownerRef := range obj.GetOwnerReferences() {
+		if ownerRef.Kind != FileSystemClaimKind || ownerRef.Name != fscName {
+			return true
+		}
+	}
+	return false
+}
+
+// sameDevices reports whether both lists hold the same devices, ignoring order and duplicates
+func sameDevices(a, b []string) bool {
+	set := make(map[string]struct{}, len(a))
+	for _, device := range a {
+		set[device] = struct{}{}
+	}
+	seen := make(map[string]struct{}, len(b))
+	for _, device := range b {
+		if _, ok := set[device]; !ok {
+			return false
+		}
+		seen[device] = struct{}{}
+	}
+	return len(seen) == len(set)
+}
+
+// setAdoptionTimestamp records when obj was adopted (human-readable RFC3339 format)
+func setAdoptionTimestamp(obj client.Object, timestamp string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[AdoptionAnnotationTimestamp] = timestamp
+	obj.SetAnnotations(annotations)
+}
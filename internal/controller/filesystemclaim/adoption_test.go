@@ -0,0 +1,213 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("FileSystemClaim adoption", func() {
+	const namespace = MigrationNamespace
+
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		fsc    *fusionv1alpha1.FileSystemClaim
+	)
+
+	legacyStorageClass := func() *storagev1.StorageClass {
+		return &storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: "shared-data"},
+			Provisioner: SpectrumScaleProvisioner,
+			Parameters:  map[string]string{"volBackendFs": "legacy-fs"},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(fusionv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fsc = createTestFSC("shared-data", namespace, nil, nil)
+		fsc.Annotations = map[string]string{fusionv1alpha1.AdoptFilesystemAnnotation: "legacy-fs"}
+	})
+
+	It("should not adopt anything without the annotation", func() {
+		fsc.Annotations = nil
+		r := newFakeReconciler(scheme, fsc)
+
+		requeueAfter, changed, err := r.ensureAdoption(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requeueAfter).To(BeZero())
+		Expect(changed).To(BeFalse())
+	})
+
+	It("should take ownership of the Filesystem, its LocalDisks and its StorageClass", func() {
+		r := newFakeReconciler(scheme, fsc,
+			createV1LocalDisk("disk-a", namespace, "/dev/sdb", "worker-1", "legacy-fs"),
+			createV1LocalDisk("disk-b", namespace, "/dev/sdc", "worker-2", "legacy-fs"),
+			createV1LocalDisk("disk-other", namespace, "/dev/sdd", "worker-1", "other-fs"),
+			createV1Filesystem("legacy-fs", namespace),
+			legacyStorageClass(),
+		)
+
+		// The devices of the LocalDisks are recorded first
+		_, changed, err := r.ensureAdoption(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		fsc = getFSC(ctx, r, fsc)
+		Expect(fsc.Spec.Devices).To(Equal([]string{"/dev/sdb", "/dev/sdc"}))
+
+		requeueAfter, changed, err := r.ensureAdoption(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requeueAfter).To(BeZero())
+		Expect(changed).To(BeTrue())
+
+		for _, name := range []string{"disk-a", "disk-b"} {
+			ld := getUnstructured(ctx, r, localDiskGVK, namespace, name)
+			Expect(isOwnedByThisFSC(ld, fsc.Name)).To(BeTrue())
+			Expect(ld.GetAnnotations()).To(HaveKey(AdoptionAnnotationTimestamp))
+			device, _, _ := unstructured.NestedString(ld.Object, "spec", "device")
+			Expect(device).NotTo(BeEmpty())
+		}
+		Expect(getUnstructured(ctx, r, localDiskGVK, namespace, "disk-other").GetOwnerReferences()).To(BeEmpty())
+
+		fs := getUnstructured(ctx, r, fileSystemGVK, namespace, "legacy-fs")
+		Expect(isOwnedByThisFSC(fs, fsc.Name)).To(BeTrue())
+		pools, _, _ := unstructured.NestedSlice(fs.Object, "spec", "local", "pools")
+		Expect(pools).To(HaveLen(1))
+
+		sc := &storagev1.StorageClass{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: "shared-data"}, sc)).To(Succeed())
+		Expect(isStorageClassOwnedByFSC(sc, fsc)).To(BeTrue())
+
+		fsc = getFSC(ctx, r, fsc)
+		Expect(fsc.Status.Adoption).NotTo(BeNil())
+		Expect(fsc.Status.Adoption.Filesystem).To(Equal("legacy-fs"))
+		Expect(fsc.Status.Adoption.LocalDisks).To(Equal([]string{"disk-a", "disk-b"}))
+		Expect(fsc.Status.Adoption.StorageClass).To(Equal("shared-data"))
+		for _, condType := range []string{
+			fusionv1alpha1.ConditionTypeAdopted,
+			fusionv1alpha1.ConditionTypeDeviceValidated,
+			fusionv1alpha1.ConditionTypeLocalDiskCreated,
+			fusionv1alpha1.ConditionTypeFileSystemCreated,
+			fusionv1alpha1.ConditionTypeStorageClassCreated,
+		} {
+			Expect(r.isConditionTrue(fsc, condType)).To(BeTrue(), condType)
+		}
+
+		// Once adopted, nothing is left to do
+		requeueAfter, changed, err = r.ensureAdoption(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requeueAfter).To(BeZero())
+		Expect(changed).To(BeFalse())
+	})
+
+	It("should leave the spec of the adopted Filesystem untouched", func() {
+		fs := createV1Filesystem("legacy-fs", namespace)
+		fs.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: "fusion.storage.openshift.io/v1alpha1", Kind: FileSystemClaimKind, Name: fsc.Name, UID: fsc.UID,
+		}})
+		fsc.Spec.Devices = []string{"/dev/sdb"}
+		fsc.Status.Conditions = []metav1.Condition{localDiskCreatedCondition(metav1.ConditionTrue, ReasonAdoptionSucceeded)}
+		r := newFakeReconciler(scheme, fsc, fs, createLocalDiskWithOwner("disk-a", namespace, "/dev/sdb", "worker-1", fsc))
+
+		changed, err := r.ensureFileSystem(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeFalse())
+
+		current := getUnstructured(ctx, r, fileSystemGVK, namespace, "legacy-fs")
+		Expect(current.Object["spec"]).To(Equal(fs.Object["spec"]))
+	})
+
+	DescribeTable("should report why the resources cannot be adopted",
+		func(devices []string, objs func() []client.Object, message string) {
+			fsc.Spec.Devices = devices
+			r := newFakeReconciler(scheme, append(objs(), fsc)...)
+
+			requeueAfter, changed, err := r.ensureAdoption(ctx, fsc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requeueAfter).To(Equal(adoptionRetryInterval))
+			Expect(changed).To(BeTrue())
+
+			cond := findCondition(getFSC(ctx, r, fsc).Status.Conditions, fusionv1alpha1.ConditionTypeAdopted)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal(ReasonAdoptionFailed))
+			Expect(cond.Message).To(ContainSubstring(message))
+		},
+		Entry("missing Filesystem", nil, func() []client.Object {
+			return []client.Object{createV1LocalDisk("disk-a", namespace, "/dev/sdb", "worker-1", "legacy-fs")}
+		}, "filesystem legacy-fs not found"),
+		Entry("no LocalDisks", nil, func() []client.Object {
+			return []client.Object{createV1Filesystem("legacy-fs", namespace)}
+		}, "no LocalDisks are used by the Filesystem"),
+		Entry("LocalDisk owned by another resource", nil, func() []client.Object {
+			ld := createLocalDiskWithOwner("disk-a", namespace, "/dev/sdb", "worker-1", createTestFSC("other-fsc", namespace, nil, nil))
+			Expect(unstructured.SetNestedField(ld.Object, "legacy-fs", "status", "filesystem")).To(Succeed())
+			return []client.Object{ld, createV1Filesystem("legacy-fs", namespace)}
+		}, "LocalDisk disk-a is owned by another resource"),
+		Entry("StorageClass of another Filesystem", nil, func() []client.Object {
+			sc := legacyStorageClass()
+			sc.Parameters["volBackendFs"] = "other-fs"
+			return []client.Object{
+				createV1LocalDisk("disk-a", namespace, "/dev/sdb", "worker-1", "legacy-fs"),
+				createV1Filesystem("legacy-fs", namespace),
+				sc,
+			}
+		}, `provisions volumes on Filesystem "other-fs"`),
+		Entry("devices that do not match the LocalDisks", []string{"/dev/sdz"}, func() []client.Object {
+			return []client.Object{
+				createV1LocalDisk("disk-a", namespace, "/dev/sdb", "worker-1", "legacy-fs"),
+				createV1Filesystem("legacy-fs", namespace),
+			}
+		}, "does not match the devices of the LocalDisks"),
+	)
+
+	It("should refuse a Filesystem owned by another resource", func() {
+		fs := createV1Filesystem("legacy-fs", namespace)
+		fs.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: "fusion.storage.openshift.io/v1alpha1", Kind: FileSystemClaimKind, Name: "other-fsc", UID: "other-uid",
+		}})
+		r := newFakeReconciler(scheme, fsc, fs, createV1LocalDisk("disk-a", namespace, "/dev/sdb", "worker-1", "legacy-fs"))
+
+		requeueAfter, _, err := r.ensureAdoption(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requeueAfter).To(Equal(adoptionRetryInterval))
+		cond := findCondition(getFSC(ctx, r, fsc).Status.Conditions, fusionv1alpha1.ConditionTypeAdopted)
+		Expect(cond.Message).To(ContainSubstring("filesystem is owned by another resource"))
+	})
+
+	It("should name the Filesystem after the annotation", func() {
+		Expect(fileSystemName(fsc)).To(Equal("legacy-fs"))
+		fsc.Annotations = nil
+		Expect(fileSystemName(fsc)).To(Equal("shared-data"))
+	})
+})
@@ -0,0 +1,340 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// IBM Spectrum Scale job resources used to drain and delete disks
+const (
+	ScaleJobGroup   = "scale.spectrum.ibm.com"
+	ScaleJobVersion = "v1alpha1"
+
+	DiskJobKind = "DiskJob"
+
+	RestripeFSJobKind = "RestripeFSJob"
+
+	DiskJobActionEmpty       = "empty"
+	DiskJobActionDelete      = "delete"
+	RestripeFSJobModeMigrate = "migrate"
+)
+
+// ensureDeviceRemovals drives the devices listed in spec.removeDevices through the removal phases, one device
+// at a time and in list order:
+//
+//	Pending -> Emptying (DiskJob empty) -> Migrating (RestripeFSJob migrate) -> Deleting (DiskJob delete) -> Removed
+//
+// The LocalDisk is left out of the Filesystem pools from the Deleting phase on and is deleted once the
+// delete DiskJob succeeds. Returns changed=true if we wrote status or created/deleted a resource.
+func (r *FileSystemClaimReconciler) ensureDeviceRemovals(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim) (bool, error) {
+	if len(fsc.Spec.RemoveDevices) == 0 && len(fsc.Status.DeviceRemovals) == 0 {
+		return false, nil
+	}
+
+	// Keep one status entry per device in spec.removeDevices, in the same order
+	desired := make([]fusionv1alpha1.DeviceRemovalStatus, 0, len(fsc.Spec.RemoveDevices))
+	for _, device := range fsc.Spec.RemoveDevices {
+		removal := fusionv1alpha1.DeviceRemovalStatus{
+			Device:  device,
+			Phase:   fusionv1alpha1.DeviceRemovalPending,
+			Message: "Waiting for the Filesystem to be ready",
+		}
+		if cur := findDeviceRemoval(fsc.Status.DeviceRemovals, device); cur != nil {
+			removal = *cur
+		}
+		desired = append(desired, removal)
+	}
+	if !reflect.DeepEqual(desired, fsc.Status.DeviceRemovals) {
+		if err := r.patchFSCStatus(ctx, fsc, func(cur *fusionv1alpha1.FileSystemClaim) {
+			cur.Status.DeviceRemovals = desired
+		}); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if !r.isConditionTrue(fsc, fusionv1alpha1.ConditionTypeFileSystemCreated) {
+		return false, nil
+	}
+
+	for i := range desired {
+		if desired[i].Phase != fusionv1alpha1.DeviceRemovalRemoved {
+			return r.advanceDeviceRemoval(ctx, fsc, desired[i])
+		}
+	}
+	return false, nil
+}
+
+// advanceDeviceRemoval moves a single device removal forward by at most one phase
+func (r *FileSystemClaimReconciler) advanceDeviceRemoval(
+	ctx context.Context,
+	fsc *fusionv1alpha1.FileSystemClaim,
+	removal fusionv1alpha1.DeviceRemovalStatus,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	ownedFS, err := r.listOwnedResources(ctx, fsc, schema.GroupVersionKind{
+		Group:   FileSystemGroup,
+		Version: FileSystemVersion,
+		Kind:    FileSystemKind,
+	}, FileSystemList)
+	if err != nil {
+		return false, fmt.Errorf("list Filesystems: %w", err)
+	}
+	if len(ownedFS) != 1 {
+		removal.Message = fmt.Sprintf("found %d Filesystems owned by FSC; expected 1", len(ownedFS))
+		return r.setDeviceRemoval(ctx, fsc, removal)
+	}
+	fsName := ownedFS[0].GetName()
+
+	if removal.LocalDisk == "" {
+		ld, err := r.findOwnedLocalDiskByDevice(ctx, fsc, removal.Device)
+		if err != nil {
+			return false, err
+		}
+		if ld == nil {
+			removal.Phase = fusionv1alpha1.DeviceRemovalRemoved
+			removal.Message = "No LocalDisk found for device"
+			return r.setDeviceRemoval(ctx, fsc, removal)
+		}
+		removal.LocalDisk = ld.GetName()
+	}
+
+	switch removal.Phase {
+	case fusionv1alpha1.DeviceRemovalPending:
+		if err := r.ensureScaleJob(ctx, fsc, DiskJobKind, diskJobName(fsc, DiskJobActionEmpty, removal.LocalDisk), map[string]any{
+			"action":     DiskJobActionEmpty,
+			"filesystem": fsName,
+			"diskNames":  []any{removal.LocalDisk},
+		}); err != nil {
+			removal.Message = err.Error()
+			return r.setDeviceRemoval(ctx, fsc, removal)
+		}
+		logger.Info("Emptying disk", "device", removal.Device, "localDisk", removal.LocalDisk, "filesystem", fsName)
+		removal.Phase = fusionv1alpha1.DeviceRemovalEmptying
+		removal.Message = "Emptying disk so no new data is placed on it"
+		return r.setDeviceRemoval(ctx, fsc, removal)
+
+	case fusionv1alpha1.DeviceRemovalEmptying:
+		done, msg, err := r.scaleJobStatus(ctx, fsc, DiskJobKind, diskJobName(fsc, DiskJobActionEmpty, removal.LocalDisk))
+		if err != nil {
+			return false, err
+		}
+		if !done {
+			removal.Message = msg
+			return r.setDeviceRemoval(ctx, fsc, removal)
+		}
+		if err := r.ensureScaleJob(ctx, fsc, RestripeFSJobKind, diskJobName(fsc, RestripeFSJobModeMigrate, removal.LocalDisk), map[string]any{
+			"filesystem": fsName,
+			"mode":       RestripeFSJobModeMigrate,
+		}); err != nil {
+			removal.Message = err.Error()
+			return r.setDeviceRemoval(ctx, fsc, removal)
+		}
+		logger.Info("Migrating data off disk", "device", removal.Device, "localDisk", removal.LocalDisk, "filesystem", fsName)
+		removal.Phase = fusionv1alpha1.DeviceRemovalMigrating
+		removal.Message = "Migrating data off the disk"
+		return r.setDeviceRemoval(ctx, fsc, removal)
+
+	case fusionv1alpha1.DeviceRemovalMigrating:
+		done, msg, err := r.scaleJobStatus(ctx, fsc, RestripeFSJobKind, diskJobName(fsc, RestripeFSJobModeMigrate, removal.LocalDisk))
+		if err != nil {
+			return false, err
+		}
+		if !done {
+			removal.Message = msg
+			return r.setDeviceRemoval(ctx, fsc, removal)
+		}
+		// ensureFileSystem drops the disk from the Filesystem pools before the delete DiskJob is created
+		removal.Phase = fusionv1alpha1.DeviceRemovalDeleting
+		removal.Message = "Data migrated, removing disk from the Filesystem"
+		return r.setDeviceRemoval(ctx, fsc, removal)
+
+	case fusionv1alpha1.DeviceRemovalDeleting:
+		jobName := diskJobName(fsc, DiskJobActionDelete, removal.LocalDisk)
+		if err := r.ensureScaleJob(ctx, fsc, DiskJobKind, jobName, map[string]any{
+			"action":     DiskJobActionDelete,
+			"filesystem": fsName,
+			"diskNames":  []any{removal.LocalDisk},
+		}); err != nil {
+			removal.Message = err.Error()
+			return r.setDeviceRemoval(ctx, fsc, removal)
+		}
+		done, msg, err := r.scaleJobStatus(ctx, fsc, DiskJobKind, jobName)
+		if err != nil {
+			return false, err
+		}
+		if !done {
+			removal.Message = msg
+			return r.setDeviceRemoval(ctx, fsc, removal)
+		}
+
+		ld := &unstructured.Unstructured{}
+		ld.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   LocalDiskGroup,
+			Version: LocalDiskVersion,
+			Kind:    LocalDiskKind,
+		})
+		ld.SetName(removal.LocalDisk)
+		ld.SetNamespace(fsc.Namespace)
+		if err := r.Delete(ctx, ld); err != nil && !errors.IsNotFound(err) {
+			removal.Message = fmt.Sprintf("failed to delete LocalDisk %s: %v", removal.LocalDisk, err)
+			return r.setDeviceRemoval(ctx, fsc, removal)
+		}
+		logger.Info("Removed disk from Filesystem", "device", removal.Device, "localDisk", removal.LocalDisk, "filesystem", fsName)
+		removal.Phase = fusionv1alpha1.DeviceRemovalRemoved
+		removal.Message = "Disk removed from the Filesystem and LocalDisk deleted"
+		return r.setDeviceRemoval(ctx, fsc, removal)
+	}
+
+	return false, nil
+}
+
+// detachedDevices returns the devices that must no longer be part of the Filesystem pools
+func detachedDevices(fsc *fusionv1alpha1.FileSystemClaim) map[string]struct{} {
+	detached := make(map[string]struct{})
+	for _, removal := range fsc.Status.DeviceRemovals {
+		if removal.Phase == fusionv1alpha1.DeviceRemovalDeleting || removal.Phase == fusionv1alpha1.DeviceRemovalRemoved {
+			detached[removal.Device] = struct{}{}
+		}
+	}
+	return detached
+}
+
+// findDeviceRemoval returns the status entry of the given device or nil
+func findDeviceRemoval(removals []fusionv1alpha1.DeviceRemovalStatus, device string) *fusionv1alpha1.DeviceRemovalStatus {
+	for i := range removals {
+		if removals[i].Device == device {
+			return &removals[i]
+		}
+	}
+	return nil
+}
+
+// setDeviceRemoval updates the status entry of a device only if it changed
+func (r *FileSystemClaimReconciler) setDeviceRemoval(
+	ctx context.Context,
+	fsc *fusionv1alpha1.FileSystemClaim,
+	removal fusionv1alpha1.DeviceRemovalStatus,
+) (bool, error) {
+	if cur := findDeviceRemoval(fsc.Status.DeviceRemovals, removal.Device); cur != nil && *cur == removal {
+		return false, nil
+	}
+
+	if err := r.patchFSCStatus(ctx, fsc, func(cur *fusionv1alpha1.FileSystemClaim) {
+		if existing := findDeviceRemoval(cur.Status.DeviceRemovals, removal.Device); existing != nil {
+			*existing = removal
+			return
+		}
+		cur.Status.DeviceRemovals = append(cur.Status.DeviceRemovals, removal)
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// findOwnedLocalDiskByDevice returns the owned LocalDisk backing the given device or nil
+func (r *FileSystemClaimReconciler) findOwnedLocalDiskByDevice(
+	ctx context.Context,
+	fsc *fusionv1alpha1.FileSystemClaim,
+	device string,
+) (*unstructured.Unstructured, error) {
+	owned, err := r.listOwnedResources(ctx, fsc, schema.GroupVersionKind{
+		Group:   LocalDiskGroup,
+		Version: LocalDiskVersion,
+		Kind:    LocalDiskKind,
+	}, LocalDiskList)
+	if err != nil {
+		return nil, fmt.Errorf("list LocalDisks: %w", err)
+	}
+	for i := range owned {
+		if localDiskDevice(&owned[i]) == device {
+			return &owned[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// diskJobName returns the deterministic name of the job running the given action on a LocalDisk
+func diskJobName(fsc *fusionv1alpha1.FileSystemClaim, action, localDisk string) string {
+	return fmt.Sprintf("%s-%s-%s", fsc.Name, action, localDisk)
+}
+
+// ensureScaleJob creates a DiskJob or RestripeFSJob owned by the FSC if it does not exist yet
+func (r *FileSystemClaimReconciler) ensureScaleJob(
+	ctx context.Context,
+	fsc *fusionv1alpha1.FileSystemClaim,
+	kind, name string,
+	spec map[string]any,
+) error {
+	job := &unstructured.Unstructured{}
+	job.SetGroupVersionKind(schema.GroupVersionKind{Group: ScaleJobGroup, Version: ScaleJobVersion, Kind: kind})
+
+	err := r.Get(ctx, types.NamespacedName{Namespace: fsc.Namespace, Name: name}, job)
+	switch {
+	case err == nil:
+		return nil
+	case !errors.IsNotFound(err):
+		return fmt.Errorf("get %s %s: %w", kind, name, err)
+	}
+
+	job.SetName(name)
+	spec["run"] = "once"
+	if err := r.createResourceWithOwnership(ctx, fsc, job, spec); err != nil {
+		return fmt.Errorf("create %s %s: %w", kind, name, err)
+	}
+	return nil
+}
+
+// scaleJobStatus reports whether a DiskJob or RestripeFSJob completed successfully. While it is not done,
+// the returned message describes what the job is waiting for or why its last run failed.
+func (r *FileSystemClaimReconciler) scaleJobStatus(
+	ctx context.Context,
+	fsc *fusionv1alpha1.FileSystemClaim,
+	kind, name string,
+) (bool, string, error) {
+	job := &unstructured.Unstructured{}
+	job.SetGroupVersionKind(schema.GroupVersionKind{Group: ScaleJobGroup, Version: ScaleJobVersion, Kind: kind})
+
+	if err := r.Get(ctx, types.NamespacedName{Namespace: fsc.Namespace, Name: name}, job); err != nil {
+		if errors.IsNotFound(err) {
+			return false, fmt.Sprintf("%s %s not found", kind, name), nil
+		}
+		return false, "", fmt.Errorf("get %s %s: %w", kind, name, err)
+	}
+
+	if lastSuccess, _, _ := unstructured.NestedString(job.Object, "status", "lastSuccessfulTime"); lastSuccess != "" {
+		return true, "", nil
+	}
+
+	failedRuns, _, _ := unstructured.NestedInt64(job.Object, "status", "consecutiveFailedRuns")
+	if failedRuns > 0 {
+		msg, _, _ := unstructured.NestedString(job.Object, "status", "completed", "message")
+		return false, fmt.Sprintf("%s %s failed %d times, retrying: %s", kind, name, failedRuns, msg), nil
+	}
+	return false, fmt.Sprintf("Waiting for %s %s to complete", kind, name), nil
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("Device removal", func() {
+	var (
+		ctx        context.Context
+		scheme     *runtime.Scheme
+		namespace  = "ibm-spectrum-scale"
+		fsc        *fusionv1alpha1.FileSystemClaim
+		fs         *unstructured.Unstructured
+		ld1, ld2   *unstructured.Unstructured
+		fakeClient client.Client
+		reconciler *FileSystemClaimReconciler
+	)
+
+	getJob := func(kind, name string) (*unstructured.Unstructured, error) {
+		job := &unstructured.Unstructured{}
+		job.SetGroupVersionKind(schema.GroupVersionKind{Group: ScaleJobGroup, Version: ScaleJobVersion, Kind: kind})
+		err := fakeClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, job)
+		return job, err
+	}
+
+	completeJob := func(kind, name string) {
+		job, err := getJob(kind, name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unstructured.SetNestedField(job.Object, "2025-01-01T00:00:00Z", "status", "lastSuccessfulTime")).To(Succeed())
+		Expect(fakeClient.Update(ctx, job)).To(Succeed())
+	}
+
+	withRemoval := func(phase fusionv1alpha1.DeviceRemovalPhase) {
+		fsc.Status.DeviceRemovals = []fusionv1alpha1.DeviceRemovalStatus{
+			{Device: "/dev/nvme2n2", LocalDisk: "test-ld-2", Phase: phase},
+		}
+	}
+
+	buildClient := func(objs ...client.Object) {
+		reconciler = newFakeReconciler(scheme, append([]client.Object{fsc, fs, ld1, ld2}, objs...)...)
+		fakeClient = reconciler.Client
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(fusionv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fsc = createTestFSC("test-fsc", namespace, []string{"/dev/nvme1n1", "/dev/nvme2n2"}, []metav1.Condition{
+			localDiskCreatedCondition(metav1.ConditionTrue, ReasonLocalDiskCreationSucceeded),
+			filesystemCreatedCondition(metav1.ConditionTrue, ReasonFileSystemCreationSucceeded),
+		})
+		fsc.Spec.RemoveDevices = []string{"/dev/nvme2n2"}
+
+		fs = createV1Filesystem(fsc.Name, namespace)
+		fs.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: "fusion.storage.openshift.io/v1alpha1",
+			Kind:       FileSystemClaimKind,
+			Name:       fsc.Name,
+		}})
+		ld1 = createLocalDiskWithOwner("test-ld-1", namespace, "/dev/nvme1n1", "node1", fsc)
+		ld2 = createLocalDiskWithOwner("test-ld-2", namespace, "/dev/nvme2n2", "node1", fsc)
+	})
+
+	It("should record a Pending entry for each device to remove", func() {
+		buildClient()
+
+		changed, err := reconciler.ensureDeviceRemovals(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		removals := getFSC(ctx, fakeClient, fsc).Status.DeviceRemovals
+		Expect(removals).To(HaveLen(1))
+		Expect(removals[0].Device).To(Equal("/dev/nvme2n2"))
+		Expect(removals[0].Phase).To(Equal(fusionv1alpha1.DeviceRemovalPending))
+	})
+
+	It("should drop entries for devices no longer listed", func() {
+		fsc.Spec.RemoveDevices = nil
+		withRemoval(fusionv1alpha1.DeviceRemovalRemoved)
+		buildClient()
+
+		changed, err := reconciler.ensureDeviceRemovals(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(getFSC(ctx, fakeClient, fsc).Status.DeviceRemovals).To(BeEmpty())
+	})
+
+	It("should wait for the Filesystem before starting", func() {
+		fsc.Status.Conditions = fsc.Status.Conditions[:1]
+		withRemoval(fusionv1alpha1.DeviceRemovalPending)
+		buildClient()
+
+		changed, err := reconciler.ensureDeviceRemovals(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeFalse())
+	})
+
+	It("should empty the disk with a DiskJob", func() {
+		fsc.Status.DeviceRemovals = []fusionv1alpha1.DeviceRemovalStatus{
+			{Device: "/dev/nvme2n2", Phase: fusionv1alpha1.DeviceRemovalPending},
+		}
+		buildClient()
+
+		changed, err := reconciler.ensureDeviceRemovals(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		job, err := getJob(DiskJobKind, "test-fsc-empty-test-ld-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Object["spec"]).To(HaveKeyWithValue("action", DiskJobActionEmpty))
+		Expect(job.Object["spec"]).To(HaveKeyWithValue("filesystem", fsc.Name))
+		Expect(job.Object["spec"]).To(HaveKeyWithValue("diskNames", []any{"test-ld-2"}))
+		Expect(isOwnedByThisFSC(job, fsc.Name)).To(BeTrue())
+
+		removal := getFSC(ctx, fakeClient, fsc).Status.DeviceRemovals[0]
+		Expect(removal.Phase).To(Equal(fusionv1alpha1.DeviceRemovalEmptying))
+		Expect(removal.LocalDisk).To(Equal("test-ld-2"))
+	})
+
+	It("should migrate data once the disk is empty", func() {
+		withRemoval(fusionv1alpha1.DeviceRemovalEmptying)
+		buildClient()
+		Expect(reconciler.ensureScaleJob(ctx, fsc, DiskJobKind, "test-fsc-empty-test-ld-2", map[string]any{})).To(Succeed())
+
+		By("waiting for the DiskJob")
+		changed, err := reconciler.ensureDeviceRemovals(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		fsc = getFSC(ctx, fakeClient, fsc)
+		Expect(fsc.Status.DeviceRemovals[0].Phase).To(Equal(fusionv1alpha1.DeviceRemovalEmptying))
+		Expect(fsc.Status.DeviceRemovals[0].Message).To(ContainSubstring("Waiting for DiskJob"))
+
+		By("starting the RestripeFSJob once the DiskJob succeeded")
+		completeJob(DiskJobKind, "test-fsc-empty-test-ld-2")
+		changed, err = reconciler.ensureDeviceRemovals(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		job, err := getJob(RestripeFSJobKind, "test-fsc-migrate-test-ld-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Object["spec"]).To(HaveKeyWithValue("mode", RestripeFSJobModeMigrate))
+		Expect(getFSC(ctx, fakeClient, fsc).Status.DeviceRemovals[0].Phase).To(Equal(fusionv1alpha1.DeviceRemovalMigrating))
+	})
+
+	It("should report failed job runs", func() {
+		withRemoval(fusionv1alpha1.DeviceRemovalMigrating)
+		buildClient()
+		Expect(reconciler.ensureScaleJob(ctx, fsc, RestripeFSJobKind, "test-fsc-migrate-test-ld-2", map[string]any{})).To(Succeed())
+		job, err := getJob(RestripeFSJobKind, "test-fsc-migrate-test-ld-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unstructured.SetNestedField(job.Object, int64(2), "status", "consecutiveFailedRuns")).To(Succeed())
+		Expect(unstructured.SetNestedField(job.Object, "not enough space", "status", "completed", "message")).To(Succeed())
+		Expect(fakeClient.Update(ctx, job)).To(Succeed())
+
+		_, err = reconciler.ensureDeviceRemovals(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+
+		removal := getFSC(ctx, fakeClient, fsc).Status.DeviceRemovals[0]
+		Expect(removal.Phase).To(Equal(fusionv1alpha1.DeviceRemovalMigrating))
+		Expect(removal.Message).To(ContainSubstring("failed 2 times"))
+		Expect(removal.Message).To(ContainSubstring("not enough space"))
+	})
+
+	It("should detach the disk from the Filesystem once data is migrated", func() {
+		withRemoval(fusionv1alpha1.DeviceRemovalMigrating)
+		buildClient()
+		Expect(reconciler.ensureScaleJob(ctx, fsc, RestripeFSJobKind, "test-fsc-migrate-test-ld-2", map[string]any{})).To(Succeed())
+		completeJob(RestripeFSJobKind, "test-fsc-migrate-test-ld-2")
+
+		changed, err := reconciler.ensureDeviceRemovals(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		fsc = getFSC(ctx, fakeClient, fsc)
+		Expect(fsc.Status.DeviceRemovals[0].Phase).To(Equal(fusionv1alpha1.DeviceRemovalDeleting))
+		Expect(detachedDevices(fsc)).To(HaveKey("/dev/nvme2n2"))
+
+		pools, err := buildFilesystemPools(nil, []unstructured.Unstructured{*ld1, *ld2}, detachedDevices(fsc))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pools).To(Equal([]filesystemPool{{name: "system", disks: []string{"test-ld-1"}}}))
+	})
+
+	It("should delete the disk and its LocalDisk", func() {
+		withRemoval(fusionv1alpha1.DeviceRemovalDeleting)
+		buildClient()
+
+		By("creating the delete DiskJob")
+		changed, err := reconciler.ensureDeviceRemovals(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		job, err := getJob(DiskJobKind, "test-fsc-delete-test-ld-2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(job.Object["spec"]).To(HaveKeyWithValue("action", DiskJobActionDelete))
+
+		By("deleting the LocalDisk once the DiskJob succeeded")
+		completeJob(DiskJobKind, "test-fsc-delete-test-ld-2")
+		fsc = getFSC(ctx, fakeClient, fsc)
+		changed, err = reconciler.ensureDeviceRemovals(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		Expect(getFSC(ctx, fakeClient, fsc).Status.DeviceRemovals[0].Phase).To(Equal(fusionv1alpha1.DeviceRemovalRemoved))
+		ld := &unstructured.Unstructured{}
+		ld.SetGroupVersionKind(schema.GroupVersionKind{Group: LocalDiskGroup, Version: LocalDiskVersion, Kind: LocalDiskKind})
+		err = fakeClient.Get(ctx, types.NamespacedName{Name: "test-ld-2", Namespace: namespace}, ld)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should not recreate the LocalDisk of a removed device", func() {
+		withRemoval(fusionv1alpha1.DeviceRemovalRemoved)
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(fsc, fs, ld1).
+			WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+			Build()
+		reconciler = &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+		changed, err := reconciler.ensureLocalDisks(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeFalse())
+		Expect(findCondition(getFSC(ctx, fakeClient, fsc).Status.Conditions, fusionv1alpha1.ConditionTypeExpanding)).To(BeNil())
+	})
+})
@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// claimDevices returns the device references the FileSystemClaim is made of: spec.devices, or the
+// WWNs recorded in status.resolvedDevices when spec.deviceSelector is used
+func claimDevices(fsc *fusionv1alpha1.FileSystemClaim) []string {
+	if fsc.Spec.DeviceSelector != nil {
+		return fsc.Status.ResolvedDevices
+	}
+	return fsc.Spec.Devices
+}
+
+// resolveDeviceSelector returns the WWNs of the discovered devices matching the selector that are
+// visible on every storage node, sorted for a stable order
+func (r *FileSystemClaimReconciler) resolveDeviceSelector(
+	ctx context.Context,
+	selector *fusionv1alpha1.DeviceSelector,
+) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	lvdrs, err := r.getStorageNodeLVDRs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Count on how many nodes each matching WWN is visible
+	seen := make(map[string]int)
+	for _, lvdr := range lvdrs {
+		onNode := make(map[string]struct{})
+		for i := range lvdr.Status.DiscoveredDevices {
+			device := &lvdr.Status.DiscoveredDevices[i]
+			// Devices already used by Storage Scale are never selected
+			if device.WWN == "" || device.InUse != nil || !matchesDeviceSelector(selector, device) {
+				continue
+			}
+			onNode[strings.ToLower(device.WWN)] = struct{}{}
+		}
+		for wwn := range onNode {
+			seen[wwn]++
+		}
+	}
+
+	var resolved []string
+	for wwn, count := range seen {
+		if count == len(lvdrs) {
+			resolved = append(resolved, wwn)
+		}
+	}
+
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("no discovered device matches spec.deviceSelector on all %d storage nodes", len(lvdrs))
+	}
+	sort.Strings(resolved)
+
+	logger.Info("Resolved spec.deviceSelector", "devices", resolved, "storageNodes", len(lvdrs))
+	return resolved, nil
+}
+
+// matchesDeviceSelector reports whether a discovered device matches every criterion set in the selector
+func matchesDeviceSelector(selector *fusionv1alpha1.DeviceSelector, device *fusionv1alpha1.DiscoveredDevice) bool {
+	if selector.Vendor != "" && !strings.EqualFold(strings.TrimSpace(device.Vendor), strings.TrimSpace(selector.Vendor)) {
+		return false
+	}
+	if selector.Model != "" && !strings.EqualFold(strings.TrimSpace(device.Model), strings.TrimSpace(selector.Model)) {
+		return false
+	}
+	if selector.MinSize != nil && device.Size < selector.MinSize.Value() {
+		return false
+	}
+	if selector.MaxSize != nil && device.Size > selector.MaxSize.Value() {
+		return false
+	}
+	if len(selector.Types) > 0 && !slices.Contains(selector.Types, device.Type) {
+		return false
+	}
+	if selector.WWNPrefix != "" && !strings.HasPrefix(strings.ToLower(device.WWN), strings.ToLower(selector.WWNPrefix)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/events"
+)
+
+// eventReporter returns the deduplicating reporter wrapping r.Recorder
+func (r *FileSystemClaimReconciler) eventReporter() *events.Reporter {
+	r.eventsOnce.Do(func() {
+		r.events = events.NewReporter(r.Recorder)
+	})
+	return r.events
+}
+
+// reportConditionEvent emits an event for a condition transition. The condition type is the topic, so
+// the event is only recorded once per status and reason.
+func (r *FileSystemClaimReconciler) reportConditionEvent(
+	fsc *fusionv1alpha1.FileSystemClaim,
+	conditionType string,
+	status metav1.ConditionStatus,
+	reason string,
+	message string,
+) {
+	if isWarningCondition(conditionType, status, reason) {
+		r.eventReporter().Report(events.NewEvent(conditionType, reason, message), fsc)
+		return
+	}
+	r.eventReporter().Report(events.NewSuccessEvent(conditionType, reason, message), fsc)
+}
+
+// isWarningCondition reports whether a condition needs the attention of the user
+func isWarningCondition(conditionType string, status metav1.ConditionStatus, reason string) bool {
+	switch conditionType {
+	case fusionv1alpha1.ConditionTypeDeletionBlocked, fusionv1alpha1.ConditionTypeCapacityWarning:
+		return status == metav1.ConditionTrue
+	}
+	_, failed := failureReasons[reason]
+	return failed && status == metav1.ConditionFalse
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("FileSystemClaim events", func() {
+	var (
+		ctx        context.Context
+		fsc        *fusionv1alpha1.FileSystemClaim
+		recorder   *record.FakeRecorder
+		reconciler *FileSystemClaimReconciler
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(fusionv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fsc = createTestFSC("test-fsc", "ibm-spectrum-scale", []string{"/dev/sdb"}, nil)
+		fsc.UID = "fsc-uid"
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(fsc).
+			WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+			Build()
+		recorder = record.NewFakeRecorder(10)
+		reconciler = &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+	})
+
+	It("should record a warning once for a repeated validation failure", func() {
+		Expect(reconciler.handleValidationError(ctx, fsc, errors.New("device /dev/sdb not found"))).To(Succeed())
+		Expect(reconciler.handleValidationError(ctx, fsc, errors.New("device /dev/sdb not found"))).To(Succeed())
+
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(<-recorder.Events).To(Equal("Warning DeviceValidationFailed device /dev/sdb not found"))
+	})
+
+	It("should record every transition of a condition", func() {
+		_, err := reconciler.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeLocalDiskCreated,
+			metav1.ConditionFalse, ReasonLocalDiskCreationInProgress, "Waiting for LocalDisk objects to appear")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = reconciler.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeLocalDiskCreated,
+			metav1.ConditionFalse, ReasonLocalDiskCreationFailed, "LocalDisk wwn-1 failed")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = reconciler.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeLocalDiskCreated,
+			metav1.ConditionTrue, ReasonLocalDiskCreationSucceeded, "All LocalDisks are ready")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(recorder.Events).To(HaveLen(3))
+		Expect(<-recorder.Events).To(HavePrefix("Normal " + ReasonLocalDiskCreationInProgress))
+		Expect(<-recorder.Events).To(HavePrefix("Warning " + ReasonLocalDiskCreationFailed))
+		Expect(<-recorder.Events).To(HavePrefix("Normal " + ReasonLocalDiskCreationSucceeded))
+	})
+
+	It("should not record events without a recorder", func() {
+		reconciler.Recorder = nil
+		Expect(reconciler.handleValidationError(ctx, fsc, errors.New("device /dev/sdb not found"))).To(Succeed())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+
+	DescribeTable("isWarningCondition",
+		func(conditionType string, status metav1.ConditionStatus, reason string, expected bool) {
+			Expect(isWarningCondition(conditionType, status, reason)).To(Equal(expected))
+		},
+		Entry("deletion blocked", fusionv1alpha1.ConditionTypeDeletionBlocked, metav1.ConditionTrue, ReasonStorageClassInUse, true),
+		Entry("capacity warning", fusionv1alpha1.ConditionTypeCapacityWarning, metav1.ConditionTrue, ReasonCapacityWarningExceeded, true),
+		Entry("capacity within thresholds", fusionv1alpha1.ConditionTypeCapacityWarning, metav1.ConditionFalse, ReasonCapacityWithinThresholds, false),
+		Entry("creation failed", fusionv1alpha1.ConditionTypeFileSystemCreated, metav1.ConditionFalse, ReasonFileSystemCreationFailed, true),
+		Entry("creation in progress", fusionv1alpha1.ConditionTypeFileSystemCreated, metav1.ConditionFalse, ReasonFileSystemCreationInProgress, false),
+		Entry("ready", fusionv1alpha1.ConditionTypeReady, metav1.ConditionTrue, ReasonProvisioningSucceeded, false),
+	)
+})
@@ -18,12 +18,11 @@ package filesystemclaim
 
 import (
 	"context"
-	cryptorand "crypto/rand"
 	"fmt"
-	"math/big"
 	"reflect"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -34,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -46,6 +46,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/events"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/metrics"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
@@ -73,6 +75,16 @@ const (
 	ReasonStorageClassCreationSucceeded  = "StorageClassCreationSucceeded"
 	ReasonStorageClassCreationInProgress = "StorageClassCreationInProgress"
 
+	// Reason constants for online expansion
+	ReasonExpansionInProgress = "ExpansionInProgress"
+	ReasonExpansionSucceeded  = "ExpansionSucceeded"
+	ReasonExpansionFailed     = "ExpansionFailed"
+
+	// Reason constants for capacity warnings
+	ReasonCapacityWithinThresholds = "CapacityWithinThresholds"
+	ReasonCapacityWarningExceeded  = "WarningThresholdExceeded"
+	ReasonCapacityCriticalExceeded = "CriticalThresholdExceeded"
+
 	// Reason constants for Device validation
 	ReasonDeviceValidationFailed    = "DeviceValidationFailed"
 	ReasonDeviceValidationSucceeded = "DeviceValidationSucceeded"
@@ -86,6 +98,11 @@ const (
 	ReasonFilesystemDeleted   = "FilesystemDeleted"
 	ReasonLocalDiskDeleted    = "LocalDiskDeleted"
 
+	// ReasonStorageClassNotRecreated is reported when a StorageClass the operator did not create differs in
+	// fields that can only change by recreating it
+	ReasonStorageClassNotRecreated = "StorageClassNotRecreated"
+	storageClassEventTopic         = "StorageClass"
+
 	// Reason constants for overall provisioning status
 	ReasonProvisioningFailed     = "ProvisioningFailed"
 	ReasonProvisioningSucceeded  = "ProvisioningSucceeded"
@@ -120,6 +137,7 @@ const (
 	FileSystemClaimOwnedByNameLabel      = "fusion.storage.openshift.io/owned-by-fsc-name"
 	FileSystemClaimOwnedByNamespaceLabel = "fusion.storage.openshift.io/owned-by-fsc-namespace"
 	StorageClassDefaultAnnotation        = "storageclass.kubevirt.io/is-default-virt-class"
+	LocalDiskDeviceAnnotation            = "fusion.storage.openshift.io/device"
 	FileSystemDeletionLabel              = "scale.spectrum.ibm.com/allowDelete"
 )
 
@@ -129,6 +147,11 @@ type FileSystemClaimReconciler struct {
 	Scheme *runtime.Scheme
 	// Configurable requeue delays for testing and optimization
 	RequeueDelay time.Duration
+	// Recorder emits the events listed by `oc describe fsc`
+	Recorder record.EventRecorder
+
+	eventsOnce sync.Once
+	events     *events.Reporter
 }
 
 // +kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=filesystemclaims,verbs=get;list;watch;create;update;patch;delete
@@ -137,8 +160,11 @@ type FileSystemClaimReconciler struct {
 // +kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=localvolumediscoveryresults,verbs=get;list;watch
 // +kubebuilder:rbac:groups=scale.spectrum.ibm.com,resources=localdisks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=scale.spectrum.ibm.com,resources=filesystems,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=scale.spectrum.ibm.com,resources=diskjobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=scale.spectrum.ibm.com,resources=restripefsjobs,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=persistentvolumes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 func (r *FileSystemClaimReconciler) Reconcile(
 	ctx context.Context,
@@ -152,6 +178,7 @@ func (r *FileSystemClaimReconciler) Reconcile(
 	if err := r.Get(ctx, req.NamespacedName, fsc); errors.IsNotFound(err) {
 		// This is normal - object might have been deleted or not yet in cache
 		logger.V(1).Info("FileSystemClaim not found, likely deleted or cache lag", "name", req.Name)
+		metrics.DeleteFileSystemClaim(req.Namespace, req.Name)
 		return ctrl.Result{}, nil
 	} else if err != nil {
 		logger.Error(err, "Failed to get FileSystemClaim", "name", req.Name)
@@ -159,6 +186,14 @@ func (r *FileSystemClaimReconciler) Reconcile(
 	}
 
 	logger.Info("Reconciling FileSystemClaim", "name", fsc.Name, "namespace", fsc.Namespace)
+	recordFSCMetrics(fsc)
+
+	// Rollback hands the resources back and deletes the claim without running the finalizer
+	if rolledBack, err := r.handleRollback(ctx, fsc); err != nil {
+		return ctrl.Result{}, err
+	} else if rolledBack {
+		return ctrl.Result{}, nil
+	}
 
 	// Finalizers first
 	if changed, err := r.handleFinalizers(ctx, fsc); err != nil {
@@ -178,6 +213,16 @@ func (r *FileSystemClaimReconciler) Reconcile(
 		return ctrl.Result{RequeueAfter: r.RequeueDelay}, nil
 	}
 
+	// Adopt the existing Filesystem named by the adopt-filesystem annotation before anything is created
+	if requeueAfter, changed, err := r.ensureAdoption(ctx, fsc); err != nil {
+		return ctrl.Result{}, err
+	} else if requeueAfter > 0 {
+		// The resources cannot be adopted yet, validate them again later
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	} else if changed {
+		return ctrl.Result{RequeueAfter: r.RequeueDelay}, nil
+	}
+
 	// 1) Ensure LocalDisks exist (create/update if needed)
 	if changed, err := r.ensureLocalDisks(ctx, fsc); err != nil {
 		return ctrl.Result{}, err
@@ -208,20 +253,34 @@ func (r *FileSystemClaimReconciler) Reconcile(
 		return ctrl.Result{RequeueAfter: r.RequeueDelay}, nil
 	}
 
-	// 5) Ensure StorageClass (only after Filesystem ready)
+	// 5) Drain and remove devices listed in spec.removeDevices (only after Filesystem ready)
+	if changed, err := r.ensureDeviceRemovals(ctx, fsc); err != nil {
+		return ctrl.Result{}, err
+	} else if changed {
+		return ctrl.Result{RequeueAfter: r.RequeueDelay}, nil
+	}
+
+	// 6) Ensure StorageClass (only after Filesystem ready)
 	if changed, err := r.ensureStorageClass(ctx, fsc); err != nil {
 		return ctrl.Result{}, err
 	} else if changed {
 		return ctrl.Result{RequeueAfter: r.RequeueDelay}, nil
 	}
 
-	// 6) Aggregate/Ready
+	// 7) Aggregate/Ready
 	if changed, err := r.syncFSCReady(ctx, fsc); err != nil {
 		return ctrl.Result{}, err
 	} else if changed {
 		return ctrl.Result{RequeueAfter: r.RequeueDelay}, nil
 	}
 
+	// 8) Record the owned resources, capacity and observed generation in status
+	if changed, err := r.syncStatusInventory(ctx, fsc); err != nil {
+		return ctrl.Result{}, err
+	} else if changed {
+		return ctrl.Result{RequeueAfter: r.RequeueDelay}, nil
+	}
+
 	logger.Info("FileSystemClaim reconciliation completed successfully")
 	return ctrl.Result{}, nil
 }
@@ -303,7 +362,7 @@ func (r *FileSystemClaimReconciler) handleDeletion(ctx context.Context, fsc *fus
 func (r *FileSystemClaimReconciler) ensureLocalDisks(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim) (bool, error) {
 	logger := log.FromContext(ctx)
 
-	// If LocalDisks are already created, verify spec.devices hasn't changed
+	// If LocalDisks are already created, verify spec.devices was only appended to
 	// This is a safety check in case the webhook is disabled or bypassed
 	if r.isConditionTrue(fsc, fusionv1alpha1.ConditionTypeLocalDiskCreated) {
 		// Get owned LocalDisks and verify they match current spec.devices
@@ -318,26 +377,43 @@ func (r *FileSystemClaimReconciler) ensureLocalDisks(ctx context.Context, fsc *f
 
 		// Extract device paths from owned LocalDisks
 		ownedDevices := make(map[string]struct{})
-		for _, ld := range owned {
-			devicePath, _, _ := unstructured.NestedString(ld.Object, "spec", "device")
-			if devicePath != "" {
+		for i := range owned {
+			if devicePath := localDiskDevice(&owned[i]); devicePath != "" {
 				ownedDevices[devicePath] = struct{}{}
 			}
 		}
 
-		// Check if spec.devices matches owned LocalDisks
+		// Check if spec.devices still contains every owned LocalDisk
 		specDevices := make(map[string]struct{})
-		for _, device := range fsc.Spec.Devices {
+		var addedDevices []string
+		removing := make(map[string]struct{}, len(fsc.Spec.RemoveDevices))
+		for _, device := range fsc.Spec.RemoveDevices {
+			removing[device] = struct{}{}
+		}
+		for _, device := range claimDevices(fsc) {
 			specDevices[device] = struct{}{}
+			_, isOwned := ownedDevices[device]
+			_, isRemoving := removing[device]
+			if !isOwned && !isRemoving {
+				addedDevices = append(addedDevices, device)
+			}
 		}
 
-		// Compare the two sets
-		if !reflect.DeepEqual(ownedDevices, specDevices) {
-			errMsg := fmt.Sprintf("spec.devices was modified after LocalDisks were created. "+
+		var removedDevices []string
+		for device := range ownedDevices {
+			if _, ok := specDevices[device]; !ok {
+				removedDevices = append(removedDevices, device)
+			}
+		}
+
+		// Removing devices is not supported, only appending
+		if len(removedDevices) > 0 {
+			errMsg := fmt.Sprintf("spec.devices was modified after LocalDisks were created, "+
+				"devices can only be appended. "+
 				"Original: %v, Current: %v. "+
 				"Either delete this FileSystemClaim (%s) and create new with desired devices, "+
 				"or create a new FileSystemClaim with UNUSED and AVAILABLE shared devices.",
-				mapKeysToSlice(ownedDevices), fsc.Spec.Devices, fsc.Name)
+				mapKeysToSlice(ownedDevices), claimDevices(fsc), fsc.Name)
 			logger.Info(errMsg)
 
 			// Set error condition
@@ -353,9 +429,14 @@ func (r *FileSystemClaimReconciler) ensureLocalDisks(ctx context.Context, fsc *f
 			}); e != nil {
 				return false, e
 			}
+			r.reportConditionEvent(fsc, fusionv1alpha1.ConditionTypeReady, metav1.ConditionFalse, ReasonImmutableFieldModified, errMsg)
 			return true, nil
 		}
 
+		if len(addedDevices) > 0 {
+			return r.expandLocalDisks(ctx, fsc, addedDevices)
+		}
+
 		return false, nil
 	}
 
@@ -364,9 +445,30 @@ func (r *FileSystemClaimReconciler) ensureLocalDisks(ctx context.Context, fsc *f
 		return false, nil
 	}
 
+	// Resolve spec.deviceSelector once, the resolved devices are then validated like spec.devices
+	if fsc.Spec.DeviceSelector != nil && len(fsc.Status.ResolvedDevices) == 0 {
+		resolved, err := r.resolveDeviceSelector(ctx, fsc.Spec.DeviceSelector)
+		if err != nil {
+			logger.Error(err, "Device selector resolution failed")
+			if e := r.handleValidationError(ctx, fsc, err); e != nil {
+				logger.Error(e, "Failed to update status after device selector resolution failure")
+				return false, e
+			}
+			return true, nil
+		}
+
+		if e := r.patchFSCStatus(ctx, fsc, func(cur *fusionv1alpha1.FileSystemClaim) {
+			cur.Status.ResolvedDevices = resolved
+		}); e != nil {
+			logger.Error(e, "Failed to record resolved devices")
+			return false, e
+		}
+		return true, nil
+	}
+
 	// Phase 1: validate once
 	if !r.isConditionTrue(fsc, fusionv1alpha1.ConditionTypeDeviceValidated) {
-		if err := r.validateDevices(ctx, fsc); err != nil {
+		if err := r.validateDevices(ctx, claimDevices(fsc)); err != nil {
 			logger.Error(err, "Device validation failed")
 			if e := r.handleValidationError(ctx, fsc, err); e != nil {
 				logger.Error(e, "Failed to update status after disk validation failure")
@@ -382,11 +484,11 @@ func (r *FileSystemClaimReconciler) ensureLocalDisks(ctx context.Context, fsc *f
 		return true, nil
 	}
 
-	// Get node name first - the same node will be used for all LocalDisks
-	nodeName, selErr := r.getRandomStorageNode(ctx)
-	if selErr != nil {
-		logger.Error(selErr, "failed to pick a storage node")
-		if e := r.handleResourceCreationError(ctx, fsc, "LocalDisk", selErr); e != nil {
+	// Place every device on a storage node first, the placements are recorded in status
+	placements, placeErr := r.ensurePlacements(ctx, fsc)
+	if placeErr != nil {
+		logger.Error(placeErr, "failed to place devices on storage nodes")
+		if e := r.handleResourceCreationError(ctx, fsc, "LocalDisk", placeErr); e != nil {
 			return false, e
 		}
 		return true, nil
@@ -396,10 +498,12 @@ func (r *FileSystemClaimReconciler) ensureLocalDisks(ctx context.Context, fsc *f
 	var requeue bool
 
 	// Phase 2: ensure LocalDisks
-	for _, devicePath := range fsc.Spec.Devices {
-		// Get WWN for the device
+	for _, devicePath := range claimDevices(fsc) {
+		nodeName := placements[devicePath]
+
+		// Resolve the device on the selected node to get its WWN and kernel path
 		// this will fail if the device is not found in any of the LocalVolumeDiscoveryResult
-		wwn, err := r.getDeviceWWN(ctx, devicePath, nodeName)
+		discovered, err := r.getDiscoveredDevice(ctx, devicePath, nodeName)
 		if err != nil {
 			logger.Error(err, "failed to get WWN for device", "device", devicePath, "node", nodeName)
 			if e := r.handleResourceCreationError(ctx, fsc, "LocalDisk", err); e != nil {
@@ -407,6 +511,7 @@ func (r *FileSystemClaimReconciler) ensureLocalDisks(ctx context.Context, fsc *f
 			}
 			return true, nil
 		}
+		wwn := discovered.WWN
 
 		// Generate LocalDisk name from WWN
 		localDiskName, err := generateLocalDiskName(wwn)
@@ -434,7 +539,8 @@ func (r *FileSystemClaimReconciler) ensureLocalDisks(ctx context.Context, fsc *f
 		switch {
 		case errors.IsNotFound(err):
 			// Create LocalDisk with new naming
-			spec := map[string]any{"device": devicePath, "node": nodeName}
+			spec := map[string]any{"device": discovered.Path, "node": nodeName}
+			ld.SetAnnotations(map[string]string{LocalDiskDeviceAnnotation: devicePath})
 			if err := r.createResourceWithOwnership(ctx, fsc, ld, spec); err != nil {
 				logger.Error(err, "failed to create LocalDisk", "name", localDiskName)
 				if e := r.handleResourceCreationError(ctx, fsc, "LocalDisk", err); e != nil {
@@ -478,6 +584,68 @@ func (r *FileSystemClaimReconciler) ensureLocalDisks(ctx context.Context, fsc *f
 	return false, nil
 }
 
+// expandLocalDisks validates the devices appended to spec.devices and creates their LocalDisks on the
+// nodes chosen by spec.nodePlacement. Progress is reported through the Expanding condition; the
+// Filesystem picks up the new LocalDisks in ensureFileSystem once they are Ready.
+func (r *FileSystemClaimReconciler) expandLocalDisks(
+	ctx context.Context,
+	fsc *fusionv1alpha1.FileSystemClaim,
+	addedDevices []string,
+) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	if err := r.validateDevices(ctx, addedDevices); err != nil {
+		logger.Error(err, "Validation of appended devices failed", "devices", addedDevices)
+		return r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeExpanding, metav1.ConditionFalse, ReasonExpansionFailed, err.Error())
+	}
+
+	placements, err := r.ensurePlacements(ctx, fsc)
+	if err != nil {
+		logger.Error(err, "failed to place appended devices on storage nodes")
+		return r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeExpanding, metav1.ConditionFalse, ReasonExpansionFailed, err.Error())
+	}
+
+	for _, devicePath := range addedDevices {
+		nodeName := placements[devicePath]
+		discovered, err := r.getDiscoveredDevice(ctx, devicePath, nodeName)
+		if err != nil {
+			logger.Error(err, "failed to get WWN for device", "device", devicePath, "node", nodeName)
+			return r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeExpanding, metav1.ConditionFalse, ReasonExpansionFailed, err.Error())
+		}
+
+		localDiskName, err := generateLocalDiskName(discovered.WWN)
+		if err != nil {
+			logger.Error(err, "failed to generate LocalDisk name", "wwn", discovered.WWN)
+			return r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeExpanding, metav1.ConditionFalse, ReasonExpansionFailed, err.Error())
+		}
+
+		ld := &unstructured.Unstructured{}
+		ld.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   LocalDiskGroup,
+			Version: LocalDiskVersion,
+			Kind:    LocalDiskKind,
+		})
+		ld.SetName(localDiskName)
+		ld.SetAnnotations(map[string]string{LocalDiskDeviceAnnotation: devicePath})
+
+		spec := map[string]any{"device": discovered.Path, "node": nodeName}
+		if err := r.createResourceWithOwnership(ctx, fsc, ld, spec); err != nil {
+			if errors.IsAlreadyExists(err) {
+				err = fmt.Errorf("LocalDisk %s for device %s already exists and is not owned by this FileSystemClaim", localDiskName, devicePath)
+			}
+			logger.Error(err, "failed to create LocalDisk", "name", localDiskName)
+			return r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeExpanding, metav1.ConditionFalse, ReasonExpansionFailed, err.Error())
+		}
+		logger.Info("Creating LocalDisk for expansion", "name", localDiskName, "device", devicePath, "node", nodeName)
+	}
+
+	if _, err := r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeExpanding, metav1.ConditionTrue, ReasonExpansionInProgress,
+		fmt.Sprintf("Adding devices %v: LocalDisks created, waiting for them to become ready", addedDevices)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // syncLocalDiskConditions inspects all LocalDisks owned by this FSC and updates
 // fusionv1alpha1.ConditionTypeLocalDiskCreated with a precise reason/message. Returns changed=true if we wrote status.
 func (r *FileSystemClaimReconciler) syncLocalDiskConditions(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim) (bool, error) {
@@ -510,7 +678,7 @@ func (r *FileSystemClaimReconciler) syncLocalDiskConditions(ctx context.Context,
 
 	// 3) Collect names of Filesystems owned by this FSC (used to validate Used=True cases)
 	ownedFS := map[string]struct{}{}
-	ownedFS[fsc.Name] = struct{}{} // include the deterministic name even if informer lagged
+	ownedFS[fileSystemName(fsc)] = struct{}{} // include the deterministic name even if informer lagged
 
 	// Also check for any existing filesystems owned by this FSC
 	ownedFilesystems, err := r.listOwnedResources(ctx, fsc, schema.GroupVersionKind{
@@ -527,6 +695,17 @@ func (r *FileSystemClaimReconciler) syncLocalDiskConditions(ctx context.Context,
 	// 4) Check health of all LocalDisks
 	allGood, failingName, failingMsg, hardFailure := r.checkAllResourcesHealthy(owned, []string{"Ready", "Used"}, ownedFS)
 
+	// While expanding, LocalDisks that are not Ready yet are the new ones; report them through the
+	// Expanding condition and keep LocalDiskCreated as-is since the Filesystem remains usable
+	if r.isConditionTrue(fsc, fusionv1alpha1.ConditionTypeExpanding) && !allGood {
+		if hardFailure {
+			return r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeExpanding, metav1.ConditionFalse, ReasonExpansionFailed,
+				fmt.Sprintf("LocalDisk %s: %s", failingName, failingMsg))
+		}
+		return r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeExpanding, metav1.ConditionTrue, ReasonExpansionInProgress,
+			fmt.Sprintf("Waiting for LocalDisk %s: %s", failingName, failingMsg))
+	}
+
 	// 5) Desired FSC condition
 	var desiredStatus metav1.ConditionStatus
 	var desiredReason, desiredMsg string
@@ -588,7 +767,23 @@ func (r *FileSystemClaimReconciler) ensureFileSystem(ctx context.Context, fsc *f
 		return false, nil
 	}
 
-	desiredSpec := buildFilesystemSpec(ldNames)
+	// Only add the LocalDisks of an expansion to the Filesystem once all of them are Ready
+	if r.isConditionTrue(fsc, fusionv1alpha1.ConditionTypeExpanding) {
+		if ready, _, _, _ := r.checkAllResourcesHealthy(ownedLDs, []string{"Ready"}, nil); !ready {
+			logger.Info("ensureFileSystem: waiting for expansion LocalDisks to become Ready")
+			return false, nil
+		}
+	}
+
+	pools, err := buildFilesystemPools(fsc.Spec.Filesystem, ownedLDs, detachedDevices(fsc))
+	if err != nil {
+		if e := r.handleResourceCreationError(ctx, fsc, "Filesystem", err); e != nil {
+			return false, e
+		}
+		return true, nil
+	}
+
+	desiredSpec := buildFilesystemSpec(fsc.Spec.Filesystem, pools)
 
 	// List existing owned Filesystems
 	owned, err := r.listOwnedResources(ctx, fsc, schema.GroupVersionKind{
@@ -603,7 +798,7 @@ func (r *FileSystemClaimReconciler) ensureFileSystem(ctx context.Context, fsc *f
 	switch len(owned) {
 	case 0:
 		// No existing Filesystems found, create a new one
-		fsName := fsc.Name
+		fsName := fileSystemName(fsc)
 
 		fs := &unstructured.Unstructured{}
 		fs.SetGroupVersionKind(schema.GroupVersionKind{
@@ -629,6 +824,12 @@ func (r *FileSystemClaimReconciler) ensureFileSystem(ctx context.Context, fsc *f
 	case 1:
 		// One existing Filesystem found, check for drift and patch if needed
 		fs := &owned[0]
+
+		// An adopted Filesystem keeps the layout and disks it was adopted with
+		if adoptedFilesystemName(fsc) != "" {
+			return false, nil
+		}
+
 		changed, err := r.detectAndPatchDrift(ctx, fs, func(obj client.Object) bool {
 			u := obj.(*unstructured.Unstructured)
 			currentSpec, _, _ := unstructured.NestedMap(u.Object, "spec")
@@ -641,6 +842,13 @@ func (r *FileSystemClaimReconciler) ensureFileSystem(ctx context.Context, fsc *f
 		if err != nil {
 			return false, fmt.Errorf("patch Filesystem: %w", err)
 		}
+		if changed && r.isConditionTrue(fsc, fusionv1alpha1.ConditionTypeExpanding) {
+			logger.Info("Added expansion LocalDisks to Filesystem", "name", fs.GetName(), "disks", ldNames)
+			if _, e := r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeExpanding, metav1.ConditionTrue, ReasonExpansionInProgress,
+				fmt.Sprintf("Filesystem %s updated with %d disks, waiting for the new disks to be used", fs.GetName(), len(ldNames))); e != nil {
+				return false, e
+			}
+		}
 		return changed, nil
 
 	default:
@@ -700,6 +908,10 @@ func (r *FileSystemClaimReconciler) syncFilesystemConditions(ctx context.Context
 		return false, err
 	}
 
+	if !changed && desiredStatus == metav1.ConditionTrue && r.isConditionTrue(fsc, fusionv1alpha1.ConditionTypeExpanding) {
+		return r.syncExpansionCompletion(ctx, fsc, owned[0].GetName())
+	}
+
 	if !changed {
 		logger.Info("FilesystemCreated condition unchanged; skipping patch")
 		// there was no change, so we don't need to requeue
@@ -710,6 +922,38 @@ func (r *FileSystemClaimReconciler) syncFilesystemConditions(ctx context.Context
 	return changed, nil
 }
 
+// syncExpansionCompletion marks the expansion as succeeded once the Filesystem uses every owned LocalDisk.
+func (r *FileSystemClaimReconciler) syncExpansionCompletion(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim, fsName string) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	ownedLDs, err := r.listOwnedResources(ctx, fsc, schema.GroupVersionKind{
+		Group:   LocalDiskGroup,
+		Version: LocalDiskVersion,
+		Kind:    LocalDiskKind,
+	}, LocalDiskList)
+	if err != nil {
+		return false, fmt.Errorf("list LocalDisks: %w", err)
+	}
+
+	for i := range ownedLDs {
+		ld := &ownedLDs[i]
+		conds, err := extractResourceConditions(ld)
+		if err != nil {
+			return false, nil
+		}
+		used, _ := checkResourceCondition(conds, "Used", metav1.ConditionTrue)
+		usedBy, _, _ := unstructured.NestedString(ld.Object, "status", "filesystem")
+		if !used || usedBy != fsName {
+			logger.Info("Expansion in progress, LocalDisk not yet used by Filesystem", "localDisk", ld.GetName(), "filesystem", fsName)
+			return false, nil
+		}
+	}
+
+	logger.Info("Expansion completed", "filesystem", fsName, "disks", len(ownedLDs))
+	return r.updateConditionIfChanged(ctx, fsc, fusionv1alpha1.ConditionTypeExpanding, metav1.ConditionFalse, ReasonExpansionSucceeded,
+		fmt.Sprintf("Filesystem %s is using all %d LocalDisks", fsName, len(ownedLDs)))
+}
+
 // ensureStorageClass creates StorageClass if it doesn't exist and returns its ready status
 func (r *FileSystemClaimReconciler) ensureStorageClass(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim) (bool, error) {
 	logger := log.FromContext(ctx)
@@ -719,8 +963,8 @@ func (r *FileSystemClaimReconciler) ensureStorageClass(ctx context.Context, fsc
 		return false, nil
 	}
 
-	scName := fsc.Name // Use FSC name directly
-	fsName := fsc.Name // the Filesystem name we created
+	scName := fusionv1alpha1.StorageClassNameOf(fsc)
+	fsName := fileSystemName(fsc) // the Filesystem name we created or adopted
 
 	desired := buildStorageClass(fsc, scName, fsName)
 
@@ -747,8 +991,17 @@ func (r *FileSystemClaimReconciler) ensureStorageClass(ctx context.Context, fsc
 		return false, fmt.Errorf("get StorageClass %q: %w", scName, err)
 
 	default:
+		// Never take over a StorageClass that was created for something else
+		if !isStorageClassOwnedByFSC(current, fsc) {
+			err := fmt.Errorf("StorageClass %q already exists and is not owned by FileSystemClaim %s/%s", scName, fsc.Namespace, fsc.Name)
+			if e := r.handleResourceCreationError(ctx, fsc, "StorageClass", err); e != nil {
+				return false, e
+			}
+			return true, nil
+		}
+
 		// StorageClass exists - check for drift and patch if needed
-		changed, err := r.reconcileExistingStorageClass(ctx, current, desired)
+		changed, err := r.reconcileExistingStorageClass(ctx, fsc, current, desired)
 		if err != nil {
 			return false, fmt.Errorf("patch StorageClass %q: %w", scName, err)
 		}
@@ -925,22 +1178,33 @@ func (r *FileSystemClaimReconciler) isConditionTrue(fsc *fusionv1alpha1.FileSyst
 	return false
 }
 
-// getRandomStorageNode returns a random node name that has both
-// WorkerNodeRoleLabel and ScaleStorageRoleLabel=ScaleStorageRoleValue labels
-func (r *FileSystemClaimReconciler) getRandomStorageNode(ctx context.Context) (string, error) {
-	logger := log.FromContext(ctx)
+// getStorageNodes returns the nodes that have both worker and storage labels, sorted by name
+func (r *FileSystemClaimReconciler) getStorageNodes(ctx context.Context) ([]metav1.PartialObjectMetadata, error) {
+	storageNodes, err := listStorageNodes(ctx, r.Client)
+	if err != nil {
+		return nil, err
+	}
 
+	if len(storageNodes) == 0 {
+		return nil, fmt.Errorf("no nodes found with both %s and %s=%s labels",
+			WorkerNodeRoleLabel, ScaleStorageRoleLabel, ScaleStorageRoleValue)
+	}
+	return storageNodes, nil
+}
+
+// listStorageNodes lists the nodes that have both worker and storage labels, sorted by name
+func listStorageNodes(ctx context.Context, c client.Reader) ([]metav1.PartialObjectMetadata, error) {
 	allNodes := &metav1.PartialObjectMetadataList{}
 	allNodes.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NodeList"))
 
 	// List all nodes
-	err := r.List(ctx, allNodes)
+	err := c.List(ctx, allNodes)
 	if err != nil {
-		return "", fmt.Errorf("failed to list nodes: %w", err)
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
 	}
 
 	// Filter nodes with both worker and storage labels
-	var storageNodes []string
+	var storageNodes []metav1.PartialObjectMetadata
 	for i := range allNodes.Items {
 		node := &allNodes.Items[i]
 		labels := node.GetLabels()
@@ -948,121 +1212,143 @@ func (r *FileSystemClaimReconciler) getRandomStorageNode(ctx context.Context) (s
 		hasStorageLabel := labels[ScaleStorageRoleLabel] == ScaleStorageRoleValue
 
 		if hasWorkerLabel && hasStorageLabel {
-			storageNodes = append(storageNodes, node.Name)
+			storageNodes = append(storageNodes, *node)
 		}
 	}
 
-	if len(storageNodes) == 0 {
-		return "", fmt.Errorf("no nodes found with both %s and %s=%s labels",
-			WorkerNodeRoleLabel, ScaleStorageRoleLabel, ScaleStorageRoleValue)
-	}
+	sort.Slice(storageNodes, func(a, b int) bool { return storageNodes[a].Name < storageNodes[b].Name })
+	return storageNodes, nil
+}
 
-	// Return random node
-	idx, err := cryptorand.Int(cryptorand.Reader, big.NewInt(int64(len(storageNodes))))
-	if err != nil {
-		return "", fmt.Errorf("failed to select random storage node: %w", err)
+// validateDevices checks that the specified devices are seen by every storage node, first in the
+// SharedDeviceInventory and then, when the inventory does not confirm them, in the LocalVolumeDiscoveryResults.
+// The inventory may lag behind discovery, the discovery results have the final word.
+// return a human readable error message.
+func (r *FileSystemClaimReconciler) validateDevices(ctx context.Context, devices []string) error {
+	logger := log.FromContext(ctx)
+
+	inventory := &fusionv1alpha1.SharedDeviceInventory{}
+	err := r.Get(ctx, types.NamespacedName{Name: fusionv1alpha1.SharedDeviceInventoryName}, inventory)
+	switch {
+	case err == nil && inventory.Status.LastUpdateTime != nil:
+		// Devices already used by Storage Scale are rejected right away
+		if err := checkDevicesNotInUse(&inventory.Status, devices); err != nil {
+			return err
+		}
+		inventoryErr := validateDevicesInInventory(&inventory.Status, devices)
+		if inventoryErr == nil {
+			logger.Info("Device validation successful", "devices", devices, "source", "SharedDeviceInventory")
+			return nil
+		}
+		logger.Info("SharedDeviceInventory does not confirm the devices, checking the discovery results", "reason", inventoryErr.Error())
+	case err != nil && !errors.IsNotFound(err):
+		return fmt.Errorf("failed to get SharedDeviceInventory: %w", err)
 	}
-	selectedNode := storageNodes[idx.Int64()]
 
-	logger.Info("Selected random storage node", "node", selectedNode, "totalStorageNodes", len(storageNodes))
-	return selectedNode, nil
+	return r.validateDevicesInLVDRs(ctx, devices)
 }
 
-// validateDevices checks if the specified devices are present in ALL LocalVolumeDiscoveryResult
+// validateDevicesInLVDRs checks if the specified devices are present in ALL LocalVolumeDiscoveryResult
 // which ensures both the device is valid and shared across all nodes.
-// When this function is called.
-// return a human readable error message.
-func (r *FileSystemClaimReconciler) validateDevices(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim) error {
+func (r *FileSystemClaimReconciler) validateDevicesInLVDRs(ctx context.Context, devices []string) error {
 	logger := log.FromContext(ctx)
 
-	allNodes := &metav1.PartialObjectMetadataList{}
-	allNodes.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("NodeList"))
-
-	// List all nodes
-	err := r.List(ctx, allNodes)
+	lvdrs, err := r.getStorageNodeLVDRs(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to list nodes: %w", err)
+		return err
 	}
 
-	lvdrs := make(map[string]*fusionv1alpha1.LocalVolumeDiscoveryResult)
-	for i := range allNodes.Items {
-		node := &allNodes.Items[i]
-		labels := node.GetLabels()
-		_, hasWorkerLabel := labels[WorkerNodeRoleLabel]
-		hasStorageLabel := labels[ScaleStorageRoleLabel] == ScaleStorageRoleValue
-
-		// Filter nodes with both worker and storage labels
-		if hasWorkerLabel && hasStorageLabel {
-			lvdrName := fmt.Sprintf("discovery-result-%s", node.Name)
-			lvdr := &fusionv1alpha1.LocalVolumeDiscoveryResult{}
+	// Track which device reference resolved to each WWN per node, so that a disk is not listed twice
+	// e.g. once by kernel path and once by WWN
+	refByWWN := make(map[string]map[string]string, len(lvdrs))
+	for nodeName := range lvdrs {
+		refByWWN[nodeName] = make(map[string]string)
+	}
 
-			// Get the LVDR for the node (LVDRs are in the operator's namespace)
-			operatorNamespace, err := utils.GetDeploymentNamespace()
-			if err != nil {
-				return fmt.Errorf("failed to get operator deployment namespace: %w", err)
+	// For each device, check if it exists in ALL LVDRs
+	for _, device := range devices {
+		for nodeName, lvdr := range lvdrs {
+			// The members of a multipath device share its WWN and are ignored, so the discovered devices
+			// come first and the ignored ones only explain why nothing was found
+			discovered := findDiscoveredDevice(lvdr.Status.DiscoveredDevices, device)
+			if discovered == nil {
+				if ignored := findIgnoredDevice(lvdr.Status.IgnoredDevices, device); ignored != nil {
+					return fmt.Errorf("device %s is not usable on node %s: %s (%s)", device, nodeName, ignored.Reason, ignored.Message)
+				}
+				if len(lvdr.Status.DiscoveredDevices) == 0 {
+					return fmt.Errorf("no discovered devices available for node %s. "+
+						"Device: %s is not shared across all nodes", nodeName, device)
+				}
+				return fmt.Errorf("device %s not found in LocalVolumeDiscoveryResult for node %s", device, nodeName)
+			}
+			if discovered.InUse != nil {
+				return deviceInUseError(device, nodeName, discovered.InUse)
 			}
 
-			err = r.Get(ctx, types.NamespacedName{
-				Name:      lvdrName,
-				Namespace: operatorNamespace,
-			}, lvdr)
-
-			if err != nil {
-				if errors.IsNotFound(err) {
-					return fmt.Errorf("LocalVolumeDiscoveryResult: %s not found for node: %s", lvdrName, node.Name)
+			if discovered.WWN != "" {
+				if other, dup := refByWWN[nodeName][discovered.WWN]; dup {
+					return fmt.Errorf("devices %s and %s refer to the same disk %s on node %s", other, device, discovered.WWN, nodeName)
 				}
-				return fmt.Errorf("failed to get LocalVolumeDiscoveryResult for node %s: %w", node.Name, err)
+				refByWWN[nodeName][discovered.WWN] = device
 			}
-
-			lvdrs[node.Name] = lvdr
 		}
+
+		logger.Info("Device validation successful", "device", device, "availableOnAllNodesWithWorkerAndstorageLabel", len(lvdrs))
 	}
 
-	if len(lvdrs) == 0 {
-		return fmt.Errorf("no nodes found with both %s and %s=%s labels", WorkerNodeRoleLabel, ScaleStorageRoleLabel, ScaleStorageRoleValue)
+	return nil
+}
+
+// getStorageNodeLVDRs returns the LocalVolumeDiscoveryResult of every node with both the worker and storage labels
+func (r *FileSystemClaimReconciler) getStorageNodeLVDRs(ctx context.Context) (map[string]*fusionv1alpha1.LocalVolumeDiscoveryResult, error) {
+	storageNodes, err := r.getStorageNodes(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	// For each device, check if it exists in ALL LVDRs
-	for _, device := range fsc.Spec.Devices {
-		for nodeName, lvdr := range lvdrs {
-			// Check if DiscoveredDevices exists and is not empty
-			if len(lvdr.Status.DiscoveredDevices) == 0 {
-				return fmt.Errorf("no discovered devices available for node %s. "+
-					"Device: %s may be in use in another filesystem or is not "+
-					"shared across all nodes", nodeName, device)
-			}
+	// LVDRs are in the operator's namespace
+	operatorNamespace, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator deployment namespace: %w", err)
+	}
 
-			deviceFound := false
-			for _, discoveredDevice := range lvdr.Status.DiscoveredDevices {
-				if discoveredDevice.Path == device {
-					deviceFound = true
-					break
-				}
-			}
+	lvdrs := make(map[string]*fusionv1alpha1.LocalVolumeDiscoveryResult, len(storageNodes))
+	for i := range storageNodes {
+		node := &storageNodes[i]
+		lvdrName := fmt.Sprintf("discovery-result-%s", node.Name)
+		lvdr := &fusionv1alpha1.LocalVolumeDiscoveryResult{}
 
-			if !deviceFound {
-				return fmt.Errorf("device %s not found in LocalVolumeDiscoveryResult for node %s", device, nodeName)
+		err = r.Get(ctx, types.NamespacedName{
+			Name:      lvdrName,
+			Namespace: operatorNamespace,
+		}, lvdr)
+
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, fmt.Errorf("LocalVolumeDiscoveryResult: %s not found for node: %s", lvdrName, node.Name)
 			}
+			return nil, fmt.Errorf("failed to get LocalVolumeDiscoveryResult for node %s: %w", node.Name, err)
 		}
 
-		logger.Info("Device validation successful", "device", device, "availableOnAllNodesWithWorkerAndstorageLabel", len(lvdrs))
+		lvdrs[node.Name] = lvdr
 	}
 
-	return nil
+	return lvdrs, nil
 }
 
-// getDeviceWWN looks up the WWN for a device path from LocalVolumeDiscoveryResult
-func (r *FileSystemClaimReconciler) getDeviceWWN(
+// getDiscoveredDevice resolves a device reference from spec.devices against the LocalVolumeDiscoveryResult
+// of the given node. The returned device carries the WWN and the kernel path of the disk on that node.
+func (r *FileSystemClaimReconciler) getDiscoveredDevice(
 	ctx context.Context,
 	devicePath string,
 	nodeName string,
-) (string, error) {
+) (*fusionv1alpha1.DiscoveredDevice, error) {
 	logger := log.FromContext(ctx)
 
 	// Get the operator namespace
 	operatorNamespace, err := utils.GetDeploymentNamespace()
 	if err != nil {
-		return "", fmt.Errorf("failed to get operator deployment namespace: %w", err)
+		return nil, fmt.Errorf("failed to get operator deployment namespace: %w", err)
 	}
 
 	// Construct LVDR name
@@ -1077,25 +1363,93 @@ func (r *FileSystemClaimReconciler) getDeviceWWN(
 
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return "", fmt.Errorf("LocalVolumeDiscoveryResult %s not found for node %s", lvdrName, nodeName)
+			return nil, fmt.Errorf("LocalVolumeDiscoveryResult %s not found for node %s", lvdrName, nodeName)
 		}
-		return "", fmt.Errorf("failed to get LocalVolumeDiscoveryResult for node %s: %w", nodeName, err)
+		return nil, fmt.Errorf("failed to get LocalVolumeDiscoveryResult for node %s: %w", nodeName, err)
 	}
 
 	// Search for the device in DiscoveredDevices
-	for _, device := range lvdr.Status.DiscoveredDevices {
-		if device.Path == devicePath {
-			if device.WWN == "" {
-				return "", fmt.Errorf("device %s found but WWN is empty", devicePath)
-			}
-			logger.Info("Found WWN for device", "device", devicePath, "wwn", device.WWN, "node", nodeName)
-			return device.WWN, nil
+	device := findDiscoveredDevice(lvdr.Status.DiscoveredDevices, devicePath)
+	if device == nil {
+		return nil, fmt.Errorf("device %s not found in LocalVolumeDiscoveryResult for node %s", devicePath, nodeName)
+	}
+	if device.WWN == "" {
+		return nil, fmt.Errorf("device %s found but WWN is empty", devicePath)
+	}
+	logger.Info("Found WWN for device", "device", devicePath, "wwn", device.WWN, "path", device.Path, "node", nodeName)
+	return device, nil
+}
+
+// matchesDeviceRef reports whether a device matches a device reference from spec.devices. A reference is
+// either a /dev/disk/by-id path matched against the deviceID, any other /dev path matched against the kernel
+// path, or a WWN matched case-insensitively.
+func matchesDeviceRef(path, deviceID, wwn, ref string) bool {
+	switch {
+	case strings.HasPrefix(ref, byIDPrefix):
+		return deviceID == ref
+	case strings.HasPrefix(ref, "/"):
+		return path == ref
+	default:
+		return wwn != "" && strings.EqualFold(wwn, ref)
+	}
+}
+
+// findDiscoveredDevice returns the discovered device matching a device reference from spec.devices or nil
+func findDiscoveredDevice(devices []fusionv1alpha1.DiscoveredDevice, ref string) *fusionv1alpha1.DiscoveredDevice {
+	for i := range devices {
+		device := &devices[i]
+		if matchesDeviceRef(device.Path, device.DeviceID, device.WWN, ref) {
+			return device
 		}
 	}
+	return nil
+}
 
-	return "", fmt.Errorf("device %s not found in LocalVolumeDiscoveryResult for node %s", devicePath, nodeName)
+// findIgnoredDevice finds a device reference among the devices discovery ignored, like findDiscoveredDevice
+func findIgnoredDevice(devices []fusionv1alpha1.IgnoredDevice, ref string) *fusionv1alpha1.IgnoredDevice {
+	for i := range devices {
+		device := &devices[i]
+		if matchesDeviceRef(device.Path, device.DeviceID, device.WWN, ref) {
+			return device
+		}
+	}
+	return nil
 }
 
+// deviceInUseError explains what already uses a device, nodeName being the node that reported it if any
+func deviceInUseError(ref, nodeName string, usage *fusionv1alpha1.DeviceUsage) error {
+	if usage.LocalDisk == "" {
+		where := ""
+		if nodeName != "" {
+			where = " on node " + nodeName
+		}
+		return fmt.Errorf("device %s carries a GPFS NSD signature%s: it is, or was, an NSD of a Storage Scale cluster "+
+			"and must be wiped before it can be claimed", ref, where)
+	}
+
+	msg := fmt.Sprintf("device %s is already used by LocalDisk %s", ref, usage.LocalDisk)
+	if usage.Filesystem != "" {
+		msg += " of Filesystem " + usage.Filesystem
+	}
+	if usage.FileSystemClaim != "" {
+		msg += " (FileSystemClaim " + usage.FileSystemClaim + ")"
+	}
+	return fmt.Errorf("%s", msg)
+}
+
+// localDiskDevice returns the spec.devices reference a LocalDisk was created for. LocalDisks created
+// before device references were recorded fall back to their kernel path.
+func localDiskDevice(ld *unstructured.Unstructured) string {
+	if ref := ld.GetAnnotations()[LocalDiskDeviceAnnotation]; ref != "" {
+		return ref
+	}
+	devicePath, _, _ := unstructured.NestedString(ld.Object, "spec", "device")
+	return devicePath
+}
+
+// byIDPrefix is the directory of the persistent device names reported as DiscoveredDevice.DeviceID
+const byIDPrefix = "/dev/disk/by-id/"
+
 // generateLocalDiskName generates a LocalDisk name from WWN
 // Uses the raw WWN directly to match v1.0 naming convention
 func generateLocalDiskName(wwn string) (string, error) {
@@ -1208,6 +1562,7 @@ func (r *FileSystemClaimReconciler) updateConditionIfChanged(
 	}); err != nil {
 		return false, err
 	}
+	r.reportConditionEvent(fsc, conditionType, status, reason, message)
 
 	return true, nil
 }
@@ -1257,6 +1612,7 @@ func (r *FileSystemClaimReconciler) handleResourceCreationError(
 	}); e != nil {
 		return e
 	}
+	r.reportConditionEvent(fsc, conditionType, metav1.ConditionFalse, reason, err.Error())
 
 	return nil
 }
@@ -1389,7 +1745,7 @@ func (r *FileSystemClaimReconciler) handleValidationError(
 	fsc *fusionv1alpha1.FileSystemClaim,
 	err error,
 ) error {
-	return r.patchFSCStatus(ctx, fsc, func(cur *fusionv1alpha1.FileSystemClaim) {
+	if e := r.patchFSCStatus(ctx, fsc, func(cur *fusionv1alpha1.FileSystemClaim) {
 		cur.Status.Conditions = utils.UpdateCondition(
 			cur.Status.Conditions, fusionv1alpha1.ConditionTypeReady,
 			metav1.ConditionFalse, ReasonValidationFailed, err.Error(), cur.Generation,
@@ -1398,11 +1754,84 @@ func (r *FileSystemClaimReconciler) handleValidationError(
 			cur.Status.Conditions, fusionv1alpha1.ConditionTypeDeviceValidated,
 			metav1.ConditionFalse, ReasonDeviceValidationFailed, err.Error(), cur.Generation,
 		)
-	})
+	}); e != nil {
+		return e
+	}
+	r.reportConditionEvent(fsc, fusionv1alpha1.ConditionTypeDeviceValidated, metav1.ConditionFalse, ReasonDeviceValidationFailed, err.Error())
+	return nil
+}
+
+// filesystemPool is a storage pool of the generated Filesystem with the names of its LocalDisks
+type filesystemPool struct {
+	name  string
+	disks []string
+}
+
+// buildFilesystemPools assigns the owned LocalDisks to the pools requested in spec.filesystem.
+// The system pool always comes first and receives every LocalDisk whose device is not listed in another pool.
+// Devices in detached are being removed from the Filesystem and are left out of every pool.
+func buildFilesystemPools(
+	layout *fusionv1alpha1.FileSystemLayout,
+	ownedLDs []unstructured.Unstructured,
+	detached map[string]struct{},
+) ([]filesystemPool, error) {
+	ldByDevice := make(map[string]string, len(ownedLDs))
+	var activeLDs []string
+	for i := range ownedLDs {
+		ld := &ownedLDs[i]
+		devicePath := localDiskDevice(ld)
+		if _, skip := detached[devicePath]; skip {
+			continue
+		}
+		if devicePath != "" {
+			ldByDevice[devicePath] = ld.GetName()
+		}
+		activeLDs = append(activeLDs, ld.GetName())
+	}
+
+	system := filesystemPool{name: fusionv1alpha1.SystemPoolName}
+	var others []filesystemPool
+	assigned := make(map[string]struct{})
+
+	if layout != nil {
+		for _, pool := range layout.Pools {
+			var disks []string
+			for _, device := range pool.Devices {
+				if _, skip := detached[device]; skip {
+					continue
+				}
+				ldName, ok := ldByDevice[device]
+				if !ok {
+					return nil, fmt.Errorf("no LocalDisk found for device %s in pool %q", device, pool.Name)
+				}
+				disks = append(disks, ldName)
+				assigned[ldName] = struct{}{}
+			}
+
+			if pool.Name == fusionv1alpha1.SystemPoolName {
+				system.disks = append(system.disks, disks...)
+				continue
+			}
+			others = append(others, filesystemPool{name: pool.Name, disks: disks})
+		}
+	}
+
+	for _, ldName := range activeLDs {
+		if _, ok := assigned[ldName]; !ok {
+			system.disks = append(system.disks, ldName)
+		}
+	}
+
+	if len(system.disks) == 0 {
+		return nil, fmt.Errorf("no LocalDisks left for the %q pool; at least one device must not be assigned to another pool",
+			fusionv1alpha1.SystemPoolName)
+	}
+
+	return append([]filesystemPool{system}, others...), nil
 }
 
 // buildFilesystemSpec constructs the standard Filesystem spec structure
-func buildFilesystemSpec(ldNames []string) map[string]any {
+func buildFilesystemSpec(layout *fusionv1alpha1.FileSystemLayout, pools []filesystemPool) map[string]any {
 	toIface := func(ss []string) []any {
 		out := make([]any, len(ss))
 		for i, s := range ss {
@@ -1411,16 +1840,30 @@ func buildFilesystemSpec(ldNames []string) map[string]any {
 		return out
 	}
 
+	blockSize := fusionv1alpha1.DefaultFileSystemBlockSize
+	replication := fusionv1alpha1.DefaultFileSystemReplication
+	if layout != nil {
+		if layout.BlockSize != "" {
+			blockSize = layout.BlockSize
+		}
+		if layout.Replication != "" {
+			replication = layout.Replication
+		}
+	}
+
+	poolSpecs := make([]any, 0, len(pools))
+	for _, pool := range pools {
+		poolSpecs = append(poolSpecs, map[string]any{
+			"name":  pool.name,
+			"disks": toIface(pool.disks),
+		})
+	}
+
 	return map[string]any{
 		"local": map[string]any{
-			"blockSize": "4M",
-			"pools": []any{
-				map[string]any{
-					"name":  "system",
-					"disks": toIface(ldNames),
-				},
-			},
-			"replication": "1-way",
+			"blockSize":   blockSize,
+			"pools":       poolSpecs,
+			"replication": replication,
 			"type":        "shared",
 		},
 		"seLinuxOptions": map[string]any{
@@ -1432,25 +1875,83 @@ func buildFilesystemSpec(ldNames []string) map[string]any {
 	}
 }
 
+// isStorageClassCreatedByOperator tells the StorageClasses the operator created from the ones it took over by
+// migration or adoption, which carry the metadata they had before
+func isStorageClassCreatedByOperator(sc *storagev1.StorageClass) bool {
+	if sc.GetLabels()[MigrationLabelMigrated] == MigrationLabelValueTrue {
+		return false
+	}
+	_, recorded := sc.GetAnnotations()[MigrationAnnotationPreState]
+	return !recorded
+}
+
+// isStorageClassOwnedByFSC checks the ownership labels of a StorageClass (cluster-scoped, so no ownerRef)
+func isStorageClassOwnedByFSC(sc *storagev1.StorageClass, fsc *fusionv1alpha1.FileSystemClaim) bool {
+	labels := sc.GetLabels()
+	return labels[FileSystemClaimOwnedByNameLabel] == fsc.Name && labels[FileSystemClaimOwnedByNamespaceLabel] == fsc.Namespace
+}
+
 func buildStorageClass(fsc *fusionv1alpha1.FileSystemClaim, scName, fsName string) *storagev1.StorageClass {
+	template := fsc.Spec.StorageClass
+	if template == nil {
+		template = &fusionv1alpha1.StorageClassTemplate{}
+	}
+
+	labels := map[string]string{}
+	for k, v := range template.Labels {
+		labels[k] = v
+	}
+	// Ownership labels always win over user supplied labels
+	labels[FileSystemClaimOwnedByNameLabel] = fsc.Name
+	labels[FileSystemClaimOwnedByNamespaceLabel] = fsc.Namespace
+
+	annotations := map[string]string{}
+	for k, v := range template.Annotations {
+		annotations[k] = v
+	}
+	if template.DefaultVirtClass == nil || *template.DefaultVirtClass {
+		annotations[StorageClassDefaultAnnotation] = "true"
+	} else {
+		delete(annotations, StorageClassDefaultAnnotation)
+	}
+
+	reclaimPolicy := corev1.PersistentVolumeReclaimDelete
+	if template.ReclaimPolicy != nil {
+		reclaimPolicy = *template.ReclaimPolicy
+	}
+
+	bindingMode := storagev1.VolumeBindingImmediate
+	if template.VolumeBindingMode != nil {
+		bindingMode = *template.VolumeBindingMode
+	}
+
+	parameters := map[string]string{
+		"volBackendFs": fsName,
+	}
+	if p := template.Parameters; p != nil {
+		for key, value := range map[string]string{
+			"uid":         p.UID,
+			"gid":         p.GID,
+			"permissions": p.Permissions,
+			"inodeLimit":  p.InodeLimit,
+		} {
+			if value != "" {
+				parameters[key] = value
+			}
+		}
+	}
+
 	return &storagev1.StorageClass{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: scName,
-			Annotations: map[string]string{
-				StorageClassDefaultAnnotation: "true",
-			},
-			Labels: map[string]string{
-				FileSystemClaimOwnedByNameLabel:      fsc.Name,
-				FileSystemClaimOwnedByNamespaceLabel: fsc.Namespace,
-			},
+			Name:        scName,
+			Annotations: annotations,
+			Labels:      labels,
 		},
 		Provisioner:          "spectrumscale.csi.ibm.com",
 		AllowVolumeExpansion: ptr.To(true),
-		ReclaimPolicy:        ptr.To(corev1.PersistentVolumeReclaimDelete),
-		VolumeBindingMode:    ptr.To(storagev1.VolumeBindingImmediate),
-		Parameters: map[string]string{
-			"volBackendFs": fsName,
-		},
+		ReclaimPolicy:        ptr.To(reclaimPolicy),
+		VolumeBindingMode:    ptr.To(bindingMode),
+		Parameters:           parameters,
 	}
 }
 
@@ -1468,12 +1969,41 @@ func storageClassRelevantFields(sc *storagev1.StorageClass) *storagev1.StorageCl
 	}
 }
 
+// storageClassImmutableFieldsDiffer reports whether fields the API server refuses to update have drifted
+func storageClassImmutableFieldsDiffer(current, desired *storagev1.StorageClass) bool {
+	return current.Provisioner != desired.Provisioner ||
+		!reflect.DeepEqual(current.Parameters, desired.Parameters) ||
+		!reflect.DeepEqual(current.ReclaimPolicy, desired.ReclaimPolicy) ||
+		!reflect.DeepEqual(current.VolumeBindingMode, desired.VolumeBindingMode)
+}
+
+// reconcileExistingStorageClass enforces the desired StorageClass. Labels, annotations and volume expansion are
+// patched in place; provisioner, parameters, reclaim policy and binding mode are immutable, so a StorageClass
+// created by the operator is deleted and recreated on the next reconcile. Existing PVs are not affected by the
+// recreation. A migrated or adopted StorageClass is never recreated, the difference is reported in an event.
 func (r *FileSystemClaimReconciler) reconcileExistingStorageClass(
 	ctx context.Context,
+	fsc *fusionv1alpha1.FileSystemClaim,
 	current *storagev1.StorageClass,
 	desired *storagev1.StorageClass,
 ) (bool, error) {
 	logger := log.FromContext(ctx)
+
+	if storageClassImmutableFieldsDiffer(current, desired) {
+		if !isStorageClassCreatedByOperator(current) {
+			msg := fmt.Sprintf("StorageClass %s was not created by the operator and differs from spec.storageClass "+
+				"in its provisioner, parameters, reclaim policy or binding mode, it is left as is", current.Name)
+			r.eventReporter().Report(events.NewEvent(storageClassEventTopic, ReasonStorageClassNotRecreated, msg), fsc)
+			logger.Info("Not recreating a StorageClass the operator did not create", "name", current.Name)
+			return false, nil
+		}
+		logger.Info("Detected drift in immutable StorageClass fields; recreating", "name", current.Name)
+		if err := r.Delete(ctx, current); err != nil && !errors.IsNotFound(err) {
+			return false, fmt.Errorf("delete StorageClass for recreation: %w", err)
+		}
+		return true, nil
+	}
+
 	return r.detectAndPatchDrift(ctx, current, func(obj client.Object) bool {
 		sc := obj.(*storagev1.StorageClass)
 		if reflect.DeepEqual(storageClassRelevantFields(sc), storageClassRelevantFields(desired)) {
@@ -1482,11 +2012,7 @@ func (r *FileSystemClaimReconciler) reconcileExistingStorageClass(
 
 		fields := storageClassRelevantFields(desired)
 		sc.Annotations = fields.Annotations
-		sc.Provisioner = fields.Provisioner
 		sc.AllowVolumeExpansion = fields.AllowVolumeExpansion
-		sc.ReclaimPolicy = fields.ReclaimPolicy
-		sc.VolumeBindingMode = fields.VolumeBindingMode
-		sc.Parameters = fields.Parameters
 		sc.Labels = fields.Labels
 		logger.Info("Detected StorageClass drift; patching to desired state", "name", sc.Name)
 		return true
@@ -1499,7 +2025,7 @@ func (r *FileSystemClaimReconciler) isStorageClassInUse(
 ) (inUse bool, who string, err error) {
 	logger := log.FromContext(ctx)
 
-	scName := fsc.Name
+	scName := fusionv1alpha1.StorageClassNameOf(fsc)
 
 	var pvList corev1.PersistentVolumeList
 	if err := r.List(ctx, &pvList); errors.IsNotFound(err) {
@@ -1589,6 +2115,7 @@ func (r *FileSystemClaimReconciler) checkStorageClassUsage(ctx context.Context,
 			return 0, false, e
 		}
 		requeueAfter := r.calculateDeletionBackoff(fsc, ReasonStorageClassInUse)
+		recordDeletionBlocked(fsc, ReasonStorageClassInUse, requeueAfter)
 		logger.Info("StorageClass is in use, blocking deletion", "name", fsc.Name, "who", who, "requeueAfter", requeueAfter)
 		return requeueAfter, changed, nil
 	}
@@ -1635,6 +2162,7 @@ func (r *FileSystemClaimReconciler) checkFilesystemDeletionLabel(ctx context.Con
 				return 0, false, e
 			}
 			requeueAfter := r.calculateDeletionBackoff(fsc, ReasonFileSystemLabelNotPresent)
+			recordDeletionBlocked(fsc, ReasonFileSystemLabelNotPresent, requeueAfter)
 			logger.Info("Filesystem deletion label not present, blocking deletion",
 				"filesystem", fs.GetName(), "requeueAfter", requeueAfter)
 			return requeueAfter, changed, nil
@@ -1653,7 +2181,7 @@ func (r *FileSystemClaimReconciler) deleteStorageClass(ctx context.Context, fsc
 		return false, nil // Already deleted
 	}
 
-	scName := fsc.Name
+	scName := fusionv1alpha1.StorageClassNameOf(fsc)
 	sc := &storagev1.StorageClass{}
 	if err := r.Get(ctx, types.NamespacedName{Name: scName}, sc); err == nil {
 		if err := r.Delete(ctx, sc); err != nil {
@@ -1784,6 +2312,7 @@ func (r *FileSystemClaimReconciler) removeFinalizer(ctx context.Context, fsc *fu
 		return false, err
 	}
 	logger.Info("Removed finalizer, FileSystemClaim will be deleted", "name", fsc.Name)
+	r.eventReporter().Forget(fsc)
 	return true, nil
 }
 
@@ -1843,19 +2372,70 @@ func didStorageClassChange() builder.WatchesOption {
 
 func enqueueFSCByStorageClass() handler.EventHandler {
 	return handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
-		labels := obj.GetLabels()
-		name := labels[FileSystemClaimOwnedByNameLabel]
-		namespace := labels[FileSystemClaimOwnedByNamespaceLabel]
-		if name == "" || namespace == "" {
+		return fscRequestFromLabels(obj)
+	})
+}
+
+// enqueueFSCByPersistentVolume enqueues the FileSystemClaim owning the StorageClass of a PersistentVolume
+// so that the used capacity in its status is refreshed
+func (r *FileSystemClaimReconciler) enqueueFSCByPersistentVolume() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		pv, ok := obj.(*corev1.PersistentVolume)
+		if !ok || pv.Spec.StorageClassName == "" {
 			return nil
 		}
 
-		return []reconcile.Request{{
-			NamespacedName: types.NamespacedName{
-				Namespace: namespace,
-				Name:      name,
-			},
-		}}
+		sc := &storagev1.StorageClass{}
+		if err := r.Get(ctx, types.NamespacedName{Name: pv.Spec.StorageClassName}, sc); err != nil {
+			return nil
+		}
+		return fscRequestFromLabels(sc)
+	})
+}
+
+// fscRequestFromLabels returns the request for the FileSystemClaim named in the ownership labels of obj
+func fscRequestFromLabels(obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name := labels[FileSystemClaimOwnedByNameLabel]
+	namespace := labels[FileSystemClaimOwnedByNamespaceLabel]
+	if name == "" || namespace == "" {
+		return nil
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}}
+}
+
+// didPersistentVolumeCapacityChange returns true when a PersistentVolume of a StorageClass is added,
+// removed or resized
+func didPersistentVolumeCapacityChange() builder.WatchesOption {
+	hasStorageClass := func(obj client.Object) bool {
+		pv, ok := obj.(*corev1.PersistentVolume)
+		return ok && pv.Spec.StorageClassName != ""
+	}
+	return builder.WithPredicates(predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return hasStorageClass(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPV, okOld := e.ObjectOld.(*corev1.PersistentVolume)
+			newPV, okNew := e.ObjectNew.(*corev1.PersistentVolume)
+			if !okOld || !okNew || newPV.Spec.StorageClassName == "" {
+				return false
+			}
+			return !oldPV.Spec.Capacity.Storage().Equal(*newPV.Spec.Capacity.Storage()) ||
+				(oldPV.DeletionTimestamp == nil) != (newPV.DeletionTimestamp == nil)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return hasStorageClass(e.Object)
+		},
+		GenericFunc: func(_ event.GenericEvent) bool {
+			return false
+		},
 	})
 }
 
@@ -1940,11 +2520,36 @@ func (r *FileSystemClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			enqueueFSCByOwner(),
 			didResourceStatusChange(),
 		).
+		Watches(
+			&unstructured.Unstructured{
+				Object: map[string]any{
+					"apiVersion": ScaleJobGroup + "/" + ScaleJobVersion,
+					"kind":       DiskJobKind,
+				},
+			},
+			enqueueFSCByOwner(),
+			didResourceStatusChange(),
+		).
+		Watches(
+			&unstructured.Unstructured{
+				Object: map[string]any{
+					"apiVersion": ScaleJobGroup + "/" + ScaleJobVersion,
+					"kind":       RestripeFSJobKind,
+				},
+			},
+			enqueueFSCByOwner(),
+			didResourceStatusChange(),
+		).
 		Watches(
 			&storagev1.StorageClass{},
 			enqueueFSCByStorageClass(),
 			didStorageClassChange(),
 		).
+		Watches(
+			&corev1.PersistentVolume{},
+			r.enqueueFSCByPersistentVolume(),
+			didPersistentVolumeCapacityChange(),
+		).
 		Named("filesystemclaim").
 		Complete(r)
 }
@@ -24,12 +24,15 @@ import (
 	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -183,6 +186,153 @@ var _ = Describe("FileSystemClaim Creation Flow", func() {
 		})
 	})
 
+	Describe("buildStorageClass", func() {
+		It("should apply the spec.storageClass template", func() {
+			fsc := &fusionv1alpha1.FileSystemClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-fsc",
+					Namespace: namespace,
+				},
+				Spec: fusionv1alpha1.FileSystemClaimSpec{
+					StorageClass: &fusionv1alpha1.StorageClassTemplate{
+						Name:              "team-a",
+						ReclaimPolicy:     ptr.To(corev1.PersistentVolumeReclaimRetain),
+						VolumeBindingMode: ptr.To(storagev1.VolumeBindingWaitForFirstConsumer),
+						DefaultVirtClass:  ptr.To(false),
+						Parameters: &fusionv1alpha1.StorageClassParameters{
+							UID:         "1000",
+							GID:         "1000",
+							Permissions: "770",
+							InodeLimit:  "1M",
+						},
+						Labels: map[string]string{
+							"team":                          "a",
+							FileSystemClaimOwnedByNameLabel: "someone-else",
+						},
+						Annotations: map[string]string{"description": "team a"},
+					},
+				},
+			}
+
+			Expect(fusionv1alpha1.StorageClassNameOf(fsc)).To(Equal("team-a"))
+			sc := buildStorageClass(fsc, fusionv1alpha1.StorageClassNameOf(fsc), fsc.Name)
+
+			Expect(sc.Name).To(Equal("team-a"))
+			Expect(*sc.ReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimRetain))
+			Expect(*sc.VolumeBindingMode).To(Equal(storagev1.VolumeBindingWaitForFirstConsumer))
+			Expect(sc.Annotations).NotTo(HaveKey(StorageClassDefaultAnnotation))
+			Expect(sc.Annotations).To(HaveKeyWithValue("description", "team a"))
+			Expect(sc.Labels).To(HaveKeyWithValue("team", "a"))
+			Expect(sc.Labels).To(HaveKeyWithValue(FileSystemClaimOwnedByNameLabel, fsc.Name))
+			Expect(sc.Parameters).To(Equal(map[string]string{
+				"volBackendFs": fsc.Name,
+				"uid":          "1000",
+				"gid":          "1000",
+				"permissions":  "770",
+				"inodeLimit":   "1M",
+			}))
+		})
+
+		It("should keep the previous defaults without a template", func() {
+			fsc := createTestFSC("test-fsc", namespace, nil, nil)
+
+			Expect(fusionv1alpha1.StorageClassNameOf(fsc)).To(Equal(fsc.Name))
+			sc := buildStorageClass(fsc, fusionv1alpha1.StorageClassNameOf(fsc), fsc.Name)
+
+			Expect(sc.Annotations).To(HaveKeyWithValue(StorageClassDefaultAnnotation, "true"))
+			Expect(*sc.ReclaimPolicy).To(Equal(corev1.PersistentVolumeReclaimDelete))
+			Expect(*sc.VolumeBindingMode).To(Equal(storagev1.VolumeBindingImmediate))
+			Expect(sc.Parameters).To(Equal(map[string]string{"volBackendFs": fsc.Name}))
+		})
+	})
+
+	Describe("reconcileExistingStorageClass", func() {
+		var fsc *fusionv1alpha1.FileSystemClaim
+
+		BeforeEach(func() {
+			fsc = createTestFSC("test-fsc", namespace, nil, []metav1.Condition{
+				filesystemCreatedCondition(metav1.ConditionTrue, ReasonFileSystemCreationSucceeded),
+			})
+		})
+
+		It("should patch mutable fields in place", func() {
+			current := buildStorageClass(fsc, fsc.Name, fsc.Name)
+			current.Annotations = map[string]string{}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(current).Build()
+			reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+			changed, err := reconciler.reconcileExistingStorageClass(ctx, fsc, current, buildStorageClass(fsc, fsc.Name, fsc.Name))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			updated := &storagev1.StorageClass{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: fsc.Name}, updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(StorageClassDefaultAnnotation, "true"))
+		})
+
+		It("should delete the StorageClass when immutable fields drifted", func() {
+			current := buildStorageClass(fsc, fsc.Name, fsc.Name)
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(current).Build()
+			reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+			fsc.Spec.StorageClass = &fusionv1alpha1.StorageClassTemplate{
+				ReclaimPolicy: ptr.To(corev1.PersistentVolumeReclaimRetain),
+			}
+			changed, err := reconciler.reconcileExistingStorageClass(ctx, fsc, current, buildStorageClass(fsc, fsc.Name, fsc.Name))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			err = fakeClient.Get(ctx, types.NamespacedName{Name: fsc.Name}, &storagev1.StorageClass{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should keep a migrated StorageClass whose immutable fields drifted and report it", func() {
+			current := buildStorageClass(fsc, fsc.Name, fsc.Name)
+			current.Labels[MigrationLabelMigrated] = MigrationLabelValueTrue
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(current).Build()
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme, Recorder: recorder}
+
+			fsc.Spec.StorageClass = &fusionv1alpha1.StorageClassTemplate{
+				ReclaimPolicy: ptr.To(corev1.PersistentVolumeReclaimRetain),
+			}
+			changed, err := reconciler.reconcileExistingStorageClass(ctx, fsc, current, buildStorageClass(fsc, fsc.Name, fsc.Name))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeFalse())
+
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: fsc.Name}, &storagev1.StorageClass{})).To(Succeed())
+			Expect(recorder.Events).To(Receive(ContainSubstring(ReasonStorageClassNotRecreated)))
+		})
+
+		It("should refuse to adopt a StorageClass owned by something else", func() {
+			foreign := &storagev1.StorageClass{
+				ObjectMeta:  metav1.ObjectMeta{Name: fsc.Name},
+				Provisioner: "spectrumscale.csi.ibm.com",
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(fsc, foreign).
+				WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+				Build()
+			reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+			changed, err := reconciler.ensureStorageClass(ctx, fsc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			updated := &fusionv1alpha1.FileSystemClaim{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: fsc.Name, Namespace: fsc.Namespace}, updated)).To(Succeed())
+			cond := findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeStorageClassCreated)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal(ReasonStorageClassCreationFailed))
+			Expect(cond.Message).To(ContainSubstring("not owned by"))
+		})
+	})
+
 	Describe("syncFSCReady", func() {
 		It("should set Ready=True when all components ready", func() {
 			fsc := &fusionv1alpha1.FileSystemClaim{
@@ -1194,6 +1344,7 @@ var _ = Describe("FileSystemClaim Creation Flow", func() {
 			fakeClient := fake.NewClientBuilder().
 				WithScheme(scheme).
 				WithObjects(fsc, node, lvdr, ld).
+				WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
 				Build()
 
 			reconciler := &FileSystemClaimReconciler{
@@ -1206,7 +1357,7 @@ var _ = Describe("FileSystemClaim Creation Flow", func() {
 			Expect(changed).To(BeFalse()) // No change, LD already exists
 		})
 
-		It("should handle error when getRandomStorageNode fails", func() {
+		It("should handle error when no storage node is available", func() {
 			operatorNS := "test-operator-ns"
 			GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", operatorNS)
 
@@ -1496,6 +1647,271 @@ var _ = Describe("FileSystemClaim Creation Flow", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(changed).To(BeFalse()) // LD already exists, no change
 		})
+
+		Context("with devices referenced by WWN or by-id", func() {
+			const (
+				wwn  = "0x6005076810810261f800000000000a1b"
+				byID = "/dev/disk/by-id/wwn-0x6005076810810261f800000000000a1b"
+			)
+
+			var (
+				operatorNS string
+				node1      *corev1.Node
+				lvdr1      *fusionv1alpha1.LocalVolumeDiscoveryResult
+				lvdr2      *fusionv1alpha1.LocalVolumeDiscoveryResult
+			)
+
+			BeforeEach(func() {
+				operatorNS = "test-operator-ns"
+				GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", operatorNS)
+
+				// The same LUN has a different kernel name on each node
+				node1 = createStorageNode("storage-node-1")
+				lvdr1 = createLVDR("storage-node-1", operatorNS, []fusionv1alpha1.DiscoveredDevice{
+					{Path: "/dev/sdb", DeviceID: byID, WWN: wwn},
+				})
+				lvdr2 = createLVDR("storage-node-2", operatorNS, []fusionv1alpha1.DiscoveredDevice{
+					{Path: "/dev/sdc", DeviceID: byID, WWN: wwn},
+				})
+			})
+
+			DescribeTable("should validate the device on every storage node",
+				func(ref string) {
+					fakeClient := fake.NewClientBuilder().
+						WithScheme(scheme).
+						WithObjects(node1, createStorageNode("storage-node-2"), lvdr1, lvdr2).
+						Build()
+					reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+					Expect(reconciler.validateDevices(ctx, []string{ref})).To(Succeed())
+				},
+				Entry("WWN", wwn),
+				Entry("by-id path", byID),
+			)
+
+			It("should reject two references to the same disk", func() {
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(node1, lvdr1).
+					Build()
+				reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+				err := reconciler.validateDevices(ctx, []string{"/dev/sdb", wwn})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("refer to the same disk"))
+			})
+
+			It("should explain why discovery ignored the device", func() {
+				lvdr1.Status.IgnoredDevices = []fusionv1alpha1.IgnoredDevice{{
+					Path: "/dev/sdd", WWN: "0x6005076810810261f800000000000a1c",
+					Reason: fusionv1alpha1.IgnoredHasFilesystem, Message: "xfs filesystem",
+				}}
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(node1, lvdr1).
+					Build()
+				reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+				err := reconciler.validateDevices(ctx, []string{"0x6005076810810261F800000000000A1C"})
+				Expect(err).To(MatchError("device 0x6005076810810261F800000000000A1C is not usable on node storage-node-1: " +
+					"HasFilesystem (xfs filesystem)"))
+			})
+
+			It("should accept a multipath device whose member paths were ignored", func() {
+				lvdr1.Status.DiscoveredDevices = []fusionv1alpha1.DiscoveredDevice{{
+					Path: "/dev/dm-0", DeviceID: "/dev/disk/by-id/dm-uuid-mpath-" + wwn, WWN: wwn,
+					Type: fusionv1alpha1.MultiPathType,
+				}}
+				lvdr1.Status.IgnoredDevices = []fusionv1alpha1.IgnoredDevice{
+					{Path: "/dev/sdb", DeviceID: byID, WWN: wwn, Reason: fusionv1alpha1.IgnoredUnsupportedType, Message: "multipath member"},
+					{Path: "/dev/sdc", DeviceID: byID, WWN: wwn, Reason: fusionv1alpha1.IgnoredUnsupportedType, Message: "multipath member"},
+				}
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(node1, lvdr1).
+					Build()
+				reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+				Expect(reconciler.validateDevices(ctx, []string{wwn})).To(Succeed())
+				Expect(reconciler.validateDevices(ctx, []string{"/dev/sdb"})).To(
+					MatchError(ContainSubstring("device /dev/sdb is not usable on node storage-node-1")))
+			})
+
+			It("should fail fast on a device already used by Storage Scale", func() {
+				lvdr2.Status.DiscoveredDevices[0].InUse = &fusionv1alpha1.DeviceUsage{LocalDisk: wwn, Filesystem: "fs1"}
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(node1, createStorageNode("storage-node-2"), lvdr1, lvdr2).
+					Build()
+				reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+				Expect(reconciler.validateDevices(ctx, []string{byID})).To(
+					MatchError("device " + byID + " is already used by LocalDisk " + wwn + " of Filesystem fs1"))
+
+				lvdr1.Status.DiscoveredDevices[0].InUse = &fusionv1alpha1.DeviceUsage{NSDSignature: true}
+				fakeClient = fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(node1, lvdr1).
+					Build()
+				reconciler = &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+				Expect(reconciler.validateDevices(ctx, []string{wwn})).To(
+					MatchError(ContainSubstring("device " + wwn + " carries a GPFS NSD signature on node storage-node-1")))
+			})
+
+			It("should create the LocalDisk with the kernel path of the selected node", func() {
+				fsc := createTestFSC("test-fsc", namespace, []string{byID}, []metav1.Condition{
+					deviceValidatedCondition(metav1.ConditionTrue),
+				})
+
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(fsc, node1, lvdr1).
+					WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+					Build()
+				reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+				changed, err := reconciler.ensureLocalDisks(ctx, fsc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeTrue())
+
+				ld := &unstructured.Unstructured{}
+				ld.SetGroupVersionKind(schema.GroupVersionKind{Group: LocalDiskGroup, Version: LocalDiskVersion, Kind: LocalDiskKind})
+				Expect(fakeClient.Get(ctx, types.NamespacedName{Name: wwn, Namespace: namespace}, ld)).To(Succeed())
+
+				device, _, _ := unstructured.NestedString(ld.Object, "spec", "device")
+				Expect(device).To(Equal("/dev/sdb"))
+				Expect(localDiskDevice(ld)).To(Equal(byID))
+			})
+		})
+
+		Context("with spec.deviceSelector", func() {
+			const (
+				sharedWWN = "0x6005076810810261f800000000000a1b"
+				localWWN  = "0x6005076810810261f800000000000a1c"
+				otherWWN  = "0x5000c500a1b2c3d4"
+			)
+
+			var (
+				operatorNS string
+				selector   *fusionv1alpha1.DeviceSelector
+				objects    []client.Object
+			)
+
+			BeforeEach(func() {
+				operatorNS = "test-operator-ns"
+				GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", operatorNS)
+
+				selector = &fusionv1alpha1.DeviceSelector{Vendor: "IBM", WWNPrefix: "0x6005076"}
+
+				// localWWN is only visible on the first node, otherWWN does not match the selector
+				objects = []client.Object{
+					createStorageNode("storage-node-1"),
+					createStorageNode("storage-node-2"),
+					createLVDR("storage-node-1", operatorNS, []fusionv1alpha1.DiscoveredDevice{
+						{Path: "/dev/sdb", Vendor: "IBM", WWN: sharedWWN},
+						{Path: "/dev/sdc", Vendor: "IBM", WWN: localWWN},
+						{Path: "/dev/sdd", Vendor: "SEAGATE", WWN: otherWWN},
+					}),
+					createLVDR("storage-node-2", operatorNS, []fusionv1alpha1.DiscoveredDevice{
+						{Path: "/dev/sdc", Vendor: "IBM", WWN: sharedWWN},
+						{Path: "/dev/sdd", Vendor: "SEAGATE", WWN: otherWWN},
+					}),
+				}
+			})
+
+			It("should record the devices visible on all storage nodes before creating LocalDisks", func() {
+				fsc := createTestFSC("test-fsc", namespace, nil, nil)
+				fsc.Spec.DeviceSelector = selector
+
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(append(objects, fsc)...).
+					WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+					Build()
+				reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+				changed, err := reconciler.ensureLocalDisks(ctx, fsc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeTrue())
+
+				updated := &fusionv1alpha1.FileSystemClaim{}
+				Expect(fakeClient.Get(ctx, types.NamespacedName{Name: fsc.Name, Namespace: namespace}, updated)).To(Succeed())
+				Expect(updated.Status.ResolvedDevices).To(Equal([]string{sharedWWN}))
+
+				lds := &unstructured.UnstructuredList{}
+				lds.SetGroupVersionKind(schema.GroupVersionKind{Group: LocalDiskGroup, Version: LocalDiskVersion, Kind: LocalDiskList})
+				Expect(fakeClient.List(ctx, lds)).To(Succeed())
+				Expect(lds.Items).To(BeEmpty())
+			})
+
+			It("should not select devices already used by Storage Scale", func() {
+				lvdr := objects[3].(*fusionv1alpha1.LocalVolumeDiscoveryResult)
+				lvdr.Status.DiscoveredDevices[0].InUse = &fusionv1alpha1.DeviceUsage{NSDSignature: true}
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(objects...).
+					Build()
+				reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+				_, err := reconciler.resolveDeviceSelector(ctx, selector)
+				Expect(err).To(MatchError(ContainSubstring("no discovered device matches spec.deviceSelector")))
+			})
+
+			It("should fail validation when no device matches on all storage nodes", func() {
+				fsc := createTestFSC("test-fsc", namespace, nil, nil)
+				fsc.Spec.DeviceSelector = &fusionv1alpha1.DeviceSelector{Vendor: "NETAPP"}
+
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(append(objects, fsc)...).
+					WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+					Build()
+				reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+				changed, err := reconciler.ensureLocalDisks(ctx, fsc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeTrue())
+
+				updated := &fusionv1alpha1.FileSystemClaim{}
+				Expect(fakeClient.Get(ctx, types.NamespacedName{Name: fsc.Name, Namespace: namespace}, updated)).To(Succeed())
+				Expect(updated.Status.ResolvedDevices).To(BeEmpty())
+
+				cond := findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeDeviceValidated)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+				Expect(cond.Message).To(ContainSubstring("no discovered device matches spec.deviceSelector"))
+			})
+
+			It("should create LocalDisks for the resolved devices", func() {
+				fsc := createTestFSC("test-fsc", namespace, nil, []metav1.Condition{
+					deviceValidatedCondition(metav1.ConditionTrue),
+				})
+				fsc.Spec.DeviceSelector = selector
+				fsc.Status.ResolvedDevices = []string{sharedWWN}
+
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(append(objects, fsc)...).
+					WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+					Build()
+				reconciler := &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+
+				changed, err := reconciler.ensureLocalDisks(ctx, fsc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeTrue())
+
+				ld := &unstructured.Unstructured{}
+				ld.SetGroupVersionKind(schema.GroupVersionKind{Group: LocalDiskGroup, Version: LocalDiskVersion, Kind: LocalDiskKind})
+				Expect(fakeClient.Get(ctx, types.NamespacedName{Name: sharedWWN, Namespace: namespace}, ld)).To(Succeed())
+				Expect(localDiskDevice(ld)).To(Equal(sharedWWN))
+
+				lds := &unstructured.UnstructuredList{}
+				lds.SetGroupVersionKind(schema.GroupVersionKind{Group: LocalDiskGroup, Version: LocalDiskVersion, Kind: LocalDiskList})
+				Expect(fakeClient.List(ctx, lds)).To(Succeed())
+				Expect(lds.Items).To(HaveLen(1))
+			})
+		})
 	})
 
 	Describe("ensureFileSystem", func() {
@@ -1664,7 +2080,7 @@ var _ = Describe("FileSystemClaim Creation Flow", func() {
 			})
 
 			// Set correct spec
-			desiredSpec := buildFilesystemSpec([]string{"test-ld-1"})
+			desiredSpec := buildFilesystemSpec(nil, []filesystemPool{{name: "system", disks: []string{"test-ld-1"}}})
 			fs.Object["spec"] = desiredSpec
 
 			fakeClient := fake.NewClientBuilder().
@@ -1875,5 +2291,205 @@ var _ = Describe("FileSystemClaim Creation Flow", func() {
 				Expect(changed).To(BeFalse()) // No change needed
 			})
 		})
+
+		Context("Online expansion - devices appended to spec.devices", func() {
+			var (
+				operatorNS string
+				fsc        *fusionv1alpha1.FileSystemClaim
+				ld1        *unstructured.Unstructured
+			)
+
+			BeforeEach(func() {
+				operatorNS = "test-operator-ns"
+				GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", operatorNS)
+
+				fsc = createTestFSC("test-fsc", namespace, []string{"/dev/nvme1n1", "/dev/nvme2n2"}, []metav1.Condition{
+					localDiskCreatedCondition(metav1.ConditionTrue, ReasonLocalDiskCreationSucceeded),
+				})
+				ld1 = createLocalDiskWithOwner("test-ld-1", fsc.Namespace, "/dev/nvme1n1", "storage-node-2", fsc)
+			})
+
+			It("should create LocalDisks for appended devices on the least loaded storage node", func() {
+				node1 := createStorageNode("storage-node-1")
+				node2 := createStorageNode("storage-node-2")
+				devices := []fusionv1alpha1.DiscoveredDevice{
+					{Path: "/dev/nvme2n2", WWN: "uuid.22222222-2222-2222-2222-222222222222"},
+				}
+				lvdr1 := createLVDR("storage-node-1", operatorNS, devices)
+				lvdr2 := createLVDR("storage-node-2", operatorNS, devices)
+
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(fsc, ld1, node1, node2, lvdr1, lvdr2).
+					WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+					Build()
+
+				reconciler := &FileSystemClaimReconciler{
+					Client: fakeClient,
+					Scheme: scheme,
+				}
+
+				changed, err := reconciler.ensureLocalDisks(ctx, fsc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeTrue())
+
+				ld := &unstructured.Unstructured{}
+				ld.SetGroupVersionKind(schema.GroupVersionKind{Group: LocalDiskGroup, Version: LocalDiskVersion, Kind: LocalDiskKind})
+				Expect(fakeClient.Get(ctx, types.NamespacedName{
+					Name:      "uuid.22222222-2222-2222-2222-222222222222",
+					Namespace: fsc.Namespace,
+				}, ld)).To(Succeed())
+				node, _, _ := unstructured.NestedString(ld.Object, "spec", "node")
+				Expect(node).To(Equal("storage-node-1"))
+				Expect(isOwnedByThisFSC(ld, fsc.Name)).To(BeTrue())
+
+				updated := &fusionv1alpha1.FileSystemClaim{}
+				Expect(fakeClient.Get(ctx, types.NamespacedName{Name: fsc.Name, Namespace: fsc.Namespace}, updated)).To(Succeed())
+				Expect(updated.Status.Placements).To(Equal([]fusionv1alpha1.DevicePlacement{
+					{Device: "/dev/nvme1n1", Node: "storage-node-2"},
+					{Device: "/dev/nvme2n2", Node: "storage-node-1"},
+				}))
+
+				cond := findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeExpanding)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				Expect(cond.Reason).To(Equal(ReasonExpansionInProgress))
+				Expect(cond.Message).To(ContainSubstring("/dev/nvme2n2"))
+
+				// LocalDiskCreated stays True while expanding
+				Expect(findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeLocalDiskCreated).Status).To(Equal(metav1.ConditionTrue))
+			})
+
+			It("should set Expanding=False when an appended device fails validation", func() {
+				node1 := createStorageNode("storage-node-1")
+				lvdr1 := createLVDR("storage-node-1", operatorNS, []fusionv1alpha1.DiscoveredDevice{
+					{Path: "/dev/nvme3n3", WWN: "uuid.33333333-3333-3333-3333-333333333333"},
+				})
+
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(fsc, ld1, node1, lvdr1).
+					WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+					Build()
+
+				reconciler := &FileSystemClaimReconciler{
+					Client: fakeClient,
+					Scheme: scheme,
+				}
+
+				changed, err := reconciler.ensureLocalDisks(ctx, fsc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeTrue())
+
+				updated := &fusionv1alpha1.FileSystemClaim{}
+				Expect(fakeClient.Get(ctx, types.NamespacedName{Name: fsc.Name, Namespace: fsc.Namespace}, updated)).To(Succeed())
+
+				cond := findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeExpanding)
+				Expect(cond).NotTo(BeNil())
+				Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+				Expect(cond.Reason).To(Equal(ReasonExpansionFailed))
+				Expect(cond.Message).To(ContainSubstring("/dev/nvme2n2"))
+
+				// The existing Filesystem is untouched, Ready is not downgraded
+				Expect(findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeReady)).To(BeNil())
+			})
+
+			It("should keep LocalDiskCreated=True and report waiting LocalDisks through Expanding", func() {
+				fsc.Status.Conditions = append(fsc.Status.Conditions, metav1.Condition{
+					Type:   fusionv1alpha1.ConditionTypeExpanding,
+					Status: metav1.ConditionTrue,
+					Reason: ReasonExpansionInProgress,
+				})
+				Expect(unstructured.SetNestedSlice(ld1.Object, []any{
+					map[string]any{"type": "Ready", "status": "True"},
+					map[string]any{"type": "Used", "status": "True"},
+				}, "status", "conditions")).To(Succeed())
+				Expect(unstructured.SetNestedField(ld1.Object, fsc.Name, "status", "filesystem")).To(Succeed())
+				ld2 := createLocalDiskWithOwner("test-ld-2", fsc.Namespace, "/dev/nvme2n2", "storage-node-2", fsc)
+				Expect(unstructured.SetNestedSlice(ld2.Object, []any{
+					map[string]any{"type": "Ready", "status": "False", "message": "creating NSD"},
+					map[string]any{"type": "Used", "status": "False"},
+				}, "status", "conditions")).To(Succeed())
+
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(fsc, ld1, ld2).
+					WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+					Build()
+
+				reconciler := &FileSystemClaimReconciler{
+					Client: fakeClient,
+					Scheme: scheme,
+				}
+
+				changed, err := reconciler.syncLocalDiskConditions(ctx, fsc)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeTrue())
+
+				updated := &fusionv1alpha1.FileSystemClaim{}
+				Expect(fakeClient.Get(ctx, types.NamespacedName{Name: fsc.Name, Namespace: fsc.Namespace}, updated)).To(Succeed())
+
+				Expect(findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeLocalDiskCreated).Status).To(Equal(metav1.ConditionTrue))
+				cond := findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeExpanding)
+				Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+				Expect(cond.Message).To(ContainSubstring("test-ld-2"))
+
+				By("not adding the LocalDisk to the Filesystem before it is Ready")
+				changed, err = reconciler.ensureFileSystem(ctx, updated)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeFalse())
+			})
+
+			It("should mark the expansion as succeeded once the Filesystem uses all LocalDisks", func() {
+				fsc.Status.Conditions = append(fsc.Status.Conditions, metav1.Condition{
+					Type:   fusionv1alpha1.ConditionTypeExpanding,
+					Status: metav1.ConditionTrue,
+					Reason: ReasonExpansionInProgress,
+				})
+				ld2 := createLocalDiskWithOwner("test-ld-2", fsc.Namespace, "/dev/nvme2n2", "storage-node-2", fsc)
+				for _, ld := range []*unstructured.Unstructured{ld1, ld2} {
+					Expect(unstructured.SetNestedSlice(ld.Object, []any{
+						map[string]any{"type": "Ready", "status": "True"},
+						map[string]any{"type": "Used", "status": "False"},
+					}, "status", "conditions")).To(Succeed())
+				}
+
+				fakeClient := fake.NewClientBuilder().
+					WithScheme(scheme).
+					WithObjects(fsc, ld1, ld2).
+					WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+					Build()
+
+				reconciler := &FileSystemClaimReconciler{
+					Client: fakeClient,
+					Scheme: scheme,
+				}
+
+				By("waiting while the new LocalDisk is not used yet")
+				changed, err := reconciler.syncExpansionCompletion(ctx, fsc, fsc.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeFalse())
+
+				By("succeeding once every LocalDisk is used by the Filesystem")
+				for _, ld := range []*unstructured.Unstructured{ld1, ld2} {
+					Expect(unstructured.SetNestedSlice(ld.Object, []any{
+						map[string]any{"type": "Ready", "status": "True"},
+						map[string]any{"type": "Used", "status": "True"},
+					}, "status", "conditions")).To(Succeed())
+					Expect(unstructured.SetNestedField(ld.Object, fsc.Name, "status", "filesystem")).To(Succeed())
+					Expect(fakeClient.Update(ctx, ld)).To(Succeed())
+				}
+
+				changed, err = reconciler.syncExpansionCompletion(ctx, fsc, fsc.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(changed).To(BeTrue())
+
+				updated := &fusionv1alpha1.FileSystemClaim{}
+				Expect(fakeClient.Get(ctx, types.NamespacedName{Name: fsc.Name, Namespace: fsc.Namespace}, updated)).To(Succeed())
+				cond := findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeExpanding)
+				Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+				Expect(cond.Reason).To(Equal(ReasonExpansionSucceeded))
+			})
+		})
 	})
 })
@@ -25,13 +25,49 @@ import (
 	. "github.com/onsi/gomega"
 	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
+// newFakeReconciler returns a reconciler on a fake client holding objs, FileSystemClaim status updates going
+// through the status subresource as on a cluster
+func newFakeReconciler(scheme *runtime.Scheme, objs ...client.Object) *FileSystemClaimReconciler {
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}).
+		Build()
+	return &FileSystemClaimReconciler{Client: fakeClient, Scheme: scheme}
+}
+
+// getFSC reads the current state of a FileSystemClaim
+func getFSC(ctx context.Context, c client.Reader, fsc *fusionv1alpha1.FileSystemClaim) *fusionv1alpha1.FileSystemClaim {
+	cur := &fusionv1alpha1.FileSystemClaim{}
+	ExpectWithOffset(1, c.Get(ctx, types.NamespacedName{Name: fsc.Name, Namespace: fsc.Namespace}, cur)).To(Succeed())
+	return cur
+}
+
+var (
+	localDiskGVK  = schema.GroupVersionKind{Group: LocalDiskGroup, Version: LocalDiskVersion, Kind: LocalDiskKind}
+	fileSystemGVK = schema.GroupVersionKind{Group: FileSystemGroup, Version: FileSystemVersion, Kind: FileSystemKind}
+)
+
+// getUnstructured reads the current state of a Storage Scale resource
+func getUnstructured(ctx context.Context, c client.Reader, gvk schema.GroupVersionKind, namespace, name string) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	ExpectWithOffset(1, c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, obj)).To(Succeed())
+	return obj
+}
+
 var _ = Describe("FileSystemClaim Helper Functions", func() {
 
 	Describe("generateLocalDiskName", func() {
@@ -122,7 +158,7 @@ var _ = Describe("FileSystemClaim Helper Functions", func() {
 			It("should build correct spec for single disk", func() {
 				ldNames := []string{"uuid.test-wwn-123"}
 
-				spec := buildFilesystemSpec(ldNames)
+				spec := buildFilesystemSpec(nil, []filesystemPool{{name: "system", disks: ldNames}})
 
 				Expect(spec).To(HaveKey("local"))
 				local := spec["local"].(map[string]any)
@@ -148,7 +184,7 @@ var _ = Describe("FileSystemClaim Helper Functions", func() {
 			It("should build correct spec for multiple disks", func() {
 				ldNames := []string{"uuid.wwn1", "eui.wwn2", "0xwwn3"}
 
-				spec := buildFilesystemSpec(ldNames)
+				spec := buildFilesystemSpec(nil, []filesystemPool{{name: "system", disks: ldNames}})
 
 				local := spec["local"].(map[string]any)
 				pools := local["pools"].([]any)
@@ -161,7 +197,7 @@ var _ = Describe("FileSystemClaim Helper Functions", func() {
 			It("should include seLinuxOptions", func() {
 				ldNames := []string{"uuid.test-wwn-123"}
 
-				spec := buildFilesystemSpec(ldNames)
+				spec := buildFilesystemSpec(nil, []filesystemPool{{name: "system", disks: ldNames}})
 
 				Expect(spec).To(HaveKey("seLinuxOptions"))
 				seLinux := spec["seLinuxOptions"].(map[string]any)
@@ -172,6 +208,113 @@ var _ = Describe("FileSystemClaim Helper Functions", func() {
 				Expect(seLinux["user"]).To(Equal("system_u"))
 			})
 		})
+
+		Context("with a custom layout", func() {
+			It("should pass block size, replication and pools through", func() {
+				layout := &fusionv1alpha1.FileSystemLayout{
+					BlockSize:   "1M",
+					Replication: "2-way",
+				}
+				pools := []filesystemPool{
+					{name: "system", disks: []string{"uuid.wwn1"}},
+					{name: "data", disks: []string{"uuid.wwn2", "uuid.wwn3"}},
+				}
+
+				spec := buildFilesystemSpec(layout, pools)
+
+				local := spec["local"].(map[string]any)
+				Expect(local["blockSize"]).To(Equal("1M"))
+				Expect(local["replication"]).To(Equal("2-way"))
+
+				poolSpecs := local["pools"].([]any)
+				Expect(poolSpecs).To(HaveLen(2))
+				Expect(poolSpecs[1].(map[string]any)["name"]).To(Equal("data"))
+				Expect(poolSpecs[1].(map[string]any)["disks"]).To(Equal([]any{"uuid.wwn2", "uuid.wwn3"}))
+			})
+
+			It("should fall back to defaults for empty fields", func() {
+				spec := buildFilesystemSpec(&fusionv1alpha1.FileSystemLayout{}, []filesystemPool{{name: "system", disks: []string{"uuid.wwn1"}}})
+
+				local := spec["local"].(map[string]any)
+				Expect(local["blockSize"]).To(Equal(fusionv1alpha1.DefaultFileSystemBlockSize))
+				Expect(local["replication"]).To(Equal(fusionv1alpha1.DefaultFileSystemReplication))
+			})
+		})
+	})
+
+	Describe("buildFilesystemPools", func() {
+		var ownedLDs []unstructured.Unstructured
+
+		BeforeEach(func() {
+			fsc := &fusionv1alpha1.FileSystemClaim{ObjectMeta: metav1.ObjectMeta{Name: "test-fsc"}}
+			ownedLDs = []unstructured.Unstructured{
+				*createLocalDiskWithOwner("uuid.wwn1", "ibm-spectrum-scale", "/dev/sda", "node1", fsc),
+				*createLocalDiskWithOwner("uuid.wwn2", "ibm-spectrum-scale", "/dev/sdb", "node1", fsc),
+				*createLocalDiskWithOwner("uuid.wwn3", "ibm-spectrum-scale", "/dev/sdc", "node1", fsc),
+			}
+		})
+
+		It("should put all LocalDisks in the system pool without a layout", func() {
+			pools, err := buildFilesystemPools(nil, ownedLDs, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pools).To(Equal([]filesystemPool{
+				{name: "system", disks: []string{"uuid.wwn1", "uuid.wwn2", "uuid.wwn3"}},
+			}))
+		})
+
+		It("should place unassigned devices in the system pool", func() {
+			layout := &fusionv1alpha1.FileSystemLayout{
+				Pools: []fusionv1alpha1.FileSystemPool{
+					{Name: "data", Devices: []string{"/dev/sdc", "/dev/sdb"}},
+				},
+			}
+
+			pools, err := buildFilesystemPools(layout, ownedLDs, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pools).To(Equal([]filesystemPool{
+				{name: "system", disks: []string{"uuid.wwn1"}},
+				{name: "data", disks: []string{"uuid.wwn3", "uuid.wwn2"}},
+			}))
+		})
+
+		It("should leave detached devices out of every pool", func() {
+			layout := &fusionv1alpha1.FileSystemLayout{
+				Pools: []fusionv1alpha1.FileSystemPool{
+					{Name: "data", Devices: []string{"/dev/sdc", "/dev/sdb"}},
+				},
+			}
+
+			pools, err := buildFilesystemPools(layout, ownedLDs, map[string]struct{}{"/dev/sdb": {}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pools).To(Equal([]filesystemPool{
+				{name: "system", disks: []string{"uuid.wwn1"}},
+				{name: "data", disks: []string{"uuid.wwn3"}},
+			}))
+		})
+
+		It("should return an error when a pool device has no LocalDisk", func() {
+			layout := &fusionv1alpha1.FileSystemLayout{
+				Pools: []fusionv1alpha1.FileSystemPool{
+					{Name: "data", Devices: []string{"/dev/sdz"}},
+				},
+			}
+
+			_, err := buildFilesystemPools(layout, ownedLDs, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("/dev/sdz"))
+		})
+
+		It("should return an error when no device is left for the system pool", func() {
+			layout := &fusionv1alpha1.FileSystemLayout{
+				Pools: []fusionv1alpha1.FileSystemPool{
+					{Name: "data", Devices: []string{"/dev/sda", "/dev/sdb", "/dev/sdc"}},
+				},
+			}
+
+			_, err := buildFilesystemPools(layout, ownedLDs, nil)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("system"))
+		})
 	})
 
 	Describe("asMetaConditions", func() {
@@ -332,7 +475,7 @@ var _ = Describe("FileSystemClaim Helper Functions", func() {
 		})
 	})
 
-	Describe("getRandomStorageNode", func() {
+	Describe("getStorageNodes", func() {
 		var ctx context.Context
 
 		BeforeEach(func() {
@@ -373,9 +516,10 @@ var _ = Describe("FileSystemClaim Helper Functions", func() {
 				Scheme: scheme,
 			}
 
-			nodeName, err := reconciler.getRandomStorageNode(ctx)
+			nodes, err := reconciler.getStorageNodes(ctx)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(nodeName).To(Equal("storage-node-1"))
+			Expect(nodes).To(HaveLen(1))
+			Expect(nodes[0].Name).To(Equal("storage-node-1"))
 		})
 
 		It("should return error when no storage nodes found", func() {
@@ -402,14 +546,14 @@ var _ = Describe("FileSystemClaim Helper Functions", func() {
 				Scheme: scheme,
 			}
 
-			nodeName, err := reconciler.getRandomStorageNode(ctx)
+			nodes, err := reconciler.getStorageNodes(ctx)
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("no nodes found"))
-			Expect(nodeName).To(BeEmpty())
+			Expect(nodes).To(BeEmpty())
 		})
 	})
 
-	Describe("getDeviceWWN", func() {
+	Describe("getDiscoveredDevice", func() {
 		var ctx context.Context
 
 		BeforeEach(func() {
@@ -452,9 +596,9 @@ var _ = Describe("FileSystemClaim Helper Functions", func() {
 				Scheme: scheme,
 			}
 
-			wwn, err := reconciler.getDeviceWWN(ctx, "/dev/nvme0n1", "node1")
+			device, err := reconciler.getDiscoveredDevice(ctx, "/dev/nvme0n1", "node1")
 			Expect(err).NotTo(HaveOccurred())
-			Expect(wwn).To(Equal("uuid.12345678-abcd-1234-abcd-123456789abc"))
+			Expect(device.WWN).To(Equal("uuid.12345678-abcd-1234-abcd-123456789abc"))
 		})
 
 		It("should return error when device not found in LVDR", func() {
@@ -493,10 +637,10 @@ var _ = Describe("FileSystemClaim Helper Functions", func() {
 				Scheme: scheme,
 			}
 
-			wwn, err := reconciler.getDeviceWWN(ctx, "/dev/nvme0n1", "node1")
+			device, err := reconciler.getDiscoveredDevice(ctx, "/dev/nvme0n1", "node1")
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("not found"))
-			Expect(wwn).To(BeEmpty())
+			Expect(device).To(BeNil())
 		})
 
 		It("should return error when WWN is empty", func() {
@@ -532,10 +676,91 @@ var _ = Describe("FileSystemClaim Helper Functions", func() {
 				Scheme: scheme,
 			}
 
-			wwn, err := reconciler.getDeviceWWN(ctx, "/dev/nvme0n1", "node1")
+			device, err := reconciler.getDiscoveredDevice(ctx, "/dev/nvme0n1", "node1")
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("WWN is empty"))
-			Expect(wwn).To(BeEmpty())
+			Expect(device).To(BeNil())
+		})
+	})
+
+	Describe("findDiscoveredDevice", func() {
+		devices := []fusionv1alpha1.DiscoveredDevice{
+			{
+				Path:     "/dev/sdb",
+				DeviceID: "/dev/disk/by-id/wwn-0x6005076810810261f800000000000a1b",
+				WWN:      "0x6005076810810261f800000000000a1b",
+			},
+			{
+				Path:     "/dev/sdc",
+				DeviceID: "/dev/disk/by-id/wwn-0x6005076810810261f800000000000a1c",
+				WWN:      "0x6005076810810261f800000000000a1c",
+			},
+		}
+
+		DescribeTable("should resolve device references",
+			func(ref, expectedPath string) {
+				device := findDiscoveredDevice(devices, ref)
+				if expectedPath == "" {
+					Expect(device).To(BeNil())
+					return
+				}
+				Expect(device).NotTo(BeNil())
+				Expect(device.Path).To(Equal(expectedPath))
+			},
+			Entry("kernel path", "/dev/sdc", "/dev/sdc"),
+			Entry("by-id path", "/dev/disk/by-id/wwn-0x6005076810810261f800000000000a1b", "/dev/sdb"),
+			Entry("WWN", "0x6005076810810261f800000000000a1c", "/dev/sdc"),
+			Entry("WWN with different case", "0x6005076810810261F800000000000A1B", "/dev/sdb"),
+			Entry("unknown by-id path", "/dev/disk/by-id/wwn-0xdeadbeef", ""),
+			Entry("by-id path is not matched against kernel paths", "/dev/disk/by-id/sdb", ""),
+			Entry("unknown WWN", "0xdeadbeef", ""),
+		)
+	})
+
+	Describe("matchesDeviceSelector", func() {
+		device := &fusionv1alpha1.DiscoveredDevice{
+			Path:   "/dev/sdb",
+			Vendor: "IBM     ",
+			Model:  "2145            ",
+			Type:   fusionv1alpha1.MultiPathType,
+			Size:   1 << 40,
+			WWN:    "0x6005076810810261f800000000000a1b",
+		}
+
+		DescribeTable("should match every set criterion",
+			func(selector fusionv1alpha1.DeviceSelector, expected bool) {
+				Expect(matchesDeviceSelector(&selector, device)).To(Equal(expected))
+			},
+			Entry("vendor ignoring case and padding", fusionv1alpha1.DeviceSelector{Vendor: "ibm"}, true),
+			Entry("other vendor", fusionv1alpha1.DeviceSelector{Vendor: "NETAPP"}, false),
+			Entry("model", fusionv1alpha1.DeviceSelector{Model: "2145"}, true),
+			Entry("other model", fusionv1alpha1.DeviceSelector{Model: "2076"}, false),
+			Entry("size within range", fusionv1alpha1.DeviceSelector{
+				MinSize: ptr.To(resource.MustParse("500Gi")),
+				MaxSize: ptr.To(resource.MustParse("1Ti")),
+			}, true),
+			Entry("smaller than minSize", fusionv1alpha1.DeviceSelector{MinSize: ptr.To(resource.MustParse("2Ti"))}, false),
+			Entry("larger than maxSize", fusionv1alpha1.DeviceSelector{MaxSize: ptr.To(resource.MustParse("500Gi"))}, false),
+			Entry("type", fusionv1alpha1.DeviceSelector{
+				Types: []fusionv1alpha1.DiscoveredDeviceType{fusionv1alpha1.DiskType, fusionv1alpha1.MultiPathType},
+			}, true),
+			Entry("other type", fusionv1alpha1.DeviceSelector{
+				Types: []fusionv1alpha1.DiscoveredDeviceType{fusionv1alpha1.DiskType},
+			}, false),
+			Entry("WWN prefix ignoring case", fusionv1alpha1.DeviceSelector{WWNPrefix: "0x6005076810810261F8"}, true),
+			Entry("other WWN prefix", fusionv1alpha1.DeviceSelector{WWNPrefix: "0x5000c5"}, false),
+			Entry("all criteria must match", fusionv1alpha1.DeviceSelector{Vendor: "IBM", Model: "2076"}, false),
+		)
+	})
+
+	Describe("localDiskDevice", func() {
+		It("should prefer the recorded device reference over the kernel path", func() {
+			fsc := &fusionv1alpha1.FileSystemClaim{ObjectMeta: metav1.ObjectMeta{Name: "test-fsc"}}
+			ld := createLocalDiskWithOwner("uuid.wwn1", "ibm-spectrum-scale", "/dev/sdb", "node1", fsc)
+			Expect(localDiskDevice(ld)).To(Equal("/dev/sdb"))
+
+			ld.SetAnnotations(map[string]string{LocalDiskDeviceAnnotation: "0x6005076810810261f800000000000a1b"})
+			Expect(localDiskDevice(ld)).To(Equal("0x6005076810810261f800000000000a1b"))
 		})
 	})
 
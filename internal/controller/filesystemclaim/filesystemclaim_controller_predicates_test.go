@@ -52,6 +52,25 @@ var _ = Describe("FileSystemClaim Predicates and Handlers", func() {
 		})
 	})
 
+	Describe("fscRequestFromLabels", func() {
+		It("should return the FileSystemClaim named in the ownership labels", func() {
+			obj := &unstructured.Unstructured{}
+			obj.SetLabels(map[string]string{
+				FileSystemClaimOwnedByNameLabel:      "test-fsc",
+				FileSystemClaimOwnedByNamespaceLabel: "ibm-spectrum-scale",
+			})
+
+			requests := fscRequestFromLabels(obj)
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].Name).To(Equal("test-fsc"))
+			Expect(requests[0].Namespace).To(Equal("ibm-spectrum-scale"))
+		})
+
+		It("should return nothing without ownership labels", func() {
+			Expect(fscRequestFromLabels(&unstructured.Unstructured{})).To(BeEmpty())
+		})
+	})
+
 	Describe("isOwnedByFileSystemClaim", func() {
 		It("should return true when owned by FileSystemClaim", func() {
 			obj := &unstructured.Unstructured{}
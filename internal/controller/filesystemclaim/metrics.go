@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/metrics"
+)
+
+// failureReasons are the condition reasons that put a FileSystemClaim in the Failed phase
+var failureReasons = map[string]struct{}{
+	ReasonDeviceValidationFailed:     {},
+	ReasonLocalDiskCreationFailed:    {},
+	ReasonFileSystemCreationFailed:   {},
+	ReasonStorageClassCreationFailed: {},
+	ReasonExpansionFailed:            {},
+	ReasonProvisioningFailed:         {},
+	ReasonValidationFailed:           {},
+	ReasonDeviceNotFound:             {},
+	ReasonDeviceInUse:                {},
+	ReasonImmutableFieldModified:     {},
+	ReasonAdoptionFailed:             {},
+}
+
+var localDiskStates = []fusionv1alpha1.LocalDiskState{
+	fusionv1alpha1.LocalDiskStatePending,
+	fusionv1alpha1.LocalDiskStateReady,
+	fusionv1alpha1.LocalDiskStateInUse,
+	fusionv1alpha1.LocalDiskStateFailed,
+}
+
+var conditionStatuses = []metav1.ConditionStatus{metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionUnknown}
+
+// fscPhase summarizes the conditions of a FileSystemClaim in a single phase
+func fscPhase(fsc *fusionv1alpha1.FileSystemClaim) string {
+	if fsc.DeletionTimestamp != nil {
+		return metrics.PhaseDeleting
+	}
+	if apimeta.IsStatusConditionTrue(fsc.Status.Conditions, fusionv1alpha1.ConditionTypeReady) {
+		return metrics.PhaseReady
+	}
+	for _, cond := range fsc.Status.Conditions {
+		if _, ok := failureReasons[cond.Reason]; ok && cond.Status == metav1.ConditionFalse {
+			return metrics.PhaseFailed
+		}
+	}
+	return metrics.PhaseProvisioning
+}
+
+// recordFSCMetrics reports the phase, conditions and LocalDisk counts of a FileSystemClaim
+func recordFSCMetrics(fsc *fusionv1alpha1.FileSystemClaim) {
+	phase := fscPhase(fsc)
+	for _, p := range metrics.Phases {
+		value := 0.0
+		if p == phase {
+			value = 1
+		}
+		metrics.FileSystemClaimPhase.WithLabelValues(fsc.Namespace, fsc.Name, p).Set(value)
+	}
+
+	// Conditions can disappear from status, so the series are rebuilt
+	metrics.FileSystemClaimCondition.DeletePartialMatch(prometheus.Labels{"namespace": fsc.Namespace, "name": fsc.Name})
+	for _, cond := range fsc.Status.Conditions {
+		for _, status := range conditionStatuses {
+			value := 0.0
+			if cond.Status == status {
+				value = 1
+			}
+			metrics.FileSystemClaimCondition.WithLabelValues(fsc.Namespace, fsc.Name, cond.Type, string(status)).Set(value)
+		}
+	}
+
+	counts := make(map[fusionv1alpha1.LocalDiskState]int)
+	for _, device := range fsc.Status.Devices {
+		counts[device.State]++
+	}
+	for _, state := range localDiskStates {
+		metrics.FileSystemClaimLocalDisks.WithLabelValues(fsc.Namespace, fsc.Name, string(state)).Set(float64(counts[state]))
+	}
+
+	if !apimeta.IsStatusConditionTrue(fsc.Status.Conditions, fusionv1alpha1.ConditionTypeDeletionBlocked) {
+		labels := prometheus.Labels{"namespace": fsc.Namespace, "name": fsc.Name}
+		metrics.FileSystemClaimDeletionBlockedSeconds.DeletePartialMatch(labels)
+		metrics.FileSystemClaimDeletionRetrySeconds.DeletePartialMatch(labels)
+	}
+}
+
+// recordDeletionBlocked reports how long the deletion has been blocked for reason, and the backoff
+// returned by calculateDeletionBackoff
+func recordDeletionBlocked(fsc *fusionv1alpha1.FileSystemClaim, reason string, retryAfter time.Duration) {
+	var blockedFor time.Duration
+	cond := apimeta.FindStatusCondition(fsc.Status.Conditions, fusionv1alpha1.ConditionTypeDeletionBlocked)
+	if cond != nil && cond.Reason == reason {
+		blockedFor = time.Since(cond.LastTransitionTime.Time)
+	}
+	metrics.RecordDeletionBlocked(fsc.Namespace, fsc.Name, reason, blockedFor, retryAfter)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/metrics"
+)
+
+var _ = Describe("FileSystemClaim metrics", func() {
+	const namespace = "ibm-spectrum-scale"
+
+	gaugeValue := func(vec *prometheus.GaugeVec, labels ...string) float64 {
+		m := &dto.Metric{}
+		Expect(vec.WithLabelValues(labels...).Write(m)).To(Succeed())
+		return m.GetGauge().GetValue()
+	}
+
+	AfterEach(func() {
+		metrics.DeleteFileSystemClaim(namespace, "metrics-fsc")
+	})
+
+	DescribeTable("fscPhase",
+		func(deleting bool, conds []metav1.Condition, expected string) {
+			fsc := createTestFSC("metrics-fsc", namespace, []string{"/dev/sdb"}, conds)
+			if deleting {
+				fsc.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			}
+			Expect(fscPhase(fsc)).To(Equal(expected))
+		},
+		Entry("no conditions", false, nil, metrics.PhaseProvisioning),
+		Entry("ready", false, []metav1.Condition{
+			{Type: fusionv1alpha1.ConditionTypeReady, Status: metav1.ConditionTrue, Reason: ReasonProvisioningSucceeded},
+		}, metrics.PhaseReady),
+		Entry("in progress", false, []metav1.Condition{
+			localDiskCreatedCondition(metav1.ConditionFalse, ReasonLocalDiskCreationInProgress),
+		}, metrics.PhaseProvisioning),
+		Entry("failed", false, []metav1.Condition{
+			localDiskCreatedCondition(metav1.ConditionFalse, ReasonLocalDiskCreationFailed),
+		}, metrics.PhaseFailed),
+		Entry("deleting", true, []metav1.Condition{
+			{Type: fusionv1alpha1.ConditionTypeReady, Status: metav1.ConditionTrue, Reason: ReasonProvisioningSucceeded},
+		}, metrics.PhaseDeleting),
+	)
+
+	It("should report the phase, conditions and LocalDisk counts", func() {
+		fsc := createTestFSC("metrics-fsc", namespace, []string{"/dev/sdb", "/dev/sdc"}, []metav1.Condition{
+			deviceValidatedCondition(metav1.ConditionTrue),
+			localDiskCreatedCondition(metav1.ConditionFalse, ReasonLocalDiskCreationInProgress),
+		})
+		fsc.Status.Devices = []fusionv1alpha1.DeviceInventory{
+			{Device: "/dev/sdb", State: fusionv1alpha1.LocalDiskStateReady},
+			{Device: "/dev/sdc", State: fusionv1alpha1.LocalDiskStatePending},
+		}
+
+		recordFSCMetrics(fsc)
+
+		Expect(gaugeValue(metrics.FileSystemClaimPhase, namespace, fsc.Name, metrics.PhaseProvisioning)).To(Equal(1.0))
+		Expect(gaugeValue(metrics.FileSystemClaimPhase, namespace, fsc.Name, metrics.PhaseReady)).To(Equal(0.0))
+		Expect(gaugeValue(metrics.FileSystemClaimCondition, namespace, fsc.Name,
+			fusionv1alpha1.ConditionTypeDeviceValidated, "True")).To(Equal(1.0))
+		Expect(gaugeValue(metrics.FileSystemClaimCondition, namespace, fsc.Name,
+			fusionv1alpha1.ConditionTypeLocalDiskCreated, "True")).To(Equal(0.0))
+		Expect(gaugeValue(metrics.FileSystemClaimCondition, namespace, fsc.Name,
+			fusionv1alpha1.ConditionTypeLocalDiskCreated, "False")).To(Equal(1.0))
+		Expect(gaugeValue(metrics.FileSystemClaimLocalDisks, namespace, fsc.Name, "Ready")).To(Equal(1.0))
+		Expect(gaugeValue(metrics.FileSystemClaimLocalDisks, namespace, fsc.Name, "Pending")).To(Equal(1.0))
+		Expect(gaugeValue(metrics.FileSystemClaimLocalDisks, namespace, fsc.Name, "InUse")).To(Equal(0.0))
+	})
+
+	It("should report how long the deletion has been blocked", func() {
+		blockedSince := time.Now().Add(-5 * time.Minute)
+		fsc := createTestFSC("metrics-fsc", namespace, []string{"/dev/sdb"}, []metav1.Condition{{
+			Type:               fusionv1alpha1.ConditionTypeDeletionBlocked,
+			Status:             metav1.ConditionTrue,
+			Reason:             ReasonStorageClassInUse,
+			LastTransitionTime: metav1.Time{Time: blockedSince},
+		}})
+		reconciler := &FileSystemClaimReconciler{}
+
+		retryAfter := reconciler.calculateDeletionBackoff(fsc, ReasonStorageClassInUse)
+		recordDeletionBlocked(fsc, ReasonStorageClassInUse, retryAfter)
+
+		Expect(gaugeValue(metrics.FileSystemClaimDeletionBlockedSeconds, namespace, fsc.Name, ReasonStorageClassInUse)).
+			To(BeNumerically("~", 300, 5))
+		Expect(gaugeValue(metrics.FileSystemClaimDeletionRetrySeconds, namespace, fsc.Name, ReasonStorageClassInUse)).
+			To(Equal((4 * time.Minute).Seconds()))
+	})
+})
@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
+	"sync"
 	"time"
 
 	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
@@ -52,102 +54,164 @@ const (
 	MigrationReasonComplete      = "MigrationComplete"
 )
 
+// migrationMu serializes the migration runs
+var migrationMu sync.Mutex
+
 // LegacyResourceGroup represents a group of v1.0 resources that belong together
 type LegacyResourceGroup struct {
 	FilesystemName string
-	LocalDisks     []*unstructured.Unstructured
-	Filesystem     *unstructured.Unstructured
-	StorageClass   *storagev1.StorageClass
-	DevicePaths    []string
-	IsValid        bool
-}
-
-// MigrationStats tracks migration progress
-type MigrationStats struct {
-	TotalGroups       int
-	SuccessfulGroups  int
-	FailedGroups      int
-	TotalLocalDisks   int
-	SkippedLocalDisks int
+	// StorageClassName is the StorageClass of the Filesystem, named after the Filesystem when empty
+	StorageClassName string
+	LocalDisks       []*unstructured.Unstructured
+	Filesystem       *unstructured.Unstructured
+	StorageClass     *storagev1.StorageClass
+	DevicePaths      []string
+	IsValid          bool
 }
 
-// RunMigration is the main entry point for v1.0 to v1.1 migration
-// It discovers, groups, validates, and migrates legacy resources
+// RunMigration is the entry point of the v1.0 to v1.1 migration run at startup. The dry-run mode is read from
+// MIGRATION_DRY_RUN, and runs that find legacy LocalDisks are recorded as a MigrationReport.
 func RunMigration(ctx context.Context, c client.Client) error {
 	logger := log.FromContext(ctx).WithName("migration")
-	logger.Info("Starting v1.0 to v1.1 migration check")
 
-	// Check for dry-run mode
 	dryRun := os.Getenv(MigrationDryRunEnvVar) == MigrationLabelValueTrue
+	status, err := runMigration(ctx, c, dryRun)
+
+	if status.TotalLocalDisks > 0 || status.Phase == fusionv1alpha1.MigrationPhaseFailed {
+		if e := recordStartupReport(ctx, c, status); e != nil {
+			logger.Error(e, "Failed to record the migration report")
+		}
+	}
+
+	return err
+}
+
+// runMigration discovers, groups, validates, and migrates legacy resources. The returned status reports the
+// outcome of each group, and is also returned along with the error when the discovery fails.
+func runMigration(ctx context.Context, c client.Client, dryRun bool) (*fusionv1alpha1.MigrationReportStatus, error) {
+	logger := log.FromContext(ctx).WithName("migration")
+
+	// Startup runs and MigrationReports must not migrate the same groups concurrently
+	migrationMu.Lock()
+	defer migrationMu.Unlock()
+
+	logger.Info("Starting v1.0 to v1.1 migration check")
 	if dryRun {
 		logger.Info("Running in DRY-RUN mode - no changes will be made")
 	}
 
-	stats := &MigrationStats{}
+	startTime := metav1.Now()
+	status := &fusionv1alpha1.MigrationReportStatus{
+		Phase:     fusionv1alpha1.MigrationPhaseRunning,
+		DryRun:    dryRun,
+		StartTime: &startTime,
+	}
+	complete := func(phase fusionv1alpha1.MigrationPhase, message string) {
+		completionTime := metav1.Now()
+		status.Phase = phase
+		status.Message = message
+		status.CompletionTime = &completionTime
+	}
 
 	// Phase 1: Discovery - find all legacy LocalDisks
 	logger.Info("Phase 1: Discovering legacy LocalDisks")
 	localDisks, err := discoverLegacyLocalDisks(ctx, c)
 	if err != nil {
-		return fmt.Errorf("failed to discover legacy LocalDisks: %w", err)
+		err = fmt.Errorf("failed to discover legacy LocalDisks: %w", err)
+		complete(fusionv1alpha1.MigrationPhaseFailed, err.Error())
+		return status, err
 	}
 	logger.Info("Discovery complete", "candidateLocalDisks", len(localDisks))
-	stats.TotalLocalDisks = len(localDisks)
+	status.TotalLocalDisks = int32(len(localDisks))
 
 	if len(localDisks) == 0 {
 		logger.Info("No legacy LocalDisks found - migration not needed")
-		return nil
+		complete(fusionv1alpha1.MigrationPhaseCompleted, "No legacy LocalDisks found")
+		return status, nil
 	}
 
 	// Phase 2: Grouping - group LocalDisks by Filesystem
 	logger.Info("Phase 2: Grouping LocalDisks by Filesystem")
 	groups := groupLocalDisksByFilesystem(localDisks)
 	logger.Info("Grouping complete", "groups", len(groups))
-	stats.TotalGroups = len(groups)
+	status.TotalGroups = int32(len(groups))
+
+	fsNames := make([]string, 0, len(groups))
+	for fsName := range groups {
+		fsNames = append(fsNames, fsName)
+	}
+	sort.Strings(fsNames)
 
 	// Phase 3 & 4: Validate and migrate each group
-	for fsName, group := range groups {
+	for _, fsName := range fsNames {
+		group := groups[fsName]
 		groupLogger := logger.WithValues("filesystem", fsName, "localDisks", len(group.LocalDisks))
 		groupLogger.Info("Processing resource group")
 
-		// Phase 3: Validation
-		if err := validateResourceGroup(ctx, c, group); err != nil {
-			groupLogger.Error(err, "Validation failed, skipping group")
-			stats.FailedGroups++
-			continue
+		result := migrateGroup(ctx, c, group, dryRun)
+		result.ProcessedAt = metav1.Now()
+		status.Groups = append(status.Groups, result)
+
+		switch result.Outcome {
+		case fusionv1alpha1.MigrationOutcomeMigrated, fusionv1alpha1.MigrationOutcomeWouldMigrate:
+			groupLogger.Info("Group processed", "outcome", result.Outcome, "devices", group.DevicePaths)
+			status.SuccessfulGroups++
+		default:
+			groupLogger.Info("Group not migrated", "outcome", result.Outcome, "reason", result.Reason)
+			status.FailedGroups++
 		}
+	}
 
-		if !group.IsValid {
-			groupLogger.Info("Group is invalid, skipping")
-			stats.FailedGroups++
-			continue
-		}
+	// Log summary
+	logger.Info("Migration complete",
+		"totalGroups", status.TotalGroups,
+		"successful", status.SuccessfulGroups,
+		"failed", status.FailedGroups,
+		"totalLocalDisks", status.TotalLocalDisks)
+
+	complete(fusionv1alpha1.MigrationPhaseCompleted,
+		fmt.Sprintf("%d of %d groups migrated", status.SuccessfulGroups, status.TotalGroups))
+	return status, nil
+}
 
-		// Phase 4: Migration
-		if dryRun {
-			groupLogger.Info("DRY-RUN: Would migrate this group", "devices", group.DevicePaths)
-			stats.SuccessfulGroups++
-			continue
-		}
+// migrateGroup validates and migrates one group and returns its outcome
+func migrateGroup(ctx context.Context, c client.Client, group *LegacyResourceGroup, dryRun bool) fusionv1alpha1.MigrationGroupResult {
+	result := fusionv1alpha1.MigrationGroupResult{
+		Filesystem: group.FilesystemName,
+		Devices:    group.DevicePaths,
+	}
+	for _, ld := range group.LocalDisks {
+		result.LocalDisks = append(result.LocalDisks, ld.GetName())
+	}
 
-		if err := migrateResourceGroup(ctx, c, group); err != nil {
-			groupLogger.Error(err, "Migration failed for group")
-			stats.FailedGroups++
-			continue
-		}
+	// Phase 3: Validation
+	if err := validateResourceGroup(ctx, c, group); err != nil {
+		result.Outcome = fusionv1alpha1.MigrationOutcomeSkipped
+		result.Reason = fmt.Sprintf("validation failed: %v", err)
+		return result
+	}
 
-		groupLogger.Info("Migration successful", "devices", group.DevicePaths)
-		stats.SuccessfulGroups++
+	if !group.IsValid {
+		result.Outcome = fusionv1alpha1.MigrationOutcomeSkipped
+		result.Reason = "group is invalid"
+		return result
 	}
 
-	// Log summary
-	logger.Info("Migration complete",
-		"totalGroups", stats.TotalGroups,
-		"successful", stats.SuccessfulGroups,
-		"failed", stats.FailedGroups,
-		"totalLocalDisks", stats.TotalLocalDisks)
+	// Phase 4: Migration
+	if dryRun {
+		result.Outcome = fusionv1alpha1.MigrationOutcomeWouldMigrate
+		return result
+	}
 
-	return nil
+	if err := migrateResourceGroup(ctx, c, group); err != nil {
+		result.Outcome = fusionv1alpha1.MigrationOutcomeFailed
+		result.Reason = err.Error()
+		return result
+	}
+
+	result.Outcome = fusionv1alpha1.MigrationOutcomeMigrated
+	result.FileSystemClaim = group.FilesystemName
+	return result
 }
 
 // discoverLegacyLocalDisks finds all LocalDisks that are candidates for migration
@@ -346,8 +410,12 @@ func fetchRelatedResources(ctx context.Context, c client.Client, group *LegacyRe
 	group.Filesystem = fs
 
 	// Fetch StorageClass (cluster-scoped, no namespace)
+	scName := group.StorageClassName
+	if scName == "" {
+		scName = group.FilesystemName
+	}
 	sc := &storagev1.StorageClass{}
-	err = c.Get(ctx, types.NamespacedName{Name: group.FilesystemName}, sc)
+	err = c.Get(ctx, types.NamespacedName{Name: scName}, sc)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// StorageClass is optional
@@ -512,6 +580,9 @@ func updateOwnerRefsAndLabels(ctx context.Context, c client.Client, fsc *fusionv
 			continue // Already updated
 		}
 
+		if err := recordPreMigrationState(ld); err != nil {
+			return fmt.Errorf("failed to record the state of LocalDisk %s: %w", ld.GetName(), err)
+		}
 		if err := addOwnerRefAndStandardLabels(ld, fsc, scheme); err != nil {
 			return fmt.Errorf("failed to add ownerRef to LocalDisk %s: %w", ld.GetName(), err)
 		}
@@ -527,6 +598,9 @@ func updateOwnerRefsAndLabels(ctx context.Context, c client.Client, fsc *fusionv
 
 	// Update Filesystem
 	if !hasOwnerRefToFSC(group.Filesystem, fsc.Name) {
+		if err := recordPreMigrationState(group.Filesystem); err != nil {
+			return fmt.Errorf("failed to record the state of Filesystem: %w", err)
+		}
 		if err := addOwnerRefAndStandardLabels(group.Filesystem, fsc, scheme); err != nil {
 			return fmt.Errorf("failed to add ownerRef to Filesystem: %w", err)
 		}
@@ -542,13 +616,17 @@ func updateOwnerRefsAndLabels(ctx context.Context, c client.Client, fsc *fusionv
 
 	// Update StorageClass labels if it exists (no ownerRef - cluster-scoped resource)
 	if group.StorageClass != nil {
-		labels := group.StorageClass.GetLabels()
-		if labels == nil {
-			labels = make(map[string]string)
-		}
-
 		// Check if already labeled
-		if labels[MigrationLabelMigrated] != MigrationLabelValueTrue {
+		if group.StorageClass.GetLabels()[MigrationLabelMigrated] != MigrationLabelValueTrue {
+			if err := recordPreMigrationState(group.StorageClass); err != nil {
+				return fmt.Errorf("failed to record the state of StorageClass: %w", err)
+			}
+
+			labels := group.StorageClass.GetLabels()
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+
 			// Add standard ownership labels (NO ownerRef - StorageClass is cluster-scoped)
 			labels[FileSystemClaimOwnedByNameLabel] = fsc.Name
 			labels[FileSystemClaimOwnedByNamespaceLabel] = fsc.Namespace
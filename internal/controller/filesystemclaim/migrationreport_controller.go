@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+const (
+	// MigrationTriggerLabel records what started the migration run of a MigrationReport
+	MigrationTriggerLabel = "fusion.storage.openshift.io/migration-trigger"
+	// MigrationTriggerStartup marks the reports of the runs done when the operator starts
+	MigrationTriggerStartup = "startup"
+
+	// maxStartupReports is the number of startup reports kept, older ones are deleted
+	maxStartupReports = 5
+)
+
+// MigrationReportReconciler runs the migration requested by a MigrationReport and records the outcome in its status
+type MigrationReportReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=migrationreports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=migrationreports/status,verbs=get;update;patch
+
+func (r *MigrationReportReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	report := &fusionv1alpha1.MigrationReport{}
+	if err := r.Get(ctx, req.NamespacedName, report); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// A report records a single run, a new report has to be created to run the migration again
+	if isMigrationReportDone(report) {
+		return ctrl.Result{}, nil
+	}
+
+	if report.Namespace != MigrationNamespace {
+		msg := fmt.Sprintf("MigrationReports are only processed in namespace %s", MigrationNamespace)
+		return ctrl.Result{}, r.patchReportStatus(ctx, report, func(status *fusionv1alpha1.MigrationReportStatus) {
+			now := metav1.Now()
+			status.Phase = fusionv1alpha1.MigrationPhaseFailed
+			status.Message = msg
+			status.CompletionTime = &now
+		})
+	}
+
+	if report.Status.Phase != fusionv1alpha1.MigrationPhaseRunning {
+		now := metav1.Now()
+		if err := r.patchReportStatus(ctx, report, func(status *fusionv1alpha1.MigrationReportStatus) {
+			status.Phase = fusionv1alpha1.MigrationPhaseRunning
+			status.DryRun = report.Spec.DryRun
+			status.StartTime = &now
+		}); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	logger.Info("Running migration requested by MigrationReport", "name", report.Name, "dryRun", report.Spec.DryRun)
+	result, err := runMigration(ctx, r.Client, report.Spec.DryRun)
+	if err != nil {
+		// The failure is recorded in the report, running it again is up to the admin
+		logger.Error(err, "Migration failed", "name", report.Name)
+	}
+
+	return ctrl.Result{}, r.patchReportStatus(ctx, report, func(status *fusionv1alpha1.MigrationReportStatus) {
+		*status = *result
+	})
+}
+
+// patchReportStatus merge-patches the status of the report
+func (r *MigrationReportReconciler) patchReportStatus(
+	ctx context.Context,
+	report *fusionv1alpha1.MigrationReport,
+	mutate func(*fusionv1alpha1.MigrationReportStatus),
+) error {
+	orig := report.DeepCopy()
+	mutate(&report.Status)
+	return r.Status().Patch(ctx, report, client.MergeFrom(orig))
+}
+
+// isMigrationReportDone reports whether the run of the report is over. Startup reports are created with their
+// outcome by the operator and are never run.
+func isMigrationReportDone(report *fusionv1alpha1.MigrationReport) bool {
+	return report.Labels[MigrationTriggerLabel] == MigrationTriggerStartup ||
+		report.Status.Phase == fusionv1alpha1.MigrationPhaseCompleted ||
+		report.Status.Phase == fusionv1alpha1.MigrationPhaseFailed
+}
+
+// recordStartupReport persists the outcome of a startup run as a MigrationReport and deletes the oldest startup
+// reports beyond maxStartupReports
+func recordStartupReport(ctx context.Context, c client.Client, status *fusionv1alpha1.MigrationReportStatus) error {
+	report := &fusionv1alpha1.MigrationReport{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "startup-",
+			Namespace:    MigrationNamespace,
+			Labels:       map[string]string{MigrationTriggerLabel: MigrationTriggerStartup},
+		},
+		Spec: fusionv1alpha1.MigrationReportSpec{DryRun: status.DryRun},
+	}
+	if err := c.Create(ctx, report); err != nil {
+		return fmt.Errorf("failed to create MigrationReport: %w", err)
+	}
+
+	report.Status = *status
+	if err := c.Status().Update(ctx, report); err != nil {
+		return fmt.Errorf("failed to update MigrationReport %s status: %w", report.Name, err)
+	}
+
+	return pruneStartupReports(ctx, c)
+}
+
+// pruneStartupReports deletes the oldest startup reports beyond maxStartupReports
+func pruneStartupReports(ctx context.Context, c client.Client) error {
+	reports := &fusionv1alpha1.MigrationReportList{}
+	if err := c.List(ctx, reports,
+		client.InNamespace(MigrationNamespace),
+		client.MatchingLabels{MigrationTriggerLabel: MigrationTriggerStartup}); err != nil {
+		return fmt.Errorf("failed to list startup MigrationReports: %w", err)
+	}
+
+	if len(reports.Items) <= maxStartupReports {
+		return nil
+	}
+
+	items := reports.Items
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].CreationTimestamp.Equal(&items[j].CreationTimestamp) {
+			return items[i].CreationTimestamp.Before(&items[j].CreationTimestamp)
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	for i := range items[:len(items)-maxStartupReports] {
+		if err := c.Delete(ctx, &items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete MigrationReport %s: %w", items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MigrationReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&fusionv1alpha1.MigrationReport{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
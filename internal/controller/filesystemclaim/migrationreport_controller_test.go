@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("MigrationReport", func() {
+	const namespace = MigrationNamespace
+
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	newClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&fusionv1alpha1.FileSystemClaim{}, &fusionv1alpha1.MigrationReport{}).
+			Build()
+	}
+
+	// legacyObjects returns a valid group for fs-valid and a group without Filesystem for fs-missing
+	legacyObjects := func() []client.Object {
+		return []client.Object{
+			createV1LocalDisk("uuid.valid", namespace, "/dev/nvme0n1", "worker-1", "fs-valid"),
+			createV1Filesystem("fs-valid", namespace),
+			createV1LocalDisk("uuid.missing", namespace, "/dev/nvme1n1", "worker-1", "fs-missing"),
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(fusionv1alpha1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	Describe("runMigration", func() {
+		It("should report the outcome of each group", func() {
+			c := newClient(legacyObjects()...)
+
+			status, err := runMigration(ctx, c, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Phase).To(Equal(fusionv1alpha1.MigrationPhaseCompleted))
+			Expect(status.StartTime).NotTo(BeNil())
+			Expect(status.CompletionTime).NotTo(BeNil())
+			Expect(status.TotalLocalDisks).To(Equal(int32(2)))
+			Expect(status.TotalGroups).To(Equal(int32(2)))
+			Expect(status.SuccessfulGroups).To(Equal(int32(1)))
+			Expect(status.FailedGroups).To(Equal(int32(1)))
+
+			Expect(status.Groups).To(HaveLen(2))
+			missing, valid := status.Groups[0], status.Groups[1]
+			Expect(missing.Filesystem).To(Equal("fs-missing"))
+			Expect(missing.Outcome).To(Equal(fusionv1alpha1.MigrationOutcomeSkipped))
+			Expect(missing.Reason).To(ContainSubstring("filesystem fs-missing not found"))
+			Expect(missing.LocalDisks).To(Equal([]string{"uuid.missing"}))
+			Expect(valid.Filesystem).To(Equal("fs-valid"))
+			Expect(valid.Outcome).To(Equal(fusionv1alpha1.MigrationOutcomeMigrated))
+			Expect(valid.Devices).To(Equal([]string{"/dev/nvme0n1"}))
+			Expect(valid.FileSystemClaim).To(Equal("fs-valid"))
+			Expect(valid.ProcessedAt.IsZero()).To(BeFalse())
+		})
+
+		It("should not change anything in a dry run", func() {
+			c := newClient(legacyObjects()...)
+
+			status, err := runMigration(ctx, c, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.DryRun).To(BeTrue())
+			Expect(status.Groups[1].Outcome).To(Equal(fusionv1alpha1.MigrationOutcomeWouldMigrate))
+			Expect(status.Groups[1].FileSystemClaim).To(BeEmpty())
+
+			fscs := &fusionv1alpha1.FileSystemClaimList{}
+			Expect(c.List(ctx, fscs, client.InNamespace(namespace))).To(Succeed())
+			Expect(fscs.Items).To(BeEmpty())
+		})
+	})
+
+	Describe("startup reports", func() {
+		It("should record a startup run that finds legacy LocalDisks", func() {
+			c := newClient(legacyObjects()...)
+
+			Expect(RunMigration(ctx, c)).To(Succeed())
+
+			reports := &fusionv1alpha1.MigrationReportList{}
+			Expect(c.List(ctx, reports, client.InNamespace(namespace))).To(Succeed())
+			Expect(reports.Items).To(HaveLen(1))
+			report := reports.Items[0]
+			Expect(report.Labels[MigrationTriggerLabel]).To(Equal(MigrationTriggerStartup))
+			Expect(report.Status.Phase).To(Equal(fusionv1alpha1.MigrationPhaseCompleted))
+			Expect(report.Status.Groups).To(HaveLen(2))
+		})
+
+		It("should not record a startup run without legacy LocalDisks", func() {
+			c := newClient()
+
+			Expect(RunMigration(ctx, c)).To(Succeed())
+
+			reports := &fusionv1alpha1.MigrationReportList{}
+			Expect(c.List(ctx, reports, client.InNamespace(namespace))).To(Succeed())
+			Expect(reports.Items).To(BeEmpty())
+		})
+
+		It("should keep only the most recent startup reports", func() {
+			var objs []client.Object
+			for i := range maxStartupReports + 2 {
+				objs = append(objs, &fusionv1alpha1.MigrationReport{ObjectMeta: metav1.ObjectMeta{
+					Name:      fmt.Sprintf("startup-%d", i),
+					Namespace: namespace,
+					Labels:    map[string]string{MigrationTriggerLabel: MigrationTriggerStartup},
+				}})
+			}
+			objs = append(objs, &fusionv1alpha1.MigrationReport{ObjectMeta: metav1.ObjectMeta{Name: "manual", Namespace: namespace}})
+			c := newClient(objs...)
+
+			Expect(pruneStartupReports(ctx, c)).To(Succeed())
+
+			reports := &fusionv1alpha1.MigrationReportList{}
+			Expect(c.List(ctx, reports, client.InNamespace(namespace))).To(Succeed())
+			Expect(reports.Items).To(HaveLen(maxStartupReports + 1))
+			Expect(c.Get(ctx, types.NamespacedName{Name: "manual", Namespace: namespace}, &fusionv1alpha1.MigrationReport{})).To(Succeed())
+		})
+	})
+
+	Describe("Reconcile", func() {
+		reconcileReport := func(c client.Client, report *fusionv1alpha1.MigrationReport) *fusionv1alpha1.MigrationReport {
+			r := &MigrationReportReconciler{Client: c, Scheme: scheme}
+			key := types.NamespacedName{Name: report.Name, Namespace: report.Namespace}
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+
+			current := &fusionv1alpha1.MigrationReport{}
+			Expect(c.Get(ctx, key, current)).To(Succeed())
+			return current
+		}
+
+		It("should run the requested dry run and record its outcome", func() {
+			report := &fusionv1alpha1.MigrationReport{
+				ObjectMeta: metav1.ObjectMeta{Name: "rerun", Namespace: namespace},
+				Spec:       fusionv1alpha1.MigrationReportSpec{DryRun: true},
+			}
+			c := newClient(append(legacyObjects(), report)...)
+
+			current := reconcileReport(c, report)
+			Expect(current.Status.Phase).To(Equal(fusionv1alpha1.MigrationPhaseCompleted))
+			Expect(current.Status.DryRun).To(BeTrue())
+			Expect(current.Status.SuccessfulGroups).To(Equal(int32(1)))
+			Expect(current.Status.Groups).To(HaveLen(2))
+		})
+
+		It("should not run a report again once it is completed", func() {
+			report := &fusionv1alpha1.MigrationReport{
+				ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: namespace},
+				Status:     fusionv1alpha1.MigrationReportStatus{Phase: fusionv1alpha1.MigrationPhaseCompleted},
+			}
+			c := newClient(append(legacyObjects(), report)...)
+
+			current := reconcileReport(c, report)
+			Expect(current.Status.Groups).To(BeEmpty())
+
+			fscs := &fusionv1alpha1.FileSystemClaimList{}
+			Expect(c.List(ctx, fscs, client.InNamespace(namespace))).To(Succeed())
+			Expect(fscs.Items).To(BeEmpty())
+		})
+
+		It("should not run a startup report", func() {
+			report := &fusionv1alpha1.MigrationReport{ObjectMeta: metav1.ObjectMeta{
+				Name:      "startup-abcde",
+				Namespace: namespace,
+				Labels:    map[string]string{MigrationTriggerLabel: MigrationTriggerStartup},
+			}}
+			c := newClient(append(legacyObjects(), report)...)
+
+			current := reconcileReport(c, report)
+			Expect(current.Status.Phase).To(BeEmpty())
+		})
+
+		It("should fail reports outside of the migration namespace", func() {
+			report := &fusionv1alpha1.MigrationReport{ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "default"}}
+			c := newClient(append(legacyObjects(), report)...)
+
+			current := reconcileReport(c, report)
+			Expect(current.Status.Phase).To(Equal(fusionv1alpha1.MigrationPhaseFailed))
+			Expect(current.Status.Message).To(ContainSubstring(MigrationNamespace))
+		})
+	})
+})
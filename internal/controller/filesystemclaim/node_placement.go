@@ -0,0 +1,221 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ensurePlacements returns the storage node serving each device of the claim. Devices with a LocalDisk
+// keep its node, the others are placed with spec.nodePlacement. The result is recorded in
+// status.placements so that later reconciles create the LocalDisks on the same nodes.
+func (r *FileSystemClaimReconciler) ensurePlacements(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim) (map[string]string, error) {
+	logger := log.FromContext(ctx)
+
+	owned, err := r.listOwnedResources(ctx, fsc, schema.GroupVersionKind{
+		Group:   LocalDiskGroup,
+		Version: LocalDiskVersion,
+		Kind:    LocalDiskKind,
+	}, LocalDiskList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned LocalDisks: %w", err)
+	}
+
+	nodes := make(map[string]string)
+	for i := range owned {
+		if node, _, _ := unstructured.NestedString(owned[i].Object, "spec", "node"); node != "" {
+			nodes[localDiskDevice(&owned[i])] = node
+		}
+	}
+
+	// Placements recorded by a previous reconcile whose LocalDisk is not created yet
+	pending := make(map[string]int)
+	for _, placement := range fsc.Status.Placements {
+		if _, ok := nodes[placement.Device]; !ok {
+			nodes[placement.Device] = placement.Node
+			pending[placement.Node]++
+		}
+	}
+
+	devices := claimDevices(fsc)
+	var unplaced []string
+	for _, device := range devices {
+		if _, ok := nodes[device]; !ok {
+			unplaced = append(unplaced, device)
+		}
+	}
+
+	if len(unplaced) > 0 {
+		candidates, err := r.placementCandidates(ctx, fsc.Spec.NodePlacement)
+		if err != nil {
+			return nil, err
+		}
+
+		strategy := placementStrategy(fsc.Spec.NodePlacement)
+		var chosen map[string]string
+		switch strategy {
+		case fusionv1alpha1.NodePlacementRoundRobin:
+			chosen = placeRoundRobin(candidates, len(devices)-len(unplaced), unplaced)
+		default:
+			load, err := r.localDiskLoad(ctx, fsc.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			for node, count := range pending {
+				load[node] += count
+			}
+			chosen = placeLeastLoaded(candidates, load, unplaced)
+		}
+
+		for device, node := range chosen {
+			nodes[device] = node
+		}
+		logger.Info("Placed devices on storage nodes", "strategy", strategy, "placements", chosen)
+	}
+
+	placements := make([]fusionv1alpha1.DevicePlacement, 0, len(devices))
+	for _, device := range devices {
+		placements = append(placements, fusionv1alpha1.DevicePlacement{Device: device, Node: nodes[device]})
+	}
+	if !reflect.DeepEqual(placements, fsc.Status.Placements) {
+		if err := r.patchFSCStatus(ctx, fsc, func(cur *fusionv1alpha1.FileSystemClaim) {
+			cur.Status.Placements = placements
+		}); err != nil {
+			return nil, fmt.Errorf("failed to record device placements: %w", err)
+		}
+	}
+
+	return nodes, nil
+}
+
+// placementCandidates returns the storage nodes allowed by spec.nodePlacement, sorted by name
+func (r *FileSystemClaimReconciler) placementCandidates(ctx context.Context, placement *fusionv1alpha1.NodePlacement) ([]string, error) {
+	storageNodes, err := r.getStorageNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	storageNodeNames := make([]string, 0, len(storageNodes))
+	for i := range storageNodes {
+		storageNodeNames = append(storageNodeNames, storageNodes[i].Name)
+	}
+	if placement != nil {
+		for _, name := range placement.Nodes {
+			if !slices.Contains(storageNodeNames, name) {
+				return nil, fmt.Errorf("node %s in spec.nodePlacement.nodes is not a storage node", name)
+			}
+		}
+	}
+
+	var candidates []string
+	for i := range storageNodes {
+		node := &storageNodes[i]
+		if placement != nil {
+			if len(placement.Nodes) > 0 && !slices.Contains(placement.Nodes, node.Name) {
+				continue
+			}
+			if !hasLabels(node.GetLabels(), placement.NodeSelector) {
+				continue
+			}
+		}
+		candidates = append(candidates, node.Name)
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("none of the %d storage nodes matches spec.nodePlacement", len(storageNodes))
+	}
+	return candidates, nil
+}
+
+// localDiskLoad counts the LocalDisks served by each node in the namespace
+func (r *FileSystemClaimReconciler) localDiskLoad(ctx context.Context, namespace string) (map[string]int, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   LocalDiskGroup,
+		Version: LocalDiskVersion,
+		Kind:    LocalDiskList,
+	})
+	if err := r.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list LocalDisks: %w", err)
+	}
+
+	load := make(map[string]int)
+	for i := range list.Items {
+		if node, _, _ := unstructured.NestedString(list.Items[i].Object, "spec", "node"); node != "" {
+			load[node]++
+		}
+	}
+	return load, nil
+}
+
+// placementStrategy returns the strategy of spec.nodePlacement, LeastLoaded by default
+func placementStrategy(placement *fusionv1alpha1.NodePlacement) fusionv1alpha1.NodePlacementStrategy {
+	if placement == nil || placement.Strategy == "" {
+		return fusionv1alpha1.NodePlacementLeastLoaded
+	}
+	return placement.Strategy
+}
+
+// placeLeastLoaded places each device on the candidate with the fewest LocalDisks, counting the devices
+// placed so far. Ties go to the first candidate so the result only depends on its inputs.
+func placeLeastLoaded(candidates []string, load map[string]int, devices []string) map[string]string {
+	counts := make(map[string]int, len(candidates))
+	for _, node := range candidates {
+		counts[node] = load[node]
+	}
+
+	chosen := make(map[string]string, len(devices))
+	for _, device := range devices {
+		best := candidates[0]
+		for _, node := range candidates[1:] {
+			if counts[node] < counts[best] {
+				best = node
+			}
+		}
+		chosen[device] = best
+		counts[best]++
+	}
+	return chosen
+}
+
+// placeRoundRobin places the devices on the candidates in turn, starting after the devices already placed
+func placeRoundRobin(candidates []string, placed int, devices []string) map[string]string {
+	chosen := make(map[string]string, len(devices))
+	for i, device := range devices {
+		chosen[device] = candidates[(placed+i)%len(candidates)]
+	}
+	return chosen
+}
+
+// hasLabels reports whether labels contains every key/value of selector
+func hasLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("Node placement", func() {
+	var (
+		ctx        context.Context
+		scheme     *runtime.Scheme
+		namespace  = "ibm-spectrum-scale"
+		fsc        *fusionv1alpha1.FileSystemClaim
+		fakeClient client.Client
+		reconciler *FileSystemClaimReconciler
+	)
+
+	buildClient := func(objs ...client.Object) {
+		nodes := []client.Object{
+			createStorageNode("storage-node-1"),
+			createStorageNode("storage-node-2"),
+			createStorageNode("storage-node-3"),
+		}
+		reconciler = newFakeReconciler(scheme, append(append(nodes, fsc), objs...)...)
+		fakeClient = reconciler.Client
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(fusionv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fsc = createTestFSC("test-fsc", namespace, []string{"/dev/sdb", "/dev/sdc", "/dev/sdd", "/dev/sde"}, nil)
+	})
+
+	Describe("placeLeastLoaded", func() {
+		It("should spread devices over the least loaded nodes", func() {
+			chosen := placeLeastLoaded(
+				[]string{"node-a", "node-b", "node-c"},
+				map[string]int{"node-a": 2, "node-b": 0, "node-c": 1},
+				[]string{"dev1", "dev2", "dev3"},
+			)
+			Expect(chosen).To(Equal(map[string]string{"dev1": "node-b", "dev2": "node-b", "dev3": "node-c"}))
+		})
+
+		It("should break ties by candidate order", func() {
+			chosen := placeLeastLoaded([]string{"node-a", "node-b"}, nil, []string{"dev1", "dev2", "dev3"})
+			Expect(chosen).To(Equal(map[string]string{"dev1": "node-a", "dev2": "node-b", "dev3": "node-a"}))
+		})
+	})
+
+	Describe("placeRoundRobin", func() {
+		It("should continue after the devices already placed", func() {
+			chosen := placeRoundRobin([]string{"node-a", "node-b", "node-c"}, 2, []string{"dev3", "dev4"})
+			Expect(chosen).To(Equal(map[string]string{"dev3": "node-c", "dev4": "node-a"}))
+		})
+	})
+
+	Describe("placementCandidates", func() {
+		It("should return every storage node by default", func() {
+			buildClient()
+
+			candidates, err := reconciler.placementCandidates(ctx, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(candidates).To(Equal([]string{"storage-node-1", "storage-node-2", "storage-node-3"}))
+		})
+
+		It("should restrict the candidates to the listed nodes and the node selector", func() {
+			labeled := createStorageNode("storage-node-4")
+			labeled.Labels["topology.kubernetes.io/zone"] = "zone-a"
+			buildClient(labeled)
+
+			candidates, err := reconciler.placementCandidates(ctx, &fusionv1alpha1.NodePlacement{
+				Nodes: []string{"storage-node-2", "storage-node-4"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(candidates).To(Equal([]string{"storage-node-2", "storage-node-4"}))
+
+			candidates, err = reconciler.placementCandidates(ctx, &fusionv1alpha1.NodePlacement{
+				NodeSelector: map[string]string{"topology.kubernetes.io/zone": "zone-a"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(candidates).To(Equal([]string{"storage-node-4"}))
+		})
+
+		It("should reject a listed node that is not a storage node", func() {
+			buildClient()
+
+			_, err := reconciler.placementCandidates(ctx, &fusionv1alpha1.NodePlacement{Nodes: []string{"worker-1"}})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is not a storage node"))
+		})
+
+		It("should fail when no storage node matches the node selector", func() {
+			buildClient()
+
+			_, err := reconciler.placementCandidates(ctx, &fusionv1alpha1.NodePlacement{
+				NodeSelector: map[string]string{"topology.kubernetes.io/zone": "zone-b"},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("matches spec.nodePlacement"))
+		})
+	})
+
+	Describe("ensurePlacements", func() {
+		It("should place devices on the least loaded nodes and record them in status", func() {
+			// storage-node-1 already serves a LocalDisk of another claim
+			other := &fusionv1alpha1.FileSystemClaim{ObjectMeta: metav1.ObjectMeta{Name: "other-fsc"}}
+			buildClient(createLocalDiskWithOwner("other-ld", namespace, "/dev/sdf", "storage-node-1", other))
+
+			nodes, err := reconciler.ensurePlacements(ctx, fsc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(Equal(map[string]string{
+				"/dev/sdb": "storage-node-2",
+				"/dev/sdc": "storage-node-3",
+				"/dev/sdd": "storage-node-1",
+				"/dev/sde": "storage-node-2",
+			}))
+
+			Expect(getFSC(ctx, fakeClient, fsc).Status.Placements).To(Equal([]fusionv1alpha1.DevicePlacement{
+				{Device: "/dev/sdb", Node: "storage-node-2"},
+				{Device: "/dev/sdc", Node: "storage-node-3"},
+				{Device: "/dev/sdd", Node: "storage-node-1"},
+				{Device: "/dev/sde", Node: "storage-node-2"},
+			}))
+		})
+
+		It("should place devices round-robin on the selected nodes", func() {
+			fsc.Spec.NodePlacement = &fusionv1alpha1.NodePlacement{
+				Strategy: fusionv1alpha1.NodePlacementRoundRobin,
+				Nodes:    []string{"storage-node-1", "storage-node-3"},
+			}
+			buildClient()
+
+			nodes, err := reconciler.ensurePlacements(ctx, fsc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes).To(Equal(map[string]string{
+				"/dev/sdb": "storage-node-1",
+				"/dev/sdc": "storage-node-3",
+				"/dev/sdd": "storage-node-1",
+				"/dev/sde": "storage-node-3",
+			}))
+		})
+
+		It("should keep recorded placements and the nodes of existing LocalDisks", func() {
+			fsc.Status.Placements = []fusionv1alpha1.DevicePlacement{
+				{Device: "/dev/sdb", Node: "storage-node-3"},
+				{Device: "/dev/sdc", Node: "storage-node-3"},
+				{Device: "/dev/sdd", Node: "storage-node-3"},
+			}
+			// The LocalDisk node wins over a stale recorded placement
+			ld := createLocalDiskWithOwner("test-ld-1", namespace, "/dev/sdb", "storage-node-1", fsc)
+			buildClient(ld)
+
+			nodes, err := reconciler.ensurePlacements(ctx, fsc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nodes["/dev/sdb"]).To(Equal("storage-node-1"))
+			Expect(nodes["/dev/sdc"]).To(Equal("storage-node-3"))
+			Expect(nodes["/dev/sdd"]).To(Equal("storage-node-3"))
+			// storage-node-2 has no LocalDisk and no pending placement
+			Expect(nodes["/dev/sde"]).To(Equal("storage-node-2"))
+		})
+
+		It("should create the LocalDisks on the placed nodes", func() {
+			operatorNS := "test-operator-ns"
+			GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", operatorNS)
+
+			fsc.Spec.Devices = []string{"/dev/sdb", "/dev/sdc"}
+			fsc.Spec.NodePlacement = &fusionv1alpha1.NodePlacement{Strategy: fusionv1alpha1.NodePlacementRoundRobin}
+			fsc.Status.Conditions = []metav1.Condition{deviceValidatedCondition(metav1.ConditionTrue)}
+
+			var lvdrs []client.Object
+			for _, node := range []string{"storage-node-1", "storage-node-2", "storage-node-3"} {
+				lvdrs = append(lvdrs, createLVDR(node, operatorNS, []fusionv1alpha1.DiscoveredDevice{
+					{Path: "/dev/sdb", WWN: "uuid.11111111-1111-1111-1111-111111111111"},
+					{Path: "/dev/sdc", WWN: "uuid.22222222-2222-2222-2222-222222222222"},
+				}))
+			}
+			buildClient(lvdrs...)
+
+			changed, err := reconciler.ensureLocalDisks(ctx, fsc)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			for name, expected := range map[string]string{
+				"uuid.11111111-1111-1111-1111-111111111111": "storage-node-1",
+				"uuid.22222222-2222-2222-2222-222222222222": "storage-node-2",
+			} {
+				ld := &unstructured.Unstructured{}
+				ld.SetGroupVersionKind(schema.GroupVersionKind{Group: LocalDiskGroup, Version: LocalDiskVersion, Kind: LocalDiskKind})
+				Expect(fakeClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, ld)).To(Succeed())
+				node, _, _ := unstructured.NestedString(ld.Object, "spec", "node")
+				Expect(node).To(Equal(expected))
+			}
+		})
+	})
+})
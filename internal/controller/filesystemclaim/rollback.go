@@ -0,0 +1,214 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/events"
+)
+
+const (
+	// MigrationAnnotationPreState records the labels, annotations and ownerRefs of a resource before it was
+	// migrated or adopted, so the migration can be rolled back
+	MigrationAnnotationPreState = "fusion.storage.openshift.io/pre-migration-state"
+
+	// RollbackMigrationAnnotation set to "true" on a migrated or adopted FileSystemClaim hands its resources back
+	// in their pre-migration state and deletes the claim without deleting them
+	RollbackMigrationAnnotation = "fusion.storage.openshift.io/rollback-migration"
+
+	// Event reasons of the rollback
+	ReasonMigrationRolledBack  = "MigrationRolledBack"
+	ReasonRollbackNotSupported = "RollbackNotSupported"
+
+	migrationRollbackEventTopic = "MigrationRollback"
+)
+
+// preMigrationState is the metadata of a resource before it was migrated or adopted
+type preMigrationState struct {
+	Labels          map[string]string       `json:"labels,omitempty"`
+	Annotations     map[string]string       `json:"annotations,omitempty"`
+	OwnerReferences []metav1.OwnerReference `json:"ownerReferences,omitempty"`
+}
+
+// recordPreMigrationState stores the current metadata of obj in an annotation, unless it was already recorded
+func recordPreMigrationState(obj client.Object) error {
+	if _, ok := obj.GetAnnotations()[MigrationAnnotationPreState]; ok {
+		return nil
+	}
+
+	state, err := json.Marshal(preMigrationState{
+		Labels:          obj.GetLabels(),
+		Annotations:     obj.GetAnnotations(),
+		OwnerReferences: obj.GetOwnerReferences(),
+	})
+	if err != nil {
+		return err
+	}
+
+	annotations := make(map[string]string, len(obj.GetAnnotations())+1)
+	for k, v := range obj.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[MigrationAnnotationPreState] = string(state)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// restorePreMigrationState puts back the metadata recorded by recordPreMigrationState. Resources without a
+// recorded state, e.g. migrated before the state was recorded, lose the ownerRef, labels and annotations added
+// by the migration or adoption instead.
+func restorePreMigrationState(obj client.Object, fsc *fusionv1alpha1.FileSystemClaim) error {
+	if raw, ok := obj.GetAnnotations()[MigrationAnnotationPreState]; ok {
+		state := &preMigrationState{}
+		if err := json.Unmarshal([]byte(raw), state); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", MigrationAnnotationPreState, err)
+		}
+		obj.SetLabels(state.Labels)
+		obj.SetAnnotations(state.Annotations)
+		obj.SetOwnerReferences(state.OwnerReferences)
+		return nil
+	}
+
+	var ownerRefs []metav1.OwnerReference
+	for _, ownerRef := range obj.GetOwnerReferences() {
+		if ownerRef.Kind != FileSystemClaimKind || ownerRef.Name != fsc.Name {
+			ownerRefs = append(ownerRefs, ownerRef)
+		}
+	}
+	obj.SetOwnerReferences(ownerRefs)
+
+	labels := obj.GetLabels()
+	for _, key := range []string{
+		FileSystemClaimOwnedByNameLabel, FileSystemClaimOwnedByNamespaceLabel, MigrationLabelMigrated, MigrationLabelSource,
+	} {
+		delete(labels, key)
+	}
+	obj.SetLabels(labels)
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, MigrationAnnotationTimestamp)
+	delete(annotations, AdoptionAnnotationTimestamp)
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// isRollbackSupported reports whether the FSC took over existing resources, through the v1.0 migration or
+// the adopt-filesystem annotation
+func isRollbackSupported(fsc *fusionv1alpha1.FileSystemClaim) bool {
+	return fsc.Labels[MigrationLabelMigrated] == MigrationLabelValueTrue || adoptedFilesystemName(fsc) != ""
+}
+
+// handleRollback rolls back the migration or adoption of the FSC when the rollback-migration annotation is set.
+// The LocalDisks, Filesystem and StorageClass get their pre-migration metadata back, the LocalDisks are labeled to
+// be skipped by the next migration, and the FSC is deleted without running its finalizer so nothing is deleted.
+// Returns true once the FSC is gone.
+func (r *FileSystemClaimReconciler) handleRollback(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	if fsc.Annotations[RollbackMigrationAnnotation] != MigrationLabelValueTrue {
+		return false, nil
+	}
+
+	if !isRollbackSupported(fsc) {
+		r.eventReporter().Report(events.NewEvent(migrationRollbackEventTopic, ReasonRollbackNotSupported,
+			"Only migrated or adopted FileSystemClaims can be rolled back, the annotation is ignored"), fsc)
+		return false, nil
+	}
+
+	logger.Info("Rolling back the migration of FileSystemClaim", "name", fsc.Name)
+
+	var localDisks, filesystems int
+	for _, gvk := range []struct {
+		gvk      schema.GroupVersionKind
+		listKind string
+		count    *int
+	}{
+		{schema.GroupVersionKind{Group: LocalDiskGroup, Version: LocalDiskVersion, Kind: LocalDiskKind}, LocalDiskList, &localDisks},
+		{schema.GroupVersionKind{Group: FileSystemGroup, Version: FileSystemVersion, Kind: FileSystemKind}, FileSystemList, &filesystems},
+	} {
+		owned, err := r.listOwnedResources(ctx, fsc, gvk.gvk, gvk.listKind)
+		if err != nil {
+			return false, fmt.Errorf("failed to list owned %s: %w", gvk.gvk.Kind, err)
+		}
+		for i := range owned {
+			obj := &owned[i]
+			orig := obj.DeepCopy()
+			if err := restorePreMigrationState(obj, fsc); err != nil {
+				return false, fmt.Errorf("failed to restore %s %s: %w", gvk.gvk.Kind, obj.GetName(), err)
+			}
+			if gvk.gvk.Kind == LocalDiskKind {
+				labels := obj.GetLabels()
+				if labels == nil {
+					labels = make(map[string]string)
+				}
+				labels[MigrationLabelSkip] = MigrationLabelValueTrue
+				obj.SetLabels(labels)
+			}
+			if err := r.Patch(ctx, obj, client.MergeFrom(orig)); err != nil {
+				return false, fmt.Errorf("failed to patch %s %s: %w", gvk.gvk.Kind, obj.GetName(), err)
+			}
+			*gvk.count++
+		}
+	}
+
+	sc := &storagev1.StorageClass{}
+	err := r.Get(ctx, types.NamespacedName{Name: fusionv1alpha1.StorageClassNameOf(fsc)}, sc)
+	switch {
+	case errors.IsNotFound(err):
+	case err != nil:
+		return false, fmt.Errorf("failed to get StorageClass: %w", err)
+	case isStorageClassOwnedByFSC(sc, fsc):
+		orig := sc.DeepCopy()
+		if err := restorePreMigrationState(sc, fsc); err != nil {
+			return false, fmt.Errorf("failed to restore StorageClass %s: %w", sc.Name, err)
+		}
+		if err := r.Patch(ctx, sc, client.MergeFrom(orig)); err != nil {
+			return false, fmt.Errorf("failed to patch StorageClass %s: %w", sc.Name, err)
+		}
+	}
+
+	// Nothing is owned anymore, the claim can go without running the deletion of its resources
+	if controllerutil.ContainsFinalizer(fsc, FileSystemClaimFinalizer) {
+		if err := r.patchFSCSpec(ctx, fsc, func(cur *fusionv1alpha1.FileSystemClaim) {
+			controllerutil.RemoveFinalizer(cur, FileSystemClaimFinalizer)
+		}); err != nil {
+			return false, fmt.Errorf("failed to remove finalizer: %w", err)
+		}
+	}
+	if err := r.Delete(ctx, fsc); client.IgnoreNotFound(err) != nil {
+		return false, fmt.Errorf("failed to delete FileSystemClaim: %w", err)
+	}
+
+	msg := fmt.Sprintf("Rolled back %d LocalDisks and %d Filesystem to their pre-migration state", localDisks, filesystems)
+	r.eventReporter().Report(events.NewSuccessEvent(migrationRollbackEventTopic, ReasonMigrationRolledBack, msg), fsc)
+	r.eventReporter().Forget(fsc)
+	logger.Info(msg, "name", fsc.Name)
+	return true, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("FileSystemClaim migration rollback", func() {
+	const namespace = MigrationNamespace
+
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	// requestRollback sets the finalizer the reconciler would have added and the rollback annotation
+	requestRollback := func(r *FileSystemClaimReconciler, name string) *fusionv1alpha1.FileSystemClaim {
+		fsc := &fusionv1alpha1.FileSystemClaim{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, fsc)).To(Succeed())
+		controllerutil.AddFinalizer(fsc, FileSystemClaimFinalizer)
+		if fsc.Annotations == nil {
+			fsc.Annotations = map[string]string{}
+		}
+		fsc.Annotations[RollbackMigrationAnnotation] = MigrationLabelValueTrue
+		Expect(r.Update(ctx, fsc)).To(Succeed())
+		return fsc
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(fusionv1alpha1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	It("should restore the pre-migration state and delete the claim", func() {
+		ld := createV1LocalDisk("uuid.a", namespace, "/dev/nvme0n1", "worker-1", "fs-legacy")
+		ld.SetLabels(map[string]string{"team": "storage"})
+		fs := createV1Filesystem("fs-legacy", namespace)
+		fs.SetAnnotations(map[string]string{"note": "v1.0"})
+		sc := &storagev1.StorageClass{
+			ObjectMeta:  metav1.ObjectMeta{Name: "fs-legacy", Labels: map[string]string{"tier": "gold"}},
+			Provisioner: SpectrumScaleProvisioner,
+		}
+		r := newFakeReconciler(scheme, ld, fs, sc)
+
+		_, err := runMigration(ctx, r.Client, false)
+		Expect(err).NotTo(HaveOccurred())
+		migrated := getUnstructured(ctx, r, localDiskGVK, namespace, "uuid.a")
+		Expect(migrated.GetOwnerReferences()).To(HaveLen(1))
+		Expect(migrated.GetAnnotations()).To(HaveKey(MigrationAnnotationPreState))
+
+		fsc := requestRollback(r, "fs-legacy")
+		rolledBack, err := r.handleRollback(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rolledBack).To(BeTrue())
+
+		restoredLD := getUnstructured(ctx, r, localDiskGVK, namespace, "uuid.a")
+		Expect(restoredLD.GetOwnerReferences()).To(BeEmpty())
+		Expect(restoredLD.GetLabels()).To(Equal(map[string]string{"team": "storage", MigrationLabelSkip: MigrationLabelValueTrue}))
+		Expect(restoredLD.GetAnnotations()).To(BeEmpty())
+
+		restoredFS := getUnstructured(ctx, r, fileSystemGVK, namespace, "fs-legacy")
+		Expect(restoredFS.GetOwnerReferences()).To(BeEmpty())
+		Expect(restoredFS.GetLabels()).To(BeEmpty())
+		Expect(restoredFS.GetAnnotations()).To(Equal(map[string]string{"note": "v1.0"}))
+
+		restoredSC := &storagev1.StorageClass{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: "fs-legacy"}, restoredSC)).To(Succeed())
+		Expect(restoredSC.Labels).To(Equal(map[string]string{"tier": "gold"}))
+		Expect(restoredSC.Annotations).To(BeEmpty())
+
+		err = r.Get(ctx, types.NamespacedName{Name: "fs-legacy", Namespace: namespace}, &fusionv1alpha1.FileSystemClaim{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		// The restored LocalDisks are not migrated again
+		status, err := runMigration(ctx, r.Client, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(status.TotalLocalDisks).To(BeZero())
+	})
+
+	It("should strip the migration metadata of resources without a recorded state", func() {
+		fsc := createTestFSC("fs-legacy", namespace, []string{"/dev/nvme0n1"}, nil)
+		fsc.Labels = map[string]string{MigrationLabelMigrated: MigrationLabelValueTrue}
+		ld := createLocalDiskWithOwner("uuid.a", namespace, "/dev/nvme0n1", "worker-1", fsc)
+		addMigrationLabels(ld, "2025-01-01T00:00:00Z")
+		r := newFakeReconciler(scheme, fsc, ld)
+
+		fsc = requestRollback(r, "fs-legacy")
+		rolledBack, err := r.handleRollback(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rolledBack).To(BeTrue())
+
+		restoredLD := getUnstructured(ctx, r, localDiskGVK, namespace, "uuid.a")
+		Expect(restoredLD.GetOwnerReferences()).To(BeEmpty())
+		Expect(restoredLD.GetLabels()).To(Equal(map[string]string{MigrationLabelSkip: MigrationLabelValueTrue}))
+		Expect(restoredLD.GetAnnotations()).NotTo(HaveKey(MigrationAnnotationTimestamp))
+	})
+
+	It("should ignore the annotation on claims that were not migrated or adopted", func() {
+		fsc := createTestFSC("fresh", namespace, []string{"/dev/nvme0n1"}, nil)
+		ld := createLocalDiskWithOwner("fresh-ld", namespace, "/dev/nvme0n1", "worker-1", fsc)
+		r := newFakeReconciler(scheme, fsc, ld)
+
+		fsc = requestRollback(r, "fresh")
+		rolledBack, err := r.handleRollback(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rolledBack).To(BeFalse())
+
+		Expect(r.Get(ctx, types.NamespacedName{Name: "fresh", Namespace: namespace}, &fusionv1alpha1.FileSystemClaim{})).To(Succeed())
+		Expect(getUnstructured(ctx, r, localDiskGVK, namespace, "fresh-ld").GetOwnerReferences()).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,352 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+// SharedDeviceInventoryReconciler aggregates the LocalVolumeDiscoveryResults of all nodes and the LocalDisks
+// using their devices into the SharedDeviceInventory
+type SharedDeviceInventoryReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=shareddeviceinventories,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=shareddeviceinventories/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=localvolumediscoveryresults,verbs=get;list;watch
+// +kubebuilder:rbac:groups=scale.spectrum.ibm.com,resources=localdisks,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+
+func (r *SharedDeviceInventoryReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	status, err := buildSharedDeviceInventory(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	inventory := &fusionv1alpha1.SharedDeviceInventory{}
+	err = r.Get(ctx, types.NamespacedName{Name: fusionv1alpha1.SharedDeviceInventoryName}, inventory)
+	switch {
+	case errors.IsNotFound(err):
+		inventory = &fusionv1alpha1.SharedDeviceInventory{
+			ObjectMeta: metav1.ObjectMeta{Name: fusionv1alpha1.SharedDeviceInventoryName},
+		}
+		if err := r.Create(ctx, inventory); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create SharedDeviceInventory: %w", err)
+		}
+	case err != nil:
+		return ctrl.Result{}, fmt.Errorf("failed to get SharedDeviceInventory: %w", err)
+	}
+
+	// Only bump LastUpdateTime when the content changed
+	current := inventory.Status.DeepCopy()
+	current.LastUpdateTime = nil
+	if inventory.Status.LastUpdateTime != nil && equality.Semantic.DeepEqual(current, status) {
+		return ctrl.Result{}, nil
+	}
+
+	orig := inventory.DeepCopy()
+	now := metav1.Now()
+	status.LastUpdateTime = &now
+	inventory.Status = *status
+	if err := r.Status().Patch(ctx, inventory, client.MergeFrom(orig)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update SharedDeviceInventory status: %w", err)
+	}
+	logger.Info("Updated SharedDeviceInventory", "devices", status.TotalDevices,
+		"shared", status.SharedDevices, "used", status.UsedDevices)
+	return ctrl.Result{}, nil
+}
+
+// buildSharedDeviceInventory computes the status of the SharedDeviceInventory from the storage nodes, the
+// LocalVolumeDiscoveryResults in the operator namespace and the LocalDisks
+func buildSharedDeviceInventory(ctx context.Context, c client.Client) (*fusionv1alpha1.SharedDeviceInventoryStatus, error) {
+	nodes, err := listStorageNodes(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	storageNodes := make([]string, 0, len(nodes))
+	for i := range nodes {
+		storageNodes = append(storageNodes, nodes[i].Name)
+	}
+
+	operatorNamespace, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator deployment namespace: %w", err)
+	}
+	lvdrs := &fusionv1alpha1.LocalVolumeDiscoveryResultList{}
+	if err := c.List(ctx, lvdrs, client.InNamespace(operatorNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list LocalVolumeDiscoveryResults: %w", err)
+	}
+
+	ldList := &unstructured.UnstructuredList{}
+	ldList.SetGroupVersionKind(schema.GroupVersionKind{Group: LocalDiskGroup, Version: LocalDiskVersion, Kind: LocalDiskList})
+	// LocalDisks only exist once Storage Scale is installed
+	if err := c.List(ctx, ldList); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list LocalDisks: %w", err)
+	}
+
+	return aggregateSharedDevices(storageNodes, lvdrs.Items, ldList.Items), nil
+}
+
+// aggregateSharedDevices keys the discovered devices by WWN, lists the nodes that see each of them and marks the
+// devices used by a LocalDisk. Devices without WWN cannot be matched across nodes and are left out.
+func aggregateSharedDevices(
+	storageNodes []string,
+	lvdrs []fusionv1alpha1.LocalVolumeDiscoveryResult,
+	localDisks []unstructured.Unstructured,
+) *fusionv1alpha1.SharedDeviceInventoryStatus {
+	byWWN := make(map[string]*fusionv1alpha1.SharedDevice)
+	for i := range lvdrs {
+		lvdr := &lvdrs[i]
+		for _, discovered := range lvdr.Status.DiscoveredDevices {
+			if discovered.WWN == "" {
+				continue
+			}
+			key := strings.ToLower(discovered.WWN)
+			device, ok := byWWN[key]
+			if !ok {
+				device = &fusionv1alpha1.SharedDevice{
+					WWN:    discovered.WWN,
+					Size:   discovered.Size,
+					Model:  discovered.Model,
+					Vendor: discovered.Vendor,
+					Type:   discovered.Type,
+				}
+				byWWN[key] = device
+			}
+			device.Nodes = append(device.Nodes, fusionv1alpha1.DeviceNodePath{
+				Node:     lvdr.Spec.NodeName,
+				Path:     discovered.Path,
+				DeviceID: discovered.DeviceID,
+			})
+			if discovered.InUse != nil {
+				mergeDeviceUsage(device, discovered.InUse)
+			}
+		}
+	}
+
+	for i := range localDisks {
+		ld := &localDisks[i]
+		if device := findLocalDiskSharedDevice(byWWN, ld); device != nil {
+			mergeDeviceUsage(device, localDiskUsage(ld))
+		}
+	}
+
+	status := &fusionv1alpha1.SharedDeviceInventoryStatus{}
+	if len(storageNodes) > 0 {
+		status.StorageNodes = storageNodes
+	}
+	keys := make([]string, 0, len(byWWN))
+	for key := range byWWN {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		device := byWWN[key]
+		sort.Slice(device.Nodes, func(a, b int) bool { return device.Nodes[a].Node < device.Nodes[b].Node })
+		device.Shared = len(storageNodes) > 0
+		for _, node := range storageNodes {
+			if !slices.ContainsFunc(device.Nodes, func(p fusionv1alpha1.DeviceNodePath) bool { return p.Node == node }) {
+				device.Shared = false
+				break
+			}
+		}
+
+		status.Devices = append(status.Devices, *device)
+		status.TotalDevices++
+		if device.Shared {
+			status.SharedDevices++
+		}
+		if device.UsedBy != nil {
+			status.UsedDevices++
+		}
+	}
+	return status
+}
+
+// findLocalDiskSharedDevice returns the device of a LocalDisk, found by its node and kernel path or else by the
+// spec.devices reference it was created for
+func findLocalDiskSharedDevice(byWWN map[string]*fusionv1alpha1.SharedDevice, ld *unstructured.Unstructured) *fusionv1alpha1.SharedDevice {
+	node, _, _ := unstructured.NestedString(ld.Object, "spec", "node")
+	devicePath, _, _ := unstructured.NestedString(ld.Object, "spec", "device")
+	for _, device := range byWWN {
+		for _, p := range device.Nodes {
+			if p.Node == node && devicePath != "" && (p.Path == devicePath || p.DeviceID == devicePath) {
+				return device
+			}
+		}
+	}
+	if ref := ld.GetAnnotations()[LocalDiskDeviceAnnotation]; ref != "" && !strings.HasPrefix(ref, "/") {
+		return byWWN[strings.ToLower(ref)]
+	}
+	return nil
+}
+
+// localDiskUsage returns the usage of the device of a LocalDisk
+func localDiskUsage(ld *unstructured.Unstructured) *fusionv1alpha1.DeviceUsage {
+	filesystem, _, _ := unstructured.NestedString(ld.Object, "status", "filesystem")
+	usage := &fusionv1alpha1.DeviceUsage{LocalDisk: ld.GetName(), Filesystem: filesystem}
+	labels := ld.GetLabels()
+	if name := labels[FileSystemClaimOwnedByNameLabel]; name != "" {
+		usage.FileSystemClaim = labels[FileSystemClaimOwnedByNamespaceLabel] + "/" + name
+	}
+	return usage
+}
+
+// mergeDeviceUsage adds to the usage of a device what a node or a LocalDisk reports
+func mergeDeviceUsage(device *fusionv1alpha1.SharedDevice, usage *fusionv1alpha1.DeviceUsage) {
+	if device.UsedBy == nil {
+		device.UsedBy = &fusionv1alpha1.DeviceUsage{}
+	}
+	if usage.LocalDisk != "" {
+		device.UsedBy.LocalDisk = usage.LocalDisk
+	}
+	if usage.Filesystem != "" {
+		device.UsedBy.Filesystem = usage.Filesystem
+	}
+	if usage.FileSystemClaim != "" {
+		device.UsedBy.FileSystemClaim = usage.FileSystemClaim
+	}
+	device.UsedBy.NSDSignature = device.UsedBy.NSDSignature || usage.NSDSignature
+}
+
+// checkDevicesNotInUse fails on the first device reference that Storage Scale already uses on a storage node.
+// A kernel path may name different disks on different nodes, it is in use when any of them is.
+func checkDevicesNotInUse(status *fusionv1alpha1.SharedDeviceInventoryStatus, devices []string) error {
+	for _, ref := range devices {
+		for i := range status.Devices {
+			device := &status.Devices[i]
+			if device.UsedBy == nil {
+				continue
+			}
+			for _, p := range device.Nodes {
+				if !slices.Contains(status.StorageNodes, p.Node) {
+					continue
+				}
+				if matchesDeviceRef(p.Path, p.DeviceID, device.WWN, ref) {
+					return deviceInUseError(ref, "", device.UsedBy)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateDevicesInInventory checks that every storage node of the inventory sees each device reference, and
+// that no two references are the same device
+func validateDevicesInInventory(status *fusionv1alpha1.SharedDeviceInventoryStatus, devices []string) error {
+	if len(status.StorageNodes) == 0 {
+		return fmt.Errorf("no storage nodes in SharedDeviceInventory")
+	}
+
+	refByWWN := make(map[string]string, len(devices))
+	for _, ref := range devices {
+		device := findSharedDevice(status.Devices, ref)
+		if device == nil {
+			return fmt.Errorf("device %s not found in SharedDeviceInventory", ref)
+		}
+		for _, node := range status.StorageNodes {
+			if !slices.ContainsFunc(device.Nodes, func(p fusionv1alpha1.DeviceNodePath) bool {
+				return p.Node == node && matchesDeviceNodePath(p, ref)
+			}) {
+				return fmt.Errorf("device %s is not seen by node %s", ref, node)
+			}
+		}
+
+		key := strings.ToLower(device.WWN)
+		if other, dup := refByWWN[key]; dup {
+			return fmt.Errorf("devices %s and %s refer to the same disk %s", other, ref, device.WWN)
+		}
+		refByWWN[key] = ref
+	}
+	return nil
+}
+
+// findSharedDevice returns the device of the inventory matching a device reference from spec.devices or nil,
+// see matchesDeviceRef for the kinds of references
+func findSharedDevice(devices []fusionv1alpha1.SharedDevice, ref string) *fusionv1alpha1.SharedDevice {
+	for i := range devices {
+		device := &devices[i]
+		for _, p := range device.Nodes {
+			if matchesDeviceRef(p.Path, p.DeviceID, device.WWN, ref) {
+				return device
+			}
+		}
+	}
+	return nil
+}
+
+// matchesDeviceNodePath reports whether the path of a device on a node matches a device reference. WWN
+// references match any path, the device was already found by WWN.
+func matchesDeviceNodePath(p fusionv1alpha1.DeviceNodePath, ref string) bool {
+	return !strings.HasPrefix(ref, "/") || matchesDeviceRef(p.Path, p.DeviceID, "", ref)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SharedDeviceInventoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Every change lands in the single inventory
+	enqueueInventory := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: fusionv1alpha1.SharedDeviceInventoryName}}}
+	})
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&fusionv1alpha1.SharedDeviceInventory{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == fusionv1alpha1.SharedDeviceInventoryName
+		}))).
+		Watches(&fusionv1alpha1.LocalVolumeDiscoveryResult{}, enqueueInventory).
+		Watches(
+			&unstructured.Unstructured{
+				Object: map[string]any{
+					"apiVersion": LocalDiskGroup + "/" + LocalDiskVersion,
+					"kind":       LocalDiskKind,
+				},
+			},
+			enqueueInventory,
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})),
+		).
+		Watches(&corev1.Node{}, enqueueInventory, builder.OnlyMetadata,
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Named("shareddeviceinventory").
+		Complete(r)
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("SharedDeviceInventory", func() {
+	const (
+		operatorNS = "test-operator-ns"
+		sharedWWN  = "uuid.11111111-1111-1111-1111-111111111111"
+		localWWN   = "uuid.22222222-2222-2222-2222-222222222222"
+	)
+
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	newClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&fusionv1alpha1.SharedDeviceInventory{}).
+			Build()
+	}
+
+	// clusterObjects returns two storage nodes that both see sharedWWN under different paths, and a worker
+	// without the storage label. localWWN is only seen by storage-1.
+	clusterObjects := func() []client.Object {
+		worker := createStorageNode("worker-1")
+		delete(worker.Labels, ScaleStorageRoleLabel)
+		return []client.Object{
+			createStorageNode("storage-2"),
+			createStorageNode("storage-1"),
+			worker,
+			createLVDR("storage-1", operatorNS, []fusionv1alpha1.DiscoveredDevice{
+				{Path: "/dev/sdb", DeviceID: "/dev/disk/by-id/wwn-0x1111", WWN: sharedWWN, Size: 100, Type: fusionv1alpha1.DiskType},
+				{Path: "/dev/sdc", WWN: localWWN, Size: 200, Type: fusionv1alpha1.DiskType},
+				{Path: "/dev/sdd"},
+			}),
+			createLVDR("storage-2", operatorNS, []fusionv1alpha1.DiscoveredDevice{
+				{Path: "/dev/sdc", DeviceID: "/dev/disk/by-id/wwn-0x1111", WWN: sharedWWN, Size: 100, Type: fusionv1alpha1.DiskType},
+			}),
+		}
+	}
+
+	getInventory := func(c client.Client) *fusionv1alpha1.SharedDeviceInventory {
+		inventory := &fusionv1alpha1.SharedDeviceInventory{}
+		Expect(c.Get(ctx, types.NamespacedName{Name: fusionv1alpha1.SharedDeviceInventoryName}, inventory)).To(Succeed())
+		return inventory
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(fusionv1alpha1.AddToScheme(scheme)).To(Succeed())
+		GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", operatorNS)
+	})
+
+	Describe("Reconcile", func() {
+		It("should aggregate the discovery results by WWN", func() {
+			fsc := createTestFSC("fsc-1", "ibm-spectrum-scale", []string{localWWN}, nil)
+			objs := append(clusterObjects(),
+				createLocalDiskWithOwner(localWWN, "ibm-spectrum-scale", "/dev/sdc", "storage-1", fsc))
+			c := newClient(objs...)
+			r := &SharedDeviceInventoryReconciler{Client: c, Scheme: scheme}
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			Expect(err).NotTo(HaveOccurred())
+
+			status := getInventory(c).Status
+			Expect(status.StorageNodes).To(Equal([]string{"storage-1", "storage-2"}))
+			Expect(status.LastUpdateTime).NotTo(BeNil())
+			Expect(status.TotalDevices).To(Equal(int32(2)))
+			Expect(status.SharedDevices).To(Equal(int32(1)))
+			Expect(status.UsedDevices).To(Equal(int32(1)))
+			Expect(status.Devices).To(Equal([]fusionv1alpha1.SharedDevice{
+				{
+					WWN:  sharedWWN,
+					Size: 100,
+					Type: fusionv1alpha1.DiskType,
+					Nodes: []fusionv1alpha1.DeviceNodePath{
+						{Node: "storage-1", Path: "/dev/sdb", DeviceID: "/dev/disk/by-id/wwn-0x1111"},
+						{Node: "storage-2", Path: "/dev/sdc", DeviceID: "/dev/disk/by-id/wwn-0x1111"},
+					},
+					Shared: true,
+				},
+				{
+					WWN:    localWWN,
+					Size:   200,
+					Type:   fusionv1alpha1.DiskType,
+					Nodes:  []fusionv1alpha1.DeviceNodePath{{Node: "storage-1", Path: "/dev/sdc"}},
+					UsedBy: &fusionv1alpha1.DeviceUsage{LocalDisk: localWWN, FileSystemClaim: "ibm-spectrum-scale/fsc-1"},
+				},
+			}))
+		})
+
+		It("should merge what the nodes and the LocalDisks report using a device", func() {
+			objs := clusterObjects()
+			lvdr := objs[4].(*fusionv1alpha1.LocalVolumeDiscoveryResult)
+			lvdr.Status.DiscoveredDevices[0].InUse = &fusionv1alpha1.DeviceUsage{NSDSignature: true}
+			ld := createV1LocalDisk("legacy-1", "ibm-spectrum-scale", "/dev/sdc", "storage-2", "fs1")
+			c := newClient(append(objs, ld)...)
+			r := &SharedDeviceInventoryReconciler{Client: c, Scheme: scheme}
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			status := getInventory(c).Status
+			Expect(status.UsedDevices).To(Equal(int32(1)))
+			Expect(status.Devices[0].WWN).To(Equal(sharedWWN))
+			Expect(status.Devices[0].UsedBy).To(Equal(&fusionv1alpha1.DeviceUsage{
+				LocalDisk:    "legacy-1",
+				Filesystem:   "fs1",
+				NSDSignature: true,
+			}))
+		})
+
+		It("should only update the inventory when the devices change", func() {
+			c := newClient(clusterObjects()...)
+			r := &SharedDeviceInventoryReconciler{Client: c, Scheme: scheme}
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			first := getInventory(c)
+
+			_, err = r.Reconcile(ctx, ctrl.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(getInventory(c).ResourceVersion).To(Equal(first.ResourceVersion))
+
+			lvdr := &fusionv1alpha1.LocalVolumeDiscoveryResult{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "discovery-result-storage-2", Namespace: operatorNS}, lvdr)).To(Succeed())
+			lvdr.Status.DiscoveredDevices = append(lvdr.Status.DiscoveredDevices,
+				fusionv1alpha1.DiscoveredDevice{Path: "/dev/sdd", WWN: localWWN, Size: 200, Type: fusionv1alpha1.DiskType})
+			Expect(c.Update(ctx, lvdr)).To(Succeed())
+
+			_, err = r.Reconcile(ctx, ctrl.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			updated := getInventory(c)
+			Expect(updated.ResourceVersion).NotTo(Equal(first.ResourceVersion))
+			Expect(updated.Status.SharedDevices).To(Equal(int32(2)))
+		})
+
+		It("should not mark any device as shared without storage nodes", func() {
+			c := newClient(createLVDR("storage-1", operatorNS, []fusionv1alpha1.DiscoveredDevice{
+				{Path: "/dev/sdb", WWN: sharedWWN},
+			}))
+			r := &SharedDeviceInventoryReconciler{Client: c, Scheme: scheme}
+
+			_, err := r.Reconcile(ctx, ctrl.Request{})
+			Expect(err).NotTo(HaveOccurred())
+			status := getInventory(c).Status
+			Expect(status.StorageNodes).To(BeEmpty())
+			Expect(status.Devices).To(HaveLen(1))
+			Expect(status.Devices[0].Shared).To(BeFalse())
+		})
+	})
+
+	Describe("validateDevicesInInventory", func() {
+		var status *fusionv1alpha1.SharedDeviceInventoryStatus
+
+		BeforeEach(func() {
+			status = aggregateSharedDevices([]string{"storage-1", "storage-2"}, []fusionv1alpha1.LocalVolumeDiscoveryResult{
+				*createLVDR("storage-1", operatorNS, []fusionv1alpha1.DiscoveredDevice{
+					{Path: "/dev/sdb", DeviceID: "/dev/disk/by-id/wwn-0x1111", WWN: sharedWWN},
+					{Path: "/dev/sdc", WWN: localWWN},
+				}),
+				*createLVDR("storage-2", operatorNS, []fusionv1alpha1.DiscoveredDevice{
+					{Path: "/dev/sdc", DeviceID: "/dev/disk/by-id/wwn-0x1111", WWN: sharedWWN},
+				}),
+			}, nil)
+		})
+
+		It("should accept WWNs and by-id paths seen by every storage node", func() {
+			Expect(validateDevicesInInventory(status, []string{sharedWWN})).To(Succeed())
+			Expect(validateDevicesInInventory(status, []string{"/dev/disk/by-id/wwn-0x1111"})).To(Succeed())
+		})
+
+		It("should reject devices that are not seen by every storage node", func() {
+			Expect(validateDevicesInInventory(status, []string{localWWN})).To(
+				MatchError("device " + localWWN + " is not seen by node storage-2"))
+			// /dev/sdb is the shared disk on storage-1 only
+			Expect(validateDevicesInInventory(status, []string{"/dev/sdb"})).To(
+				MatchError("device /dev/sdb is not seen by node storage-2"))
+			Expect(validateDevicesInInventory(status, []string{"/dev/sdz"})).To(
+				MatchError("device /dev/sdz not found in SharedDeviceInventory"))
+		})
+
+		It("should reject two references to the same device", func() {
+			Expect(validateDevicesInInventory(status, []string{sharedWWN, "/dev/disk/by-id/wwn-0x1111"})).To(
+				MatchError(ContainSubstring("refer to the same disk")))
+		})
+	})
+
+	Describe("validateDevices", func() {
+		now := metav1.Now()
+
+		It("should accept the devices confirmed by the inventory", func() {
+			inventory := &fusionv1alpha1.SharedDeviceInventory{
+				ObjectMeta: metav1.ObjectMeta{Name: fusionv1alpha1.SharedDeviceInventoryName},
+				Status: fusionv1alpha1.SharedDeviceInventoryStatus{
+					StorageNodes: []string{"storage-1"},
+					Devices: []fusionv1alpha1.SharedDevice{{
+						WWN:    sharedWWN,
+						Nodes:  []fusionv1alpha1.DeviceNodePath{{Node: "storage-1", Path: "/dev/sdb"}},
+						Shared: true,
+					}},
+					LastUpdateTime: &now,
+				},
+			}
+			// No discovery result is needed when the inventory confirms the devices
+			r := &FileSystemClaimReconciler{Client: newClient(inventory, createStorageNode("storage-1")), Scheme: scheme}
+			Expect(r.validateDevices(ctx, []string{sharedWWN})).To(Succeed())
+		})
+
+		It("should reject right away the devices used by Storage Scale", func() {
+			inventory := &fusionv1alpha1.SharedDeviceInventory{
+				ObjectMeta: metav1.ObjectMeta{Name: fusionv1alpha1.SharedDeviceInventoryName},
+				Status: fusionv1alpha1.SharedDeviceInventoryStatus{
+					StorageNodes: []string{"storage-1", "storage-2"},
+					Devices: []fusionv1alpha1.SharedDevice{{
+						WWN: sharedWWN,
+						Nodes: []fusionv1alpha1.DeviceNodePath{
+							{Node: "storage-1", Path: "/dev/sdb"},
+							{Node: "storage-2", Path: "/dev/sdc"},
+						},
+						Shared: true,
+						UsedBy: &fusionv1alpha1.DeviceUsage{
+							LocalDisk:       sharedWWN,
+							Filesystem:      "fs1",
+							FileSystemClaim: "ibm-spectrum-scale/fsc-1",
+						},
+					}},
+					LastUpdateTime: &now,
+				},
+			}
+			r := &FileSystemClaimReconciler{Client: newClient(inventory), Scheme: scheme}
+			// /dev/sdc is the used disk on storage-2
+			Expect(r.validateDevices(ctx, []string{"/dev/sdc"})).To(MatchError("device /dev/sdc is already used by LocalDisk " +
+				sharedWWN + " of Filesystem fs1 (FileSystemClaim ibm-spectrum-scale/fsc-1)"))
+		})
+
+		It("should explain from the discovery results the devices the inventory does not confirm", func() {
+			inventory := &fusionv1alpha1.SharedDeviceInventory{
+				ObjectMeta: metav1.ObjectMeta{Name: fusionv1alpha1.SharedDeviceInventoryName},
+				Status: fusionv1alpha1.SharedDeviceInventoryStatus{
+					StorageNodes:   []string{"storage-1"},
+					LastUpdateTime: &now,
+				},
+			}
+			lvdr := createLVDR("storage-1", operatorNS, nil)
+			lvdr.Status.IgnoredDevices = []fusionv1alpha1.IgnoredDevice{{
+				Path:    "/dev/sdb",
+				Reason:  fusionv1alpha1.IgnoredMounted,
+				Message: "device is mounted",
+			}}
+			r := &FileSystemClaimReconciler{
+				Client: newClient(inventory, createStorageNode("storage-1"), lvdr),
+				Scheme: scheme,
+			}
+			Expect(r.validateDevices(ctx, []string{"/dev/sdb"})).To(
+				MatchError(ContainSubstring("device /dev/sdb is not usable on node storage-1")))
+		})
+	})
+})
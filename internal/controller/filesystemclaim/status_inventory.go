@@ -0,0 +1,426 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// FilesystemMountRoot is the directory under which IBM Storage Scale mounts the Filesystems on the storage nodes
+const FilesystemMountRoot = "/mnt"
+
+// fscInventory is the part of the FileSystemClaim status describing the owned resources
+type fscInventory struct {
+	devices          []fusionv1alpha1.DeviceInventory
+	filesystem       *fusionv1alpha1.FilesystemInventory
+	storageClassName string
+	capacity         *fusionv1alpha1.CapacityStatus
+}
+
+// syncStatusInventory records the owned LocalDisks, Filesystem, StorageClass and capacity in the
+// FileSystemClaim status together with the observed generation
+func (r *FileSystemClaimReconciler) syncStatusInventory(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	inventory, err := r.buildStatusInventory(ctx, fsc)
+	if err != nil {
+		return false, err
+	}
+
+	warnStatus, warnReason, warnMsg, hasWarning := capacityWarning(inventory.capacity, fsc.Spec.CapacityThresholds)
+	warningUnchanged := !hasWarning
+	if prev := apimeta.FindStatusCondition(fsc.Status.Conditions, fusionv1alpha1.ConditionTypeCapacityWarning); hasWarning && prev != nil {
+		warningUnchanged = prev.Status == warnStatus && prev.Reason == warnReason && prev.Message == warnMsg
+	}
+
+	if fsc.Status.ObservedGeneration == fsc.Generation &&
+		equality.Semantic.DeepEqual(fsc.Status.Devices, inventory.devices) &&
+		equality.Semantic.DeepEqual(fsc.Status.Filesystem, inventory.filesystem) &&
+		fsc.Status.StorageClassName == inventory.storageClassName &&
+		equality.Semantic.DeepEqual(fsc.Status.Capacity, inventory.capacity) &&
+		warningUnchanged {
+		return false, nil
+	}
+
+	if err := r.patchFSCStatus(ctx, fsc, func(cur *fusionv1alpha1.FileSystemClaim) {
+		cur.Status.ObservedGeneration = fsc.Generation
+		cur.Status.Devices = inventory.devices
+		cur.Status.Filesystem = inventory.filesystem
+		cur.Status.StorageClassName = inventory.storageClassName
+		cur.Status.Capacity = inventory.capacity
+		if hasWarning {
+			cur.Status.Conditions = utils.UpdateCondition(
+				cur.Status.Conditions, fusionv1alpha1.ConditionTypeCapacityWarning,
+				warnStatus, warnReason, warnMsg, cur.Generation,
+			)
+		}
+	}); err != nil {
+		return false, fmt.Errorf("failed to update status inventory: %w", err)
+	}
+	if hasWarning {
+		r.reportConditionEvent(fsc, fusionv1alpha1.ConditionTypeCapacityWarning, warnStatus, warnReason, warnMsg)
+	}
+
+	logger.Info("Updated FileSystemClaim status inventory", "devices", len(inventory.devices), "storageClass", inventory.storageClassName)
+	return true, nil
+}
+
+// buildStatusInventory collects the inventory from the owned LocalDisks and Filesystem and from the
+// PersistentVolumes provisioned from the StorageClass
+func (r *FileSystemClaimReconciler) buildStatusInventory(ctx context.Context, fsc *fusionv1alpha1.FileSystemClaim) (*fscInventory, error) {
+	ownedLDs, err := r.listOwnedResources(ctx, fsc, schema.GroupVersionKind{
+		Group:   LocalDiskGroup,
+		Version: LocalDiskVersion,
+		Kind:    LocalDiskKind,
+	}, LocalDiskList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned LocalDisks: %w", err)
+	}
+
+	ownedFilesystems, err := r.listOwnedResources(ctx, fsc, schema.GroupVersionKind{
+		Group:   FileSystemGroup,
+		Version: FileSystemVersion,
+		Kind:    FileSystemKind,
+	}, FileSystemList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned Filesystems: %w", err)
+	}
+
+	inventory := &fscInventory{}
+
+	ownedFS := map[string]struct{}{fileSystemName(fsc): {}}
+	if len(ownedFilesystems) > 0 {
+		name := ownedFilesystems[0].GetName()
+		ownedFS[name] = struct{}{}
+		inventory.filesystem = &fusionv1alpha1.FilesystemInventory{
+			Name:       name,
+			MountPoint: path.Join(FilesystemMountRoot, name),
+		}
+	}
+
+	var pools []fusionv1alpha1.PoolCapacity
+	if len(ownedFilesystems) > 0 {
+		pools = filesystemPoolCapacity(&ownedFilesystems[0])
+	}
+
+	var discovered map[string][]fusionv1alpha1.DiscoveredDevice
+	if len(ownedLDs) > 0 {
+		if discovered, err = r.discoveredDevicesByNode(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	total := resource.NewQuantity(0, resource.BinarySI)
+	for i := range ownedLDs {
+		device := r.localDiskInventory(&ownedLDs[i], ownedFS)
+		device.WWN = localDiskWWN(&ownedLDs[i], discovered)
+		if device.State == fusionv1alpha1.LocalDiskStateInUse && device.Size != nil {
+			total.Add(*device.Size)
+		}
+		inventory.devices = append(inventory.devices, device)
+	}
+	sortDeviceInventory(inventory.devices, claimDevices(fsc))
+
+	if apimeta.IsStatusConditionTrue(fsc.Status.Conditions, fusionv1alpha1.ConditionTypeStorageClassCreated) {
+		inventory.storageClassName = fusionv1alpha1.StorageClassNameOf(fsc)
+	}
+
+	if inventory.filesystem != nil {
+		// Prefer the pool sizes reported by the Filesystem over the LocalDisk sizes
+		var poolTotal *resource.Quantity
+		for _, pool := range pools {
+			if pool.Total == nil {
+				continue
+			}
+			if poolTotal == nil {
+				poolTotal = resource.NewQuantity(0, resource.BinarySI)
+			}
+			poolTotal.Add(*pool.Total)
+		}
+		if poolTotal != nil {
+			total = poolTotal
+		}
+		inventory.capacity = &fusionv1alpha1.CapacityStatus{Total: total, Pools: pools}
+
+		if inventory.storageClassName != "" {
+			used, err := r.provisionedCapacity(ctx, inventory.storageClassName)
+			if err != nil {
+				return nil, err
+			}
+			inventory.capacity.Used = used
+			if total.Value() > 0 {
+				percent := int32(used.Value() * 100 / total.Value())
+				inventory.capacity.UsedPercent = &percent
+			}
+		}
+	}
+
+	return inventory, nil
+}
+
+// localDiskInventory summarizes an owned LocalDisk. The state follows the same rules as the
+// LocalDiskCreated condition: a LocalDisk used by a Filesystem the claim does not own has failed.
+func (r *FileSystemClaimReconciler) localDiskInventory(ld *unstructured.Unstructured, ownedFS map[string]struct{}) fusionv1alpha1.DeviceInventory {
+	node, _, _ := unstructured.NestedString(ld.Object, "spec", "node")
+	pool, _, _ := unstructured.NestedString(ld.Object, "status", "pool")
+	sizeStr, _, _ := unstructured.NestedString(ld.Object, "status", "size")
+
+	device := fusionv1alpha1.DeviceInventory{
+		Device:    localDiskDevice(ld),
+		Node:      node,
+		LocalDisk: ld.GetName(),
+		Pool:      pool,
+	}
+	if size, ok := parseScaleSize(sizeStr); ok {
+		device.Size = size
+	}
+
+	healthy, _, msg, hardFailure := r.checkAllResourcesHealthy([]unstructured.Unstructured{*ld}, []string{"Ready", "Used"}, ownedFS)
+	switch {
+	case hardFailure:
+		device.State = fusionv1alpha1.LocalDiskStateFailed
+		device.Message = msg
+	case !healthy:
+		device.State = fusionv1alpha1.LocalDiskStatePending
+		device.Message = msg
+	default:
+		device.State = fusionv1alpha1.LocalDiskStateReady
+		if conds, err := extractResourceConditions(ld); err == nil && apimeta.IsStatusConditionTrue(conds, "Used") {
+			device.State = fusionv1alpha1.LocalDiskStateInUse
+		}
+	}
+	return device
+}
+
+// discoveredDevicesByNode returns the devices of the LocalVolumeDiscoveryResults per node
+func (r *FileSystemClaimReconciler) discoveredDevicesByNode(ctx context.Context) (map[string][]fusionv1alpha1.DiscoveredDevice, error) {
+	operatorNamespace, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator deployment namespace: %w", err)
+	}
+	lvdrs := &fusionv1alpha1.LocalVolumeDiscoveryResultList{}
+	if err := r.List(ctx, lvdrs, client.InNamespace(operatorNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list LocalVolumeDiscoveryResults: %w", err)
+	}
+	devices := make(map[string][]fusionv1alpha1.DiscoveredDevice, len(lvdrs.Items))
+	for i := range lvdrs.Items {
+		devices[lvdrs.Items[i].Spec.NodeName] = lvdrs.Items[i].Status.DiscoveredDevices
+	}
+	return devices, nil
+}
+
+// localDiskWWN resolves the WWN of the disk of a LocalDisk on its node the way it was created: from the
+// device reference, else from the kernel path. It is empty when discovery no longer reports the disk.
+func localDiskWWN(ld *unstructured.Unstructured, discovered map[string][]fusionv1alpha1.DiscoveredDevice) string {
+	node, _, _ := unstructured.NestedString(ld.Object, "spec", "node")
+	devicePath, _, _ := unstructured.NestedString(ld.Object, "spec", "device")
+	for _, ref := range []string{localDiskDevice(ld), devicePath} {
+		if ref == "" {
+			continue
+		}
+		if device := findDiscoveredDevice(discovered[node], ref); device != nil {
+			return device.WWN
+		}
+	}
+	return ""
+}
+
+// filesystemPoolCapacity reads the disk capacity of each pool from the Filesystem status. A replicated
+// Filesystem only reports the sizes of the failure groups of its pools, whose sum holds every replica.
+func filesystemPoolCapacity(fs *unstructured.Unstructured) []fusionv1alpha1.PoolCapacity {
+	statusPools, _, _ := unstructured.NestedSlice(fs.Object, "status", "pools")
+	replicas := filesystemReplicas(fs)
+
+	var pools []fusionv1alpha1.PoolCapacity
+	for _, item := range statusPools {
+		pool, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(pool, "name")
+		if name == "" {
+			continue
+		}
+		capacity := fusionv1alpha1.PoolCapacity{Name: name}
+		if size, _, _ := unstructured.NestedString(pool, "totalDiskSize"); size != "" {
+			if q, ok := parseScaleSize(size); ok {
+				capacity.Total = q
+			}
+		}
+		if disks, found, _ := unstructured.NestedInt64(pool, "diskCount"); found {
+			capacity.Disks = int32(disks)
+		}
+		if capacity.Total == nil {
+			capacity.Total, capacity.Disks = failureGroupsCapacity(pool, replicas)
+		}
+		pools = append(pools, capacity)
+	}
+	return pools
+}
+
+// failureGroupsCapacity sums the disk sizes and counts of the failure groups of a replicated pool. The total
+// is divided by the number of replicas: it is the capacity usable by the PersistentVolumes.
+func failureGroupsCapacity(pool map[string]any, replicas int64) (*resource.Quantity, int32) {
+	groups, _, _ := unstructured.NestedSlice(pool, "failureGroups")
+	var total *resource.Quantity
+	var disks int32
+	for _, item := range groups {
+		group, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if count, found, _ := unstructured.NestedInt64(group, "diskCount"); found {
+			disks += int32(count)
+		}
+		size, _, _ := unstructured.NestedString(group, "totalDiskSize")
+		if q, ok := parseScaleSize(size); ok {
+			if total == nil {
+				total = resource.NewQuantity(0, resource.BinarySI)
+			}
+			total.Add(*q)
+		}
+	}
+	if total != nil {
+		total = resource.NewQuantity(total.Value()/replicas, resource.BinarySI)
+	}
+	return total, disks
+}
+
+// filesystemReplicas returns the number of replicas of the spec.local.replication of a Filesystem, e.g. 2
+// for "2-way"
+func filesystemReplicas(fs *unstructured.Unstructured) int64 {
+	replication, _, _ := unstructured.NestedString(fs.Object, "spec", "local", "replication")
+	replicas, err := strconv.ParseInt(strings.TrimSuffix(replication, "-way"), 10, 64)
+	if err != nil || replicas < 1 {
+		return 1
+	}
+	return replicas
+}
+
+// capacityWarning returns the CapacityWarning condition for the used capacity. ok is false while the
+// used capacity is unknown, e.g. before the StorageClass is created.
+func capacityWarning(
+	capacity *fusionv1alpha1.CapacityStatus,
+	thresholds *fusionv1alpha1.CapacityThresholds,
+) (status metav1.ConditionStatus, reason, message string, ok bool) {
+	if capacity == nil || capacity.UsedPercent == nil {
+		return "", "", "", false
+	}
+
+	warning, critical := int32(fusionv1alpha1.DefaultCapacityWarningPercent), int32(fusionv1alpha1.DefaultCapacityCriticalPercent)
+	if thresholds != nil {
+		if thresholds.WarningPercent != nil {
+			warning = *thresholds.WarningPercent
+		}
+		if thresholds.CriticalPercent != nil {
+			critical = *thresholds.CriticalPercent
+		}
+	}
+
+	used := *capacity.UsedPercent
+	switch {
+	case used >= critical:
+		return metav1.ConditionTrue, ReasonCapacityCriticalExceeded,
+			fmt.Sprintf("%d%% of the capacity is used, critical threshold is %d%%", used, critical), true
+	case used >= warning:
+		return metav1.ConditionTrue, ReasonCapacityWarningExceeded,
+			fmt.Sprintf("%d%% of the capacity is used, warning threshold is %d%%", used, warning), true
+	default:
+		return metav1.ConditionFalse, ReasonCapacityWithinThresholds,
+			fmt.Sprintf("%d%% of the capacity is used, warning threshold is %d%%", used, warning), true
+	}
+}
+
+// provisionedCapacity sums the capacity of the PersistentVolumes provisioned from the StorageClass
+func (r *FileSystemClaimReconciler) provisionedCapacity(ctx context.Context, scName string) (*resource.Quantity, error) {
+	var pvList corev1.PersistentVolumeList
+	if err := r.List(ctx, &pvList); err != nil {
+		return nil, fmt.Errorf("failed to list PersistentVolumes: %w", err)
+	}
+
+	used := resource.NewQuantity(0, resource.BinarySI)
+	for i := range pvList.Items {
+		pv := &pvList.Items[i]
+		if pv.Spec.StorageClassName != scName || pv.DeletionTimestamp != nil {
+			continue
+		}
+		if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+			used.Add(capacity)
+		}
+	}
+	return used, nil
+}
+
+// sortDeviceInventory orders the inventory like the devices of the claim. LocalDisks whose device is no
+// longer listed, such as devices being removed, come last ordered by name.
+func sortDeviceInventory(devices []fusionv1alpha1.DeviceInventory, order []string) {
+	index := func(device string) int {
+		if i := slices.Index(order, device); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	sort.SliceStable(devices, func(a, b int) bool {
+		ia, ib := index(devices[a].Device), index(devices[b].Device)
+		if ia != ib {
+			return ia < ib
+		}
+		return devices[a].LocalDisk < devices[b].LocalDisk
+	})
+}
+
+// parseScaleSize parses a size reported by IBM Storage Scale such as "1.5 TiB" or "100 GB"
+func parseScaleSize(s string) (*resource.Quantity, bool) {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	if s == "" {
+		return nil, false
+	}
+
+	// Kubernetes quantities use Ki/Mi/Gi and k/M/G suffixes without the trailing B
+	switch {
+	case strings.HasSuffix(s, "iB"):
+		s = strings.TrimSuffix(s, "B")
+	case strings.HasSuffix(s, "KB"):
+		s = strings.TrimSuffix(s, "KB") + "k"
+	case strings.HasSuffix(s, "B"):
+		s = strings.TrimSuffix(s, "B")
+	}
+
+	q, err := resource.ParseQuantity(s)
+	if err != nil {
+		return nil, false
+	}
+	return &q, true
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystemclaim
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("Status inventory", func() {
+	var (
+		ctx        context.Context
+		scheme     *runtime.Scheme
+		namespace  = "ibm-spectrum-scale"
+		fsc        *fusionv1alpha1.FileSystemClaim
+		fs         *unstructured.Unstructured
+		fakeClient client.Client
+		reconciler *FileSystemClaimReconciler
+	)
+
+	// localDisk returns an owned LocalDisk with the given Used condition and status.filesystem
+	localDisk := func(name, device, node string, used bool, filesystem string) *unstructured.Unstructured {
+		ld := createLocalDiskWithOwner(name, namespace, device, node, fsc)
+		usedStatus := "False"
+		if used {
+			usedStatus = "True"
+		}
+		ld.Object["status"] = map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "True", "reason": "Ready", "message": ""},
+				map[string]any{"type": "Used", "status": usedStatus, "reason": "Used", "message": ""},
+			},
+			"filesystem": filesystem,
+			"pool":       "system",
+			"size":       "100 GiB",
+		}
+		return ld
+	}
+
+	pv := func(name, scName, size string) *corev1.PersistentVolume {
+		return &corev1.PersistentVolume{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PersistentVolumeSpec{
+				StorageClassName: scName,
+				Capacity:         corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		}
+	}
+
+	buildClient := func(objs ...client.Object) {
+		reconciler = newFakeReconciler(scheme, append([]client.Object{fsc}, objs...)...)
+		fakeClient = reconciler.Client
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		GinkgoT().Setenv("DEPLOYMENT_NAMESPACE", "test-operator-ns")
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(fusionv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		fsc = createTestFSC("test-fsc", namespace, []string{"/dev/sdb", "/dev/sdc"}, []metav1.Condition{
+			localDiskCreatedCondition(metav1.ConditionTrue, ReasonLocalDiskCreationSucceeded),
+			filesystemCreatedCondition(metav1.ConditionTrue, ReasonFileSystemCreationSucceeded),
+			storageClassCreatedCondition(metav1.ConditionTrue, ReasonStorageClassCreationSucceeded),
+		})
+		fsc.Generation = 3
+
+		fs = createV1Filesystem(fsc.Name, namespace)
+		fs.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: "fusion.storage.openshift.io/v1alpha1",
+			Kind:       FileSystemClaimKind,
+			Name:       fsc.Name,
+		}})
+	})
+
+	It("should record the owned resources, capacity and observed generation", func() {
+		buildClient(
+			fs,
+			localDisk("wwn-c", "/dev/sdc", "storage-node-2", true, fsc.Name),
+			localDisk("data-disk-b", "/dev/sdb", "storage-node-1", true, fsc.Name),
+			createLVDR("storage-node-1", "test-operator-ns", []fusionv1alpha1.DiscoveredDevice{
+				{Path: "/dev/sdb", WWN: "0x6005076810810261f800000000000a1b"},
+			}),
+			pv("pv-1", fsc.Name, "20Gi"),
+			pv("pv-2", fsc.Name, "30Gi"),
+			pv("pv-other", "other-sc", "500Gi"),
+		)
+
+		changed, err := reconciler.syncStatusInventory(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		updated := getFSC(ctx, fakeClient, fsc)
+		Expect(updated.Status.ObservedGeneration).To(Equal(int64(3)))
+		Expect(updated.Status.StorageClassName).To(Equal(fsc.Name))
+		Expect(updated.Status.Filesystem).To(Equal(&fusionv1alpha1.FilesystemInventory{
+			Name:       fsc.Name,
+			MountPoint: "/mnt/" + fsc.Name,
+		}))
+
+		Expect(updated.Status.Devices).To(HaveLen(2))
+		Expect(updated.Status.Devices[0].Device).To(Equal("/dev/sdb"))
+		Expect(updated.Status.Devices[0].WWN).To(Equal("0x6005076810810261f800000000000a1b"))
+		Expect(updated.Status.Devices[0].LocalDisk).To(Equal("data-disk-b"))
+		Expect(updated.Status.Devices[0].Node).To(Equal("storage-node-1"))
+		Expect(updated.Status.Devices[0].Pool).To(Equal("system"))
+		Expect(updated.Status.Devices[0].State).To(Equal(fusionv1alpha1.LocalDiskStateInUse))
+		Expect(updated.Status.Devices[0].Size.Cmp(resource.MustParse("100Gi"))).To(Equal(0))
+		Expect(updated.Status.Devices[1].Device).To(Equal("/dev/sdc"))
+		// No discovery result reports the disk of the other node
+		Expect(updated.Status.Devices[1].WWN).To(BeEmpty())
+
+		Expect(updated.Status.Capacity).NotTo(BeNil())
+		Expect(updated.Status.Capacity.Total.Cmp(resource.MustParse("200Gi"))).To(Equal(0))
+		Expect(updated.Status.Capacity.Used.Cmp(resource.MustParse("50Gi"))).To(Equal(0))
+
+		// A second sync with the same resources does not write status again
+		changed, err = reconciler.syncStatusInventory(ctx, updated)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeFalse())
+	})
+
+	It("should report the state of LocalDisks that are not in use", func() {
+		fsc.Status.Conditions = []metav1.Condition{
+			localDiskCreatedCondition(metav1.ConditionFalse, ReasonLocalDiskCreationFailed),
+		}
+		buildClient(
+			localDisk("wwn-b", "/dev/sdb", "storage-node-1", false, ""),
+			localDisk("wwn-c", "/dev/sdc", "storage-node-1", true, "other-fs"),
+		)
+
+		_, err := reconciler.syncStatusInventory(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := getFSC(ctx, fakeClient, fsc)
+		Expect(updated.Status.Devices).To(HaveLen(2))
+		Expect(updated.Status.Devices[0].State).To(Equal(fusionv1alpha1.LocalDiskStateReady))
+		Expect(updated.Status.Devices[1].State).To(Equal(fusionv1alpha1.LocalDiskStateFailed))
+		Expect(updated.Status.Devices[1].Message).To(ContainSubstring("other-fs"))
+
+		// No Filesystem and no StorageClass yet
+		Expect(updated.Status.Filesystem).To(BeNil())
+		Expect(updated.Status.StorageClassName).To(BeEmpty())
+		Expect(updated.Status.Capacity).To(BeNil())
+	})
+
+	It("should use the pool sizes of the Filesystem and raise CapacityWarning", func() {
+		Expect(unstructured.SetNestedSlice(fs.Object, []any{
+			map[string]any{"name": "system", "totalDiskSize": "100 GiB", "diskCount": int64(1)},
+			map[string]any{"name": "data", "totalDiskSize": "300 GiB", "diskCount": int64(3)},
+		}, "status", "pools")).To(Succeed())
+		fsc.Spec.CapacityThresholds = &fusionv1alpha1.CapacityThresholds{WarningPercent: ptr.To[int32](50)}
+		buildClient(
+			fs,
+			localDisk("wwn-b", "/dev/sdb", "storage-node-1", true, fsc.Name),
+			pv("pv-1", fsc.Name, "240Gi"),
+		)
+
+		_, err := reconciler.syncStatusInventory(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := getFSC(ctx, fakeClient, fsc)
+		Expect(updated.Status.Capacity.Total.Cmp(resource.MustParse("400Gi"))).To(Equal(0))
+		Expect(updated.Status.Capacity.Pools).To(HaveLen(2))
+		Expect(updated.Status.Capacity.Pools[1].Name).To(Equal("data"))
+		Expect(updated.Status.Capacity.Pools[1].Disks).To(Equal(int32(3)))
+		Expect(*updated.Status.Capacity.UsedPercent).To(Equal(int32(60)))
+
+		cond := findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeCapacityWarning)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(ReasonCapacityWarningExceeded))
+	})
+
+	It("should use the failure group sizes of a 2-way replicated Filesystem", func() {
+		Expect(unstructured.SetNestedField(fs.Object, "2-way", "spec", "local", "replication")).To(Succeed())
+		Expect(unstructured.SetNestedSlice(fs.Object, []any{
+			map[string]any{"name": "system", "failureGroups": []any{
+				map[string]any{"failureGroup": "1", "totalDiskSize": "200 GiB", "diskCount": int64(2)},
+				map[string]any{"failureGroup": "2", "totalDiskSize": "200 GiB", "diskCount": int64(2)},
+			}},
+		}, "status", "pools")).To(Succeed())
+		buildClient(
+			fs,
+			localDisk("wwn-b", "/dev/sdb", "storage-node-1", true, fsc.Name),
+			pv("pv-1", fsc.Name, "170Gi"),
+		)
+
+		_, err := reconciler.syncStatusInventory(ctx, fsc)
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := getFSC(ctx, fakeClient, fsc)
+		Expect(updated.Status.Capacity.Total.Cmp(resource.MustParse("200Gi"))).To(Equal(0))
+		Expect(updated.Status.Capacity.Pools).To(HaveLen(1))
+		Expect(updated.Status.Capacity.Pools[0].Disks).To(Equal(int32(4)))
+		Expect(*updated.Status.Capacity.UsedPercent).To(Equal(int32(85)))
+
+		cond := findCondition(updated.Status.Conditions, fusionv1alpha1.ConditionTypeCapacityWarning)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(ReasonCapacityWarningExceeded))
+	})
+
+	DescribeTable("capacityWarning",
+		func(usedPercent *int32, thresholds *fusionv1alpha1.CapacityThresholds, status metav1.ConditionStatus, reason string) {
+			var capacity *fusionv1alpha1.CapacityStatus
+			if usedPercent != nil {
+				capacity = &fusionv1alpha1.CapacityStatus{UsedPercent: usedPercent}
+			}
+			gotStatus, gotReason, _, ok := capacityWarning(capacity, thresholds)
+			if reason == "" {
+				Expect(ok).To(BeFalse())
+				return
+			}
+			Expect(ok).To(BeTrue())
+			Expect(gotStatus).To(Equal(status))
+			Expect(gotReason).To(Equal(reason))
+		},
+		Entry("unknown usage", nil, nil, metav1.ConditionStatus(""), ""),
+		Entry("below the default warning threshold", ptr.To[int32](79), nil, metav1.ConditionFalse, ReasonCapacityWithinThresholds),
+		Entry("at the default warning threshold", ptr.To[int32](80), nil, metav1.ConditionTrue, ReasonCapacityWarningExceeded),
+		Entry("at the default critical threshold", ptr.To[int32](95), nil, metav1.ConditionTrue, ReasonCapacityCriticalExceeded),
+		Entry("overcommitted", ptr.To[int32](130), nil, metav1.ConditionTrue, ReasonCapacityCriticalExceeded),
+		Entry("custom thresholds", ptr.To[int32](65),
+			&fusionv1alpha1.CapacityThresholds{WarningPercent: ptr.To[int32](50), CriticalPercent: ptr.To[int32](60)},
+			metav1.ConditionTrue, ReasonCapacityCriticalExceeded),
+	)
+
+	DescribeTable("parseScaleSize",
+		func(size, expected string) {
+			q, ok := parseScaleSize(size)
+			if expected == "" {
+				Expect(ok).To(BeFalse())
+				return
+			}
+			Expect(ok).To(BeTrue())
+			Expect(q.Cmp(resource.MustParse(expected))).To(Equal(0))
+		},
+		Entry("binary unit", "100 GiB", "100Gi"),
+		Entry("fractional binary unit", "1.5 TiB", "1.5Ti"),
+		Entry("decimal unit", "100 GB", "100G"),
+		Entry("kilobytes", "512 KB", "512k"),
+		Entry("bytes", "4096 B", "4096"),
+		Entry("kubernetes quantity", "10Gi", "10Gi"),
+		Entry("empty", "", ""),
+		Entry("garbage", "unknown", ""),
+	)
+})
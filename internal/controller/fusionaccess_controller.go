@@ -21,11 +21,12 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"time"
 
-	mfc "github.com/manifestival/controller-runtime-client"
 	"github.com/manifestival/manifestival"
 	buildv1 "github.com/openshift/api/build/v1"
+	kmmv1beta1 "github.com/rh-ecosystem-edge/kernel-module-management/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 
@@ -34,6 +35,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,6 +50,9 @@ import (
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/imageregistry"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/localvolumediscovery"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/manifest"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/events"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/metrics"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
 )
 
@@ -57,6 +62,29 @@ const (
 	moduleBuildTimeLimit     = 30
 )
 
+// Event topics and reasons of the FusionAccess events
+const (
+	eventTopicVersion              = "StorageScaleVersion"
+	eventTopicManifestApply        = "ManifestApply"
+	eventTopicImageRegistryStorage = "ImageRegistryStorage"
+	eventTopicKernelModule         = "KernelModule"
+	eventTopicConsolePlugin        = "ConsolePlugin"
+	eventTopicImagePull            = "ImagePull"
+	eventTopicStatus               = "Status"
+
+	EventReasonStorageScaleVersionUpdated = "StorageScaleVersionUpdated"
+	EventReasonManifestApplied            = "ManifestApplied"
+	EventReasonManifestApplyFailed        = "ManifestApplyFailed"
+	EventReasonValidStorageBackend        = "ValidStorageBackend"
+	EventReasonInvalidStorageBackend      = "InvalidStorageBackend"
+	EventReasonKernelModuleCreated        = "KernelModuleResourcesCreated"
+	EventReasonKernelModuleFailed         = "KernelModuleResourcesFailed"
+	EventReasonConsolePluginFailed        = "ConsolePluginFailed"
+	EventReasonImagePullSucceeded         = "ImagePullSucceeded"
+	EventReasonImagePullFailed            = "ImagePullFailed"
+	EventReasonReady                      = "Ready"
+)
+
 type CanPullImageFunc func(ctx context.Context, client client.Client, ns, image, pullSecret string) (bool, error)
 
 // FusionAccessReconciler reconciles a FusionAccess object
@@ -65,20 +93,37 @@ type FusionAccessReconciler struct {
 	Scheme *runtime.Scheme
 	// Need this for mocking when needed
 	CanPullImage CanPullImageFunc
+	// Recorder emits the events listed by `oc describe fusionaccess`
+	Recorder record.EventRecorder
+	// APIReader reads the objects of the install manifest without going through the cache
+	APIReader client.Reader
+
+	events *events.Reporter
 }
 
 func NewFusionAccessReconciler(
 	myClient client.Client,
+	apiReader client.Reader,
 	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
 ) *FusionAccessReconciler {
 	return &FusionAccessReconciler{
 		Client:       myClient,
+		APIReader:    apiReader,
 		Scheme:       scheme,
 		CanPullImage: utils.CanPullImage,
+		Recorder:     recorder,
+		events:       events.NewReporter(recorder),
 	}
 }
 
-// const storageScaleFinalizer = "fusion.storage.openshift.io/finalizer"
+// apiReader returns the uncached reader, falling back to the client when none was set
+func (r *FusionAccessReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
 
 // Basic Operator RBACs
 //+kubebuilder:rbac:groups=fusion.storage.openshift.io,resources=fusionaccesses,verbs=get;list;watch;create;update;patch;delete
@@ -298,36 +343,62 @@ func (r *FusionAccessReconciler) Reconcile(
 		return ctrl.Result{}, err
 	}
 
+	if !fusionaccess.DeletionTimestamp.IsZero() {
+		return r.handleTeardown(ctx, fusionaccess, ns)
+	}
+	if changed, err := r.ensureTeardownFinalizer(ctx, fusionaccess); err != nil {
+		return ctrl.Result{}, err
+	} else if changed {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
 	cnsaVersion, installPath, err := getIbmManifest(fusionaccess.Spec)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if err := r.updateSupportedStorageScaleVersions(ctx, fusionaccess); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if cnsaVersion != "" && string(fusionaccess.Spec.StorageScaleVersion) != cnsaVersion {
-		return r.updateStorageScaleVersion(ctx, fusionaccess, cnsaVersion)
+		// A fresh install picks the bundled version, an installed one goes through the upgrade checks
+		if fusionaccess.Spec.StorageScaleVersion == "" {
+			return r.updateStorageScaleVersion(ctx, fusionaccess, cnsaVersion)
+		}
+		return r.reconcileUpgrade(ctx, fusionaccess, ns, cnsaVersion, installPath)
 	}
 
-	installManifest, err := manifestival.NewManifest(
-		installPath,
-		manifestival.UseClient(mfc.NewClient(r.Client)),
-	)
+	installManifest, err := loadIbmManifest(fusionaccess.Spec, installPath)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 	log.Log.Info(fmt.Sprintf("Applying manifest from %s", installPath))
 
-	if err := installManifest.Apply(); err != nil {
+	result, err := manifest.Apply(ctx, r.Client, r.apiReader(), ns, cnsaVersion, installManifest.Resources())
+	if err == nil {
+		err = result.Err()
+	}
+	metrics.RecordManifestApply(err)
+	setManifestApplyStatus(fusionaccess, cnsaVersion, result)
+	if err != nil {
 		log.Log.Error(err, "Error applying manifest")
+		r.events.Report(events.NewEvent(eventTopicManifestApply, EventReasonManifestApplyFailed,
+			fmt.Sprintf("Storage Scale manifest apply from %s failed: %v", installPath, err)), fusionaccess)
 		fusionaccess.Status.Status = "Error"
 		meta.SetStatusCondition(&fusionaccess.Status.Conditions,
-			v1.Condition{Type: "ManifestApply", Status: v1.ConditionFalse, Reason: "ReconcileCompleted", Message: "Storage Scale manifest apply failed"})
+			v1.Condition{Type: "ManifestApply", Status: v1.ConditionFalse, Reason: "ReconcileCompleted",
+				Message: fmt.Sprintf("Storage Scale manifest apply failed: %v", err)})
 		serr := r.Status().Update(ctx, fusionaccess)
 		if serr != nil {
 			return ctrl.Result{}, errors.Join(serr, err)
 		}
 		return ctrl.Result{}, err
 	}
-	log.Log.Info(fmt.Sprintf("Applied manifest from %s", installPath))
+	log.Log.Info(fmt.Sprintf("Applied manifest from %s", installPath),
+		"applied", result.Applied, "unchanged", result.Unchanged, "pruned", result.Pruned)
+	r.events.Report(events.NewSuccessEvent(eventTopicManifestApply, EventReasonManifestApplied,
+		fmt.Sprintf("Storage Scale manifest from %s was applied", installPath)), fusionaccess)
 	meta.SetStatusCondition(&fusionaccess.Status.Conditions,
 		v1.Condition{Type: "ManifestApply", Status: v1.ConditionTrue, Reason: "ReconcileCompleted", Message: "Storage Scale manifest was applied"})
 	serr := r.Status().Update(ctx, fusionaccess)
@@ -339,6 +410,9 @@ func (r *FusionAccessReconciler) Reconcile(
 	if pdbreq != nil || err != nil {
 		return *pdbreq, err
 	}
+	if err := r.syncUpgradeProgress(ctx, fusionaccess); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	// We try and create the entitlement secrets only if we found the "fusion-pullsecret" in our namespace
 	// If we don't find it, we don't create the entitlement secrets and we keep going as a user might be
@@ -367,6 +441,7 @@ func (r *FusionAccessReconciler) Reconcile(
 		if usingInternalRegistry {
 			log.Log.Info("Using internal image registry, validating storage configuration")
 			if err := imageregistry.CheckImageRegistryStorage(ctx, r.Client); err != nil {
+				r.events.Report(events.NewEvent(eventTopicImageRegistryStorage, EventReasonInvalidStorageBackend, err.Error()), fusionaccess)
 				fusionaccess.Status.Status = "Error"
 				meta.SetStatusCondition(&fusionaccess.Status.Conditions,
 					v1.Condition{Type: "ImageRegistryStorage", Status: v1.ConditionFalse, Reason: "InvalidStorageBackend", Message: err.Error()})
@@ -377,6 +452,8 @@ func (r *FusionAccessReconciler) Reconcile(
 				return ctrl.Result{}, err
 			}
 			log.Log.Info("Image registry storage validation passed")
+			r.events.Report(events.NewSuccessEvent(eventTopicImageRegistryStorage, EventReasonValidStorageBackend,
+				"Image registry is using a supported storage backend"), fusionaccess)
 			meta.SetStatusCondition(&fusionaccess.Status.Conditions,
 				v1.Condition{Type: "ImageRegistryStorage", Status: v1.ConditionTrue, Reason: "ValidStorageBackend", Message: "Image registry is using a supported storage backend"})
 			serr := r.Status().Update(ctx, fusionaccess)
@@ -390,17 +467,24 @@ func (r *FusionAccessReconciler) Reconcile(
 		// Since the kernel module requires the pull secret, we only create that if the secret is found
 		log.Log.Info("Creating kernel module resources")
 		if err := kernelmodule.CreateOrUpdateKMMResources(ctx, r.Client); err != nil {
+			r.events.Report(events.NewEvent(eventTopicKernelModule, EventReasonKernelModuleFailed, err.Error()), fusionaccess)
 			return ctrl.Result{}, err
 		}
 
 		log.Log.Info("Successfully created kernel module resources")
+		r.events.Report(events.NewSuccessEvent(eventTopicKernelModule, EventReasonKernelModuleCreated,
+			"Kernel module resources were created"), fusionaccess)
 	}
+	r.recordKMMMetrics(ctx, ns)
+
 	if err := console.CreateOrUpdatePlugin(ctx, r.Client); err != nil {
+		r.events.Report(events.NewEvent(eventTopicConsolePlugin, EventReasonConsolePluginFailed, err.Error()), fusionaccess)
 		return ctrl.Result{}, err
 	}
 	log.Log.Info("Successfully created / updated console plugin resources")
 
 	if err := console.EnablePlugin(ctx, r.Client); err != nil {
+		r.events.Report(events.NewEvent(eventTopicConsolePlugin, EventReasonConsolePluginFailed, err.Error()), fusionaccess)
 		return ctrl.Result{}, err
 	}
 	log.Log.Info("Successfully enabled console plugin")
@@ -408,8 +492,9 @@ func (r *FusionAccessReconciler) Reconcile(
 	// Check if can pull the image if we have not already or if it failed previously
 	// Only do this check if we have a set cnsa version
 	if fusionaccess.Spec.StorageScaleVersion != "" {
-		err = r.runPullImageCheck(ctx, ns)
+		err = r.runPullImageCheck(ctx, ns, string(fusionaccess.Spec.StorageScaleVersion))
 		if err != nil {
+			r.events.Report(events.NewEvent(eventTopicImagePull, EventReasonImagePullFailed, "Protected images can't be pulled"), fusionaccess)
 			fusionaccess.Status.Status = "ErrImagePull"
 			meta.SetStatusCondition(&fusionaccess.Status.Conditions,
 				v1.Condition{Type: "ImagePull", Status: v1.ConditionFalse, Reason: "ImagePullDone", Message: "Protected images can't be pulled"})
@@ -419,6 +504,7 @@ func (r *FusionAccessReconciler) Reconcile(
 			}
 			return ctrl.Result{}, err
 		}
+		r.events.Report(events.NewSuccessEvent(eventTopicImagePull, EventReasonImagePullSucceeded, "Protected images pulled successfully"), fusionaccess)
 		fusionaccess.Status.Status = ""
 		meta.SetStatusCondition(&fusionaccess.Status.Conditions,
 			v1.Condition{Type: "ImagePull", Status: v1.ConditionTrue, Reason: "ImagePullDone", Message: "Protected images pulled successfully"})
@@ -433,6 +519,8 @@ func (r *FusionAccessReconciler) Reconcile(
 	if fusionaccess.Spec.LocalVolumeDiscovery.Create {
 		// Create Device discovery
 		lvd := localvolumediscovery.NewLocalVolumeDiscovery(ns)
+		lvd.Spec.InclusionSpec = fusionaccess.Spec.LocalVolumeDiscovery.InclusionSpec
+		lvd.Spec.ExclusionSpec = fusionaccess.Spec.LocalVolumeDiscovery.ExclusionSpec
 		if err := localvolumediscovery.CreateOrUpdateLocalVolumeDiscovery(ctx, lvd, r.Client); err != nil {
 			return ctrl.Result{}, err
 		}
@@ -443,7 +531,9 @@ func (r *FusionAccessReconciler) Reconcile(
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+	r.events.Report(events.NewSuccessEvent(eventTopicStatus, EventReasonReady, "Storage Scale is installed"), fusionaccess)
+	// Come back to apply the manifest again, reverting the changes made by hand to its objects
+	return ctrl.Result{RequeueAfter: manifest.ResyncPeriod}, nil
 }
 
 func (r *FusionAccessReconciler) updateStorageScaleVersion(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, version string) (reconcile.Result, error) {
@@ -461,10 +551,45 @@ func (r *FusionAccessReconciler) updateStorageScaleVersion(ctx context.Context,
 		return ctrl.Result{}, err
 	}
 	log.Log.Info("Successfully updated FusionAccess storageScaleVersion")
+	r.events.Report(events.NewSuccessEvent(eventTopicVersion, EventReasonStorageScaleVersionUpdated,
+		fmt.Sprintf("storageScaleVersion set to %s", version)), fusionaccess)
 	// Requeue to apply the manifest with the updated version
 	return ctrl.Result{Requeue: true}, nil
 }
 
+// setManifestApplyStatus reports the objects applied, skipped, pruned and failed per kind
+func setManifestApplyStatus(fusionaccess *fusionv1alpha1.FusionAccess, version string, result manifest.Result) {
+	now := v1.Now()
+	status := &fusionv1alpha1.ManifestApplyStatus{
+		Version:       version,
+		Applied:       int32(result.Applied),
+		Unchanged:     int32(result.Unchanged),
+		Pruned:        int32(result.Pruned),
+		LastApplyTime: &now,
+	}
+	for _, failure := range result.Failures {
+		status.Failures = append(status.Failures, fusionv1alpha1.ManifestApplyFailure{
+			Kind:    failure.Kind,
+			Objects: failure.Objects,
+			Message: failure.Message,
+		})
+	}
+	fusionaccess.Status.ManifestApply = status
+}
+
+// updateSupportedStorageScaleVersions reports the CNSA versions bundled with the operator
+func (r *FusionAccessReconciler) updateSupportedStorageScaleVersions(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) error {
+	versions, err := utils.GetBundledStorageScaleVersions()
+	if err != nil {
+		return err
+	}
+	if slices.Equal(fusionaccess.Status.SupportedStorageScaleVersions, versions) {
+		return nil
+	}
+	fusionaccess.Status.SupportedStorageScaleVersions = versions
+	return r.Status().Update(ctx, fusionaccess)
+}
+
 func isBuildUpdateOrDelete() builder.WatchesOption {
 	return builder.WithPredicates(predicate.Funcs{
 		CreateFunc: func(_ event.CreateEvent) bool {
@@ -558,13 +683,15 @@ func (r *FusionAccessReconciler) fusionAccessHandler(
 	return []reconcile.Request{req}
 }
 
-func (r *FusionAccessReconciler) runPullImageCheck(ctx context.Context, ns string) error {
-	testImage, err := utils.GetExternalTestImage()
+func (r *FusionAccessReconciler) runPullImageCheck(ctx context.Context, ns, cnsaVersion string) error {
+	testImage, err := utils.GetExternalTestImage(cnsaVersion)
 	if err != nil {
 		log.Log.Error(err, "Could not figure out test image", "testImage", testImage)
+		metrics.RecordImagePullCheck(false)
 		return err
 	}
 	ok, err := r.CanPullImage(ctx, r.Client, ns, testImage, IBMENTITLEMENTNAME)
+	metrics.RecordImagePullCheck(ok)
 	if ok {
 		log.Log.Info("Image pull test succeeded", "ns", ns, "testImage", testImage)
 	} else {
@@ -584,7 +711,12 @@ func getIbmManifest(fusionobj fusionv1alpha1.FusionAccessSpec) (ibmCnsaVersion,
 		return "", "", fmt.Errorf("disallowed URL for external manifest: %s", extManifestURL)
 	}
 
-	ibmCnsaVersion, installPath, err = utils.GetStorageScaleVersion()
+	// An approved upgrade to another bundled version takes over the installed one
+	version := string(fusionobj.StorageScaleVersion)
+	if approved := string(fusionobj.ApprovedStorageScaleVersion); approved != "" && utils.IsStorageScaleVersionBundled(approved) {
+		version = approved
+	}
+	ibmCnsaVersion, installPath, err = utils.GetStorageScaleVersion(version)
 	if err != nil {
 		return "", "", err
 	}
@@ -592,6 +724,23 @@ func getIbmManifest(fusionobj fusionv1alpha1.FusionAccessSpec) (ibmCnsaVersion,
 	return ibmCnsaVersion, installPath, nil
 }
 
+// loadIbmManifest reads the install manifest and applies spec.manifestOverrides to it
+func loadIbmManifest(
+	fusionobj fusionv1alpha1.FusionAccessSpec,
+	installPath string,
+	opts ...manifestival.Option,
+) (manifestival.Manifest, error) {
+	installManifest, err := manifestival.NewManifest(installPath, opts...)
+	if err != nil {
+		return manifestival.Manifest{}, err
+	}
+	installManifest, err = installManifest.Transform(manifest.Transformers(fusionobj.ManifestOverrides)...)
+	if err != nil {
+		return manifestival.Manifest{}, fmt.Errorf("failed to apply the manifest overrides: %w", err)
+	}
+	return installManifest, nil
+}
+
 // isItOurPullSecret returns true for Create or changed Update events
 func isItOurPullSecret() builder.WatchesOption {
 	return builder.WithPredicates(predicate.Funcs{
@@ -646,15 +795,6 @@ func checkPullSecret(secret *corev1.Secret, ns string) bool {
 	return true
 }
 
-// func (r *FusionAccessReconciler) finalizeFusionAccess(reqLogger logr.Logger, sc *v1alpha1.FusionAccess) error {
-// 	// TODO(user): Add the cleanup steps that the operator
-// 	// needs to do before the CR can be deleted. Examples
-// 	// of finalizers include performing backups and deleting
-// 	// resources that are not owned by this CR, like a PVC.
-// 	reqLogger.Info("Successfully finalized FusionAccess")
-// 	return nil
-// }
-
 // returns true if the registry secret has changed
 func didTheRegistrySecretChange(c client.Client) builder.WatchesOption {
 	ns, _ := utils.GetDeploymentNamespace()
@@ -801,6 +941,46 @@ func (r *FusionAccessReconciler) haveBuilds(ctx context.Context, fusionaccess *f
 	return len(buildList.Items) > 0, nil
 }
 
+// buildPhases are the phases reported by the kernel module build metric
+var buildPhases = []buildv1.BuildPhase{
+	buildv1.BuildPhaseNew,
+	buildv1.BuildPhasePending,
+	buildv1.BuildPhaseRunning,
+	buildv1.BuildPhaseComplete,
+	buildv1.BuildPhaseFailed,
+	buildv1.BuildPhaseError,
+	buildv1.BuildPhaseCancelled,
+}
+
+// recordKMMMetrics reports the kernel module builds by phase and the nodes the module is loaded on.
+// Failures are only logged, metrics must not block the reconcile.
+func (r *FusionAccessReconciler) recordKMMMetrics(ctx context.Context, ns string) {
+	buildList := &buildv1.BuildList{}
+	if err := r.List(ctx, buildList, client.InNamespace(ns)); err != nil {
+		log.Log.Error(err, "Failed to list Build resources for metrics")
+	} else {
+		counts := make(map[buildv1.BuildPhase]int)
+		for i := range buildList.Items {
+			counts[buildList.Items[i].Status.Phase]++
+		}
+		for _, phase := range buildPhases {
+			metrics.KMMModuleBuilds.WithLabelValues(string(phase)).Set(float64(counts[phase]))
+		}
+	}
+
+	module := &kmmv1beta1.Module{}
+	if err := r.Get(ctx, client.ObjectKey{Name: kernelmodule.KMMModuleName, Namespace: ns}, module); err != nil {
+		if kerrors.IsNotFound(err) {
+			metrics.KMMModuleLoaderNodes.Reset()
+			return
+		}
+		log.Log.Error(err, "Failed to get KMM Module for metrics")
+		return
+	}
+	metrics.KMMModuleLoaderNodes.WithLabelValues(module.Name, "desired").Set(float64(module.Status.ModuleLoader.DesiredNumber))
+	metrics.KMMModuleLoaderNodes.WithLabelValues(module.Name, "available").Set(float64(module.Status.ModuleLoader.AvailableNumber))
+}
+
 // This routine manages the deletion of a PodDisruptionBudget (PDB) which is created just before an update of Spectrum Scale
 // is started. The PDB is created to prevent any node drains done during the update from terminating the GPFS module build pod.
 // The PDB is then deleted once the GPFS nodule build has completed, to avoid getting an alarm about there being a PDB with
@@ -33,10 +33,13 @@ import (
 	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -52,6 +55,22 @@ func newNamespace(name string) *corev1.Namespace {
 	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
 }
 
+// applyAsCreateOrUpdate emulates server-side apply, which the fake client does not support
+func applyAsCreateOrUpdate(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); errors.IsNotFound(err) {
+		return c.Create(ctx, obj)
+	} else if err != nil {
+		return err
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
 func newOCPVersion(version string) *configv1.ClusterVersion {
 	return &configv1.ClusterVersion{
 		ObjectMeta: metav1.ObjectMeta{Name: "version"},
@@ -117,6 +136,7 @@ var _ = Describe("FusionAccess Controller Suite", func() {
 				os.Setenv("DEPLOYMENT_NAMESPACE", "ibm-fusion-access-operator")
 				fakeClientBuilder = fake.NewClientBuilder().
 					WithScheme(scheme).
+					WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).
 					WithRuntimeObjects(version, namespace, clusterConsole, clusterPullSecret).
 					WithStatusSubresource(&fusionv1alpha.FusionAccess{})
 
@@ -160,6 +180,62 @@ var _ = Describe("FusionAccess Controller Suite", func() {
 				Expect(err).ToNot(HaveOccurred())
 			})
 
+			It("should emit events when the manifest is applied and Storage Scale is ready", func() {
+				resource := &fusionv1alpha.FusionAccess{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: fusionv1alpha.FusionAccessSpec{
+						StorageScaleVersion: fusionv1alpha.StorageScaleVersions(cnsaVersion),
+					},
+				}
+				k8sClient = fakeClientBuilder.WithRuntimeObjects(resource).Build()
+				recorder := record.NewFakeRecorder(10)
+				r := NewFusionAccessReconciler(k8sClient, k8sClient, k8sClient.Scheme(), recorder)
+				r.CanPullImage = func(ctx context.Context, client client.Client, ns, image, pullSecret string) (bool, error) {
+					return true, nil
+				}
+
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(recorder.Events).To(Receive(And(
+					ContainSubstring(corev1.EventTypeNormal), ContainSubstring(EventReasonManifestApplied))))
+				Expect(recorder.Events).To(Receive(ContainSubstring(EventReasonImagePullSucceeded)))
+				Expect(recorder.Events).To(Receive(And(
+					ContainSubstring(corev1.EventTypeNormal), ContainSubstring(EventReasonReady))))
+			})
+
+			It("should emit a warning event when the manifest apply fails", func() {
+				resource := &fusionv1alpha.FusionAccess{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      resourceName,
+						Namespace: "default",
+					},
+					Spec: fusionv1alpha.FusionAccessSpec{
+						StorageScaleVersion: fusionv1alpha.StorageScaleVersions(cnsaVersion),
+					},
+				}
+				k8sClient = fakeClientBuilder.WithRuntimeObjects(resource).
+					WithInterceptorFuncs(interceptor.Funcs{
+						Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+							if patch.Type() == types.ApplyPatchType {
+								return fmt.Errorf("apply refused")
+							}
+							return c.Patch(ctx, obj, patch, opts...)
+						},
+					}).Build()
+				recorder := record.NewFakeRecorder(10)
+				r := NewFusionAccessReconciler(k8sClient, k8sClient, k8sClient.Scheme(), recorder)
+
+				_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).To(HaveOccurred())
+				Expect(recorder.Events).To(Receive(And(
+					ContainSubstring(corev1.EventTypeWarning), ContainSubstring(EventReasonManifestApplyFailed),
+					ContainSubstring("apply refused"))))
+				Expect(recorder.Events).NotTo(Receive(ContainSubstring(EventReasonReady)))
+			})
+
 			It("should not delete the PDB when there are Builds", func() {
 				resource := &fusionv1alpha.FusionAccess{
 					ObjectMeta: metav1.ObjectMeta{
@@ -436,6 +512,20 @@ var _ = Describe("FusionAccess Controller Suite", func() {
 				Expect(installPath).To(Equal(path.Join("../../files", cnsaVersion, "install.yaml")))
 			})
 		})
+
+		Context("when the bundled versions are reported", func() {
+			It("should list them in the status", func() {
+				fa := &fusionv1alpha.FusionAccess{ObjectMeta: metav1.ObjectMeta{Name: "fusionaccess-object", Namespace: "default"}}
+				scheme := createFakeScheme()
+				c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(fa).WithStatusSubresource(fa).Build()
+				r := &FusionAccessReconciler{Client: c, Scheme: scheme}
+
+				Expect(r.updateSupportedStorageScaleVersions(context.Background(), fa)).To(Succeed())
+				current := &fusionv1alpha.FusionAccess{}
+				Expect(c.Get(context.Background(), client.ObjectKeyFromObject(fa), current)).To(Succeed())
+				Expect(current.Status.SupportedStorageScaleVersions).To(ContainElement(cnsaVersion))
+			})
+		})
 	})
 
 	var _ = Describe("getCurrentRegistrySecretName", func() {
@@ -55,6 +55,11 @@ const (
 	KMMImageConfigKeyRegistrySecretName = "kmm_image_registry_secret_name" //nolint:gosec
 	KMMRegistryPushPullSecretName       = "kmm-registry-push-pull-secret"  //nolint:gosec
 
+	// The ConfigMap of the Storage Scale operator listing its images
+	IBMManagerConfigNamespace = "ibm-spectrum-scale-operator"
+	IBMManagerConfigName      = "ibm-spectrum-scale-manager-config"
+	IBMManagerConfigKey       = "controller_manager_config.yaml"
+
 	// These has to match the values we use in the plugin code to label the selected nodes (STORAGE_ROLE_LABEL)
 	// Do not change this without also implementing a solution for upgrade of existing clusters using the current value.
 	KMMNodeSelectorKey   = "scale.spectrum.ibm.com/role"
@@ -325,15 +330,26 @@ func getMergedRegistrySecret(ctx context.Context, cl client.Client, namespace st
 // getIBMCoreImage gets the core init image with the source code in them
 func getIBMCoreImage(ctx context.Context, cl client.Client) (string, error) {
 	cm := &corev1.ConfigMap{}
-	err := cl.Get(ctx, types.NamespacedName{Namespace: "ibm-spectrum-scale-operator", Name: "ibm-spectrum-scale-manager-config"}, cm)
+	err := cl.Get(ctx, types.NamespacedName{Namespace: IBMManagerConfigNamespace, Name: IBMManagerConfigName}, cm)
 	if err != nil {
 		return "", err
 	}
+	return GetIBMCoreImageFromConfigMap(cm)
+}
+
+// GetIBMCoreImageFromConfigMap returns the core init image of the ibm-spectrum-scale-manager-config ConfigMap,
+// be it the one applied or the one of a Storage Scale manifest
+func GetIBMCoreImageFromConfigMap(cm *corev1.ConfigMap) (string, error) {
 	var objmap map[string]any
-	if err := yaml.Unmarshal([]byte(cm.Data["controller_manager_config.yaml"]), &objmap); err != nil {
+	if err := yaml.Unmarshal([]byte(cm.Data[IBMManagerConfigKey]), &objmap); err != nil {
 		return "", err
 	}
-	return objmap["images"].(map[string]any)["coreInit"].(string), nil
+	images, _ := objmap["images"].(map[string]any)
+	coreInit, _ := images["coreInit"].(string)
+	if coreInit == "" {
+		return "", fmt.Errorf("no coreInit image in ConfigMap %s", cm.Name)
+	}
+	return coreInit, nil
 }
 
 func getIBMCoreImageHash(image string) string {
@@ -410,3 +426,29 @@ var GetServiceAccountDockercfgSecretName = func(ctx context.Context, cl client.C
 	// Return an error if no secret matches the pattern
 	return "", fmt.Errorf("no dockercfg secret found for service account %s in namespace %s", serviceAccountName, namespace)
 }
+
+// DeleteKMMResources deletes the resources created by CreateOrUpdateKMMResources and reports whether they are gone.
+// The Module goes first and is waited for, KMM still needs the registry secret to unload the kernel module.
+func DeleteKMMResources(ctx context.Context, cl client.Client) (bool, error) {
+	ns, err := utils.GetDeploymentNamespace()
+	if err != nil {
+		return false, fmt.Errorf("failed to get namespace in DeleteKMMResources: %w", err)
+	}
+
+	module := &kmmv1beta1.Module{ObjectMeta: metav1.ObjectMeta{Name: KMMModuleName, Namespace: ns}}
+	if err := cl.Delete(ctx, module); err == nil {
+		return false, nil
+	} else if !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to delete kernelModule in DeleteKMMResources: %w", err)
+	}
+
+	for _, obj := range []client.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: ns}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: KMMRegistryPushPullSecretName, Namespace: ns}},
+	} {
+		if err := cl.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete %s in DeleteKMMResources: %w", obj.GetName(), err)
+		}
+	}
+	return true, nil
+}
@@ -36,3 +36,13 @@ func CreateOrUpdateLocalVolumeDiscovery(ctx context.Context, devicefinder *fusio
 	}
 	return nil
 }
+
+// DeleteLocalVolumeDiscovery deletes the device finder and reports whether it is gone
+func DeleteLocalVolumeDiscovery(ctx context.Context, devicefinder *fusionv1alpha.LocalVolumeDiscovery, cl client.Client) (bool, error) {
+	if err := cl.Delete(ctx, devicefinder); apierrors.IsNotFound(err) {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("could not delete device finder: %w", err)
+	}
+	return false, nil
+}
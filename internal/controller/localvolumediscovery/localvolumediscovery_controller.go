@@ -18,12 +18,14 @@ package localvolumediscovery
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
 	localv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/assets"
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/metrics"
 	operatorv1 "github.com/openshift/api/operator/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -84,8 +86,26 @@ func (r *LocalVolumeDiscoveryReconciler) Reconcile(ctx context.Context, request
 		return ctrl.Result{}, err
 	}
 
+	if err := r.recordDiscoveredDevices(ctx, instance.Namespace); err != nil {
+		klog.ErrorS(err, "failed to record discovered device metrics")
+	}
+
+	envVars, err := getEnvVars(instance.Name, string(instance.UID), &instance.Spec)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// Dropping invalid filters would publish the devices they exclude, the daemons keep the last valid ones
+	// instead, and the invalid filters are reported below
+	filtersErr := localv1alpha1.ValidateDeviceFilters(instance.Spec.InclusionSpec, instance.Spec.ExclusionSpec)
+	if filtersErr != nil {
+		klog.ErrorS(filtersErr, "keeping the last valid discovery filters")
+		envVars, err = r.keepLastValidFilters(ctx, request.Namespace, envVars)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 	diskMakerDSMutateFn := getDeviceFinderDiscoveryDSMutateFn(request, instance.Spec.Tolerations,
-		getEnvVars(instance.Name, string(instance.UID)),
+		envVars,
 		getOwnerRefs(instance),
 		instance.Spec.NodeSelector, r.Scheme)
 	ds, opResult, err := CreateOrUpdateDaemonset(ctx, r.Client, diskMakerDSMutateFn)
@@ -125,9 +145,15 @@ func (r *LocalVolumeDiscoveryReconciler) Reconcile(ctx context.Context, request
 		return waitForRequeueIfDaemonsNotReady, nil
 	}
 
-	message := fmt.Sprintf("successfully running %d out of %d discovery daemons", desiredDaemons, readyDaemons)
-	err = r.updateDiscoveryStatus(ctx, instance, operatorv1.OperatorStatusTypeAvailable, message,
-		metav1.ConditionTrue, localv1alpha1.Discovering)
+	if filtersErr != nil {
+		message := fmt.Sprintf("invalid discovery filters, keeping the last valid ones: %v", filtersErr)
+		err = r.updateDiscoveryStatus(ctx, instance, operatorv1.OperatorStatusTypeDegraded, message,
+			metav1.ConditionFalse, localv1alpha1.DiscoveryFailed)
+	} else {
+		message := fmt.Sprintf("successfully running %d out of %d discovery daemons", desiredDaemons, readyDaemons)
+		err = r.updateDiscoveryStatus(ctx, instance, operatorv1.OperatorStatusTypeAvailable, message,
+			metav1.ConditionTrue, localv1alpha1.Discovering)
+	}
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -242,6 +268,33 @@ func (r *LocalVolumeDiscoveryReconciler) deleteOrphanDiscoveryResults(ctx contex
 	return nil
 }
 
+// recordDiscoveredDevices reports the number of devices discovered on each node by type
+func (r *LocalVolumeDiscoveryReconciler) recordDiscoveredDevices(ctx context.Context, namespace string) error {
+	discoveryResultList := &localv1alpha1.LocalVolumeDiscoveryResultList{}
+	err := r.Client.List(ctx, discoveryResultList, client.InNamespace(namespace))
+	if err != nil {
+		return fmt.Errorf("failed to list LocalVolumeDiscoveryResult instances in namespace %q", namespace)
+	}
+
+	// Nodes can leave the discovery, so the series are rebuilt
+	metrics.DiscoveredDevices.Reset()
+	for idx := range discoveryResultList.Items {
+		result := &discoveryResultList.Items[idx]
+		counts := map[localv1alpha1.DiscoveredDeviceType]int{
+			localv1alpha1.DiskType:      0,
+			localv1alpha1.MultiPathType: 0,
+		}
+		for _, device := range result.Status.DiscoveredDevices {
+			counts[device.Type]++
+		}
+		for deviceType, count := range counts {
+			metrics.DiscoveredDevices.WithLabelValues(result.Spec.NodeName, string(deviceType)).Set(float64(count))
+		}
+	}
+
+	return nil
+}
+
 func (r *LocalVolumeDiscoveryReconciler) updateStatus(ctx context.Context, lvd *localv1alpha1.LocalVolumeDiscovery) error {
 	err := r.Client.Status().Update(ctx, lvd)
 	if err != nil {
@@ -274,8 +327,8 @@ func getOwnerRefs(cr *localv1alpha1.LocalVolumeDiscovery) []metav1.OwnerReferenc
 	}
 }
 
-func getEnvVars(objName, uid string) []corev1.EnvVar {
-	return []corev1.EnvVar{
+func getEnvVars(objName, uid string, spec *localv1alpha1.LocalVolumeDiscoverySpec) ([]corev1.EnvVar, error) {
+	envVars := []corev1.EnvVar{
 		{
 			Name:  "DISCOVERY_OBJECT_UID",
 			Value: uid,
@@ -285,6 +338,52 @@ func getEnvVars(objName, uid string) []corev1.EnvVar {
 			Value: objName,
 		},
 	}
+	if spec.InclusionSpec == nil && spec.ExclusionSpec == nil {
+		return envVars, nil
+	}
+
+	// The daemons are rolled out again when the filters change
+	filters, err := json.Marshal(localv1alpha1.LocalVolumeDiscoverySpec{
+		InclusionSpec: spec.InclusionSpec,
+		ExclusionSpec: spec.ExclusionSpec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the discovery filters: %w", err)
+	}
+	return append(envVars, corev1.EnvVar{Name: common.DiscoveryFiltersEnv, Value: string(filters)}), nil
+}
+
+// keepLastValidFilters replaces the invalid filters of envVars with the valid ones the discovery daemonset runs
+// with. Without them, e.g. before the daemonset is created, the invalid filters are kept and the daemons fail
+// closed: they discover no devices.
+func (r *LocalVolumeDiscoveryReconciler) keepLastValidFilters(ctx context.Context, namespace string, envVars []corev1.EnvVar) ([]corev1.EnvVar, error) {
+	existingDS := &appsv1.DaemonSet{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: DeviceFinderDiscovery, Namespace: namespace}, existingDS)
+	if errors.IsNotFound(err) {
+		return envVars, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	for _, container := range existingDS.Spec.Template.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name != common.DiscoveryFiltersEnv {
+				continue
+			}
+			spec := localv1alpha1.LocalVolumeDiscoverySpec{}
+			if json.Unmarshal([]byte(env.Value), &spec) != nil ||
+				localv1alpha1.ValidateDeviceFilters(spec.InclusionSpec, spec.ExclusionSpec) != nil {
+				return envVars, nil
+			}
+			for i := range envVars {
+				if envVars[i].Name == common.DiscoveryFiltersEnv {
+					envVars[i].Value = env.Value
+				}
+			}
+			return envVars, nil
+		}
+	}
+	return envVars, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -292,6 +391,7 @@ func (r *LocalVolumeDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) erro
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&localv1alpha1.LocalVolumeDiscovery{}).
 		Watches(&appsv1.DaemonSet{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &localv1alpha1.LocalVolumeDiscovery{})).
+		Watches(&localv1alpha1.LocalVolumeDiscoveryResult{}, handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &localv1alpha1.LocalVolumeDiscovery{})).
 		Complete(r)
 }
 
@@ -7,6 +7,10 @@ import (
 	. "github.com/onsi/gomega"
 
 	localv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -193,6 +197,62 @@ var _ = Describe("LocalVolumeDiscoveryReconciler", func() {
 				false, int32(0), int32(0), localv1alpha1.DiscoveryFailed, "Degraded", metav1.ConditionFalse,
 			),
 		)
+
+		It("should keep the last valid filters and report invalid filters", func() {
+			discoveryObj := &localv1alpha1.LocalVolumeDiscovery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: localv1alpha1.LocalVolumeDiscoverySpec{
+					ExclusionSpec: &localv1alpha1.DeviceExclusionSpec{Model: "(C-Mode"},
+				},
+			}
+			validFilters := `{"exclusionSpec":{"model":"C-Mode"}}`
+			existingDS := discoveryDaemonSet.DeepCopy()
+			existingDS.Spec.Template.Spec.Containers = []corev1.Container{{
+				Name: "devicefinder-discovery",
+				Env:  []corev1.EnvVar{{Name: common.DiscoveryFiltersEnv, Value: validFilters}},
+			}}
+			fakeReconciler := newFakeLocalVolumeDiscoveryReconciler(discoveryObj, existingDS)
+			_, err := fakeReconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(discoveryObj)})
+			Expect(err).ToNot(HaveOccurred())
+
+			ds := &appsv1.DaemonSet{}
+			Expect(fakeReconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(discoveryDaemonSet), ds)).To(Succeed())
+			Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+				corev1.EnvVar{Name: common.DiscoveryFiltersEnv, Value: validFilters}))
+
+			Expect(fakeReconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(discoveryObj), discoveryObj)).To(Succeed())
+			Expect(discoveryObj.Status.Phase).To(Equal(localv1alpha1.DiscoveryFailed))
+			Expect(discoveryObj.Status.Conditions[0].Type).To(Equal("Degraded"))
+			Expect(discoveryObj.Status.Conditions[0].Message).To(ContainSubstring("exclusionSpec.model is not a valid regular expression"))
+		})
+
+		It("should pass invalid filters without valid ones so that no devices are discovered", func() {
+			discoveryObj := &localv1alpha1.LocalVolumeDiscovery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: localv1alpha1.LocalVolumeDiscoverySpec{
+					InclusionSpec: &localv1alpha1.DeviceInclusionSpec{Model: "("},
+				},
+			}
+			fakeReconciler := newFakeLocalVolumeDiscoveryReconciler(discoveryObj, discoveryDaemonSet.DeepCopy())
+			_, err := fakeReconciler.Reconcile(context.TODO(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(discoveryObj)})
+			Expect(err).ToNot(HaveOccurred())
+
+			ds := &appsv1.DaemonSet{}
+			Expect(fakeReconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(discoveryDaemonSet), ds)).To(Succeed())
+			Expect(ds.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+				corev1.EnvVar{Name: common.DiscoveryFiltersEnv, Value: `{"inclusionSpec":{"model":"("}}`}))
+
+			Expect(fakeReconciler.Client.Get(context.TODO(), client.ObjectKeyFromObject(discoveryObj), discoveryObj)).To(Succeed())
+			Expect(discoveryObj.Status.Phase).To(Equal(localv1alpha1.DiscoveryFailed))
+			Expect(discoveryObj.Status.Conditions[0].Type).To(Equal("Degraded"))
+			Expect(discoveryObj.Status.Conditions[0].Message).To(ContainSubstring("inclusionSpec.model is not a valid regular expression"))
+		})
 	})
 
 	Context("deleteOrphanDiscoveryResults", func() {
@@ -270,4 +330,47 @@ var _ = Describe("LocalVolumeDiscoveryReconciler", func() {
 			Expect(results.Items).To(BeEmpty())
 		})
 	})
+
+	Context("getEnvVars", func() {
+		It("should pass the discovery filters to the daemonset", func() {
+			envVars, err := getEnvVars(name, "uid", &localv1alpha1.LocalVolumeDiscoverySpec{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envVars).To(HaveLen(2))
+
+			envVars, err = getEnvVars(name, "uid", &localv1alpha1.LocalVolumeDiscoverySpec{
+				ExclusionSpec: &localv1alpha1.DeviceExclusionSpec{Vendor: "^NETAPP"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(envVars).To(ContainElement(corev1.EnvVar{
+				Name:  common.DiscoveryFiltersEnv,
+				Value: `{"exclusionSpec":{"vendor":"^NETAPP"}}`,
+			}))
+		})
+	})
+
+	Context("recordDiscoveredDevices", func() {
+		It("should report the discovered devices of each node by type", func() {
+			discoveryResults := &localv1alpha1.LocalVolumeDiscoveryResultList{}
+			localVolumeDiscoveryResultList.DeepCopyInto(discoveryResults)
+			discoveryResults.Items[0].Status.DiscoveredDevices = []localv1alpha1.DiscoveredDevice{
+				{Path: "/dev/sdb", Type: localv1alpha1.DiskType},
+				{Path: "/dev/sdc", Type: localv1alpha1.DiskType},
+				{Path: "/dev/dm-0", Type: localv1alpha1.MultiPathType},
+			}
+
+			fakeReconciler := newFakeLocalVolumeDiscoveryReconciler(discoveryResults)
+			err := fakeReconciler.recordDiscoveredDevices(context.TODO(), namespace)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(gaugeValue(metrics.DiscoveredDevices.WithLabelValues("Node1", "disk"))).To(Equal(2.0))
+			Expect(gaugeValue(metrics.DiscoveredDevices.WithLabelValues("Node1", "mpath"))).To(Equal(1.0))
+			Expect(gaugeValue(metrics.DiscoveredDevices.WithLabelValues("Node2", "disk"))).To(Equal(0.0))
+		})
+	})
 })
+
+func gaugeValue(g prometheus.Gauge) float64 {
+	m := &dto.Metric{}
+	Expect(g.Write(m)).To(Succeed())
+	return m.GetGauge().GetValue()
+}
@@ -0,0 +1,316 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifest applies the IBM Storage Scale install manifest with server-side apply. The applied objects
+// and the hashes of their manifests are kept in an inventory ConfigMap, so that unchanged objects are not
+// applied again and objects dropped from a newer manifest are pruned. Every object is applied again once per
+// ResyncPeriod, which reverts the changes made to the managed objects by hand.
+package manifest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// FieldManager owns the fields of the objects applied from the manifest
+	FieldManager = "fusion-access-operator"
+	// InventoryConfigMapName is the ConfigMap keeping the inventory of the applied objects
+	InventoryConfigMapName = "fusion-access-manifest-inventory"
+	// HashAnnotation records on every applied object the hash of its manifest
+	HashAnnotation = "fusion.storage.openshift.io/manifest-hash"
+	// ResyncPeriod is how often every object of the manifest is applied again, unchanged or not
+	ResyncPeriod = 30 * time.Minute
+
+	inventoryKey             = "inventory.json"
+	inventoryVersionKey      = "version"
+	inventoryLastResyncedKey = "lastResynced"
+)
+
+// Failure gathers the objects of one kind that could not be applied or pruned
+type Failure struct {
+	Kind    string
+	Objects []string
+	Message string
+}
+
+// Result summarizes a manifest apply
+type Result struct {
+	Applied   int
+	Unchanged int
+	Pruned    int
+	Failures  []Failure
+}
+
+// Err returns an error describing the failures, nil when there is none
+func (r Result) Err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	msgs := make([]string, 0, len(r.Failures))
+	for _, f := range r.Failures {
+		msgs = append(msgs, fmt.Sprintf("%s %s: %s", f.Kind, strings.Join(f.Objects, ", "), f.Message))
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+func (r *Result) addFailure(kind, object string, err error) {
+	for i := range r.Failures {
+		if r.Failures[i].Kind == kind {
+			r.Failures[i].Objects = append(r.Failures[i].Objects, object)
+			return
+		}
+	}
+	r.Failures = append(r.Failures, Failure{Kind: kind, Objects: []string{object}, Message: err.Error()})
+}
+
+// inventoryEntry identifies an applied object
+type inventoryEntry struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Hash of the manifest of the object, empty when its last apply failed
+	Hash string `json:"hash,omitempty"`
+}
+
+func (e inventoryEntry) key() string {
+	return strings.Join([]string{e.Group, e.Kind, e.Namespace, e.Name}, "/")
+}
+
+func (e inventoryEntry) object() string {
+	if e.Namespace == "" {
+		return e.Name
+	}
+	return e.Namespace + "/" + e.Name
+}
+
+func (e inventoryEntry) partialObject() *metav1.PartialObjectMetadata {
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: e.Group, Version: e.Version, Kind: e.Kind})
+	obj.SetNamespace(e.Namespace)
+	obj.SetName(e.Name)
+	return obj
+}
+
+func newInventoryEntry(obj *unstructured.Unstructured) (inventoryEntry, error) {
+	gvk := obj.GroupVersionKind()
+	b, err := json.Marshal(obj.Object)
+	if err != nil {
+		return inventoryEntry{}, err
+	}
+	sum := sha256.Sum256(b)
+	return inventoryEntry{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Hash:      hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// Apply server-side applies the objects of the manifest of a version, in order, skipping the ones whose
+// manifest did not change since they were applied and whose live object still carries the hash of that
+// manifest. Live objects are read with reader, which should not be a cached client: a cache would watch every
+// kind of the manifest cluster-wide. The objects of the previous inventory that are no longer in the manifest
+// are deleted once everything was applied. The inventory is kept in namespace ns.
+func Apply(
+	ctx context.Context,
+	cl client.Client,
+	reader client.Reader,
+	ns, version string,
+	resources []unstructured.Unstructured,
+) (Result, error) {
+	var result Result
+	inventory, lastResynced, err := loadInventory(ctx, cl, ns)
+	if err != nil {
+		return result, err
+	}
+	resync := time.Since(lastResynced) >= ResyncPeriod
+
+	applied := make([]inventoryEntry, 0, len(resources))
+	for i := range resources {
+		obj := resources[i].DeepCopy()
+		entry, err := newInventoryEntry(obj)
+		if err != nil {
+			return result, err
+		}
+
+		if previous, ok := inventory[entry.key()]; ok && previous.Hash == entry.Hash && !resync {
+			live := entry.partialObject()
+			err := reader.Get(ctx, client.ObjectKeyFromObject(obj), live)
+			if err == nil && live.GetAnnotations()[HashAnnotation] == entry.Hash {
+				result.Unchanged++
+				applied = append(applied, entry)
+				continue
+			}
+			if err != nil && !apierrors.IsNotFound(err) {
+				log.Log.Error(err, "Failed to check applied object", "kind", entry.Kind, "object", entry.object())
+			}
+		}
+
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[HashAnnotation] = entry.Hash
+		obj.SetAnnotations(annotations)
+		obj.SetManagedFields(nil)
+		obj.SetResourceVersion("")
+		if err := cl.Patch(ctx, obj, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+			log.Log.Error(err, "Failed to apply object", "kind", entry.Kind, "object", entry.object())
+			result.addFailure(entry.Kind, entry.object(), err)
+			// Keep the object in the inventory without hash so that it is applied again
+			entry.Hash = ""
+		} else {
+			result.Applied++
+		}
+		applied = append(applied, entry)
+	}
+
+	applyFailed := len(result.Failures) > 0
+	current := make(map[string]bool, len(applied))
+	for _, entry := range applied {
+		current[entry.key()] = true
+	}
+	for _, key := range sortedKeys(inventory) {
+		if current[key] {
+			continue
+		}
+		entry := inventory[key]
+		// Only prune once the new manifest is fully applied, the objects it replaces may still be needed
+		if applyFailed {
+			applied = append(applied, entry)
+			continue
+		}
+		if err := cl.Delete(ctx, entry.partialObject()); err != nil && !apierrors.IsNotFound(err) {
+			log.Log.Error(err, "Failed to prune object", "kind", entry.Kind, "object", entry.object())
+			result.addFailure(entry.Kind, entry.object(), fmt.Errorf("prune failed: %w", err))
+			applied = append(applied, entry)
+			continue
+		}
+		log.Log.Info("Pruned object removed from the manifest", "kind", entry.Kind, "object", entry.object())
+		result.Pruned++
+	}
+
+	// A resync only counts once every object was applied
+	if resync && len(result.Failures) == 0 {
+		lastResynced = time.Now()
+	}
+	if err := saveInventory(ctx, cl, ns, version, applied, lastResynced); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// DeleteInventory deletes the inventory, once the objects of the manifest are deleted
+func DeleteInventory(ctx context.Context, cl client.Client, ns string) error {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: InventoryConfigMapName, Namespace: ns}}
+	if err := cl.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the manifest inventory: %w", err)
+	}
+	return nil
+}
+
+// loadInventory returns the inventory and when every object was last applied, the zero time when unknown
+func loadInventory(ctx context.Context, cl client.Client, ns string) (map[string]inventoryEntry, time.Time, error) {
+	inventory := map[string]inventoryEntry{}
+	cm := &corev1.ConfigMap{}
+	if err := cl.Get(ctx, client.ObjectKey{Name: InventoryConfigMapName, Namespace: ns}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return inventory, time.Time{}, nil
+		}
+		return nil, time.Time{}, fmt.Errorf("failed to get the manifest inventory: %w", err)
+	}
+
+	var entries []inventoryEntry
+	if err := json.Unmarshal([]byte(cm.Data[inventoryKey]), &entries); err != nil {
+		// A broken inventory only costs a full apply, nothing is pruned
+		log.Log.Error(err, "Ignoring unreadable manifest inventory", "configmap", InventoryConfigMapName)
+		return inventory, time.Time{}, nil
+	}
+	for _, entry := range entries {
+		inventory[entry.key()] = entry
+	}
+	// A missing or unreadable time forces a resync
+	lastResynced, _ := time.Parse(time.RFC3339, cm.Data[inventoryLastResyncedKey])
+	return inventory, lastResynced, nil
+}
+
+func saveInventory(
+	ctx context.Context,
+	cl client.Client,
+	ns, version string,
+	entries []inventoryEntry,
+	lastResynced time.Time,
+) error {
+	b, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	data := map[string]string{inventoryKey: string(b), inventoryVersionKey: version}
+	if !lastResynced.IsZero() {
+		data[inventoryLastResyncedKey] = lastResynced.UTC().Format(time.RFC3339)
+	}
+
+	cm := &corev1.ConfigMap{}
+	err = cl.Get(ctx, client.ObjectKey{Name: InventoryConfigMapName, Namespace: ns}, cm)
+	switch {
+	case apierrors.IsNotFound(err):
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: InventoryConfigMapName, Namespace: ns},
+			Data:       data,
+		}
+		if err := cl.Create(ctx, cm); err != nil {
+			return fmt.Errorf("failed to create the manifest inventory: %w", err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get the manifest inventory: %w", err)
+	}
+
+	cm.Data = data
+	if err := cl.Update(ctx, cm); err != nil {
+		return fmt.Errorf("failed to update the manifest inventory: %w", err)
+	}
+	return nil
+}
+
+func sortedKeys(inventory map[string]inventoryEntry) []string {
+	keys := make([]string, 0, len(inventory))
+	for key := range inventory {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("Manifest apply", func() {
+	const ns = "ibm-fusion-access"
+
+	var (
+		ctx     = context.Background()
+		cl      client.Client
+		patched []string
+		failing string
+	)
+
+	newObject := func(kind, name, value string) unstructured.Unstructured {
+		obj := unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       kind,
+			"metadata":   map[string]any{"name": name, "namespace": "ibm-spectrum-scale"},
+		}}
+		if kind == "ConfigMap" {
+			obj.Object["data"] = map[string]any{"value": value}
+		}
+		return obj
+	}
+
+	BeforeEach(func() {
+		patched = nil
+		failing = ""
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		// The fake client does not support server-side apply, it is emulated with create or update
+		cl = fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch.Type() != types.ApplyPatchType {
+					return c.Patch(ctx, obj, patch, opts...)
+				}
+				u := obj.(*unstructured.Unstructured)
+				if u.GetKind() == failing {
+					return errors.New("admission denied")
+				}
+				patched = append(patched, u.GetName())
+				existing := &unstructured.Unstructured{}
+				existing.SetGroupVersionKind(u.GroupVersionKind())
+				if err := c.Get(ctx, client.ObjectKeyFromObject(u), existing); apierrors.IsNotFound(err) {
+					return c.Create(ctx, u)
+				}
+				u.SetResourceVersion(existing.GetResourceVersion())
+				return c.Update(ctx, u)
+			},
+		}).Build()
+	})
+
+	It("should only apply the objects whose manifest changed", func() {
+		resources := []unstructured.Unstructured{newObject("ConfigMap", "config", "a"), newObject("Service", "svc", "")}
+
+		result, err := Apply(ctx, cl, cl, ns, "v1", resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(Result{Applied: 2}))
+		Expect(cl.Get(ctx, client.ObjectKey{Name: InventoryConfigMapName, Namespace: ns}, &corev1.ConfigMap{})).To(Succeed())
+
+		patched = nil
+		result, err = Apply(ctx, cl, cl, ns, "v1", resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(Result{Unchanged: 2}))
+		Expect(patched).To(BeEmpty())
+
+		resources[0] = newObject("ConfigMap", "config", "b")
+		result, err = Apply(ctx, cl, cl, ns, "v1", resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(Result{Applied: 1, Unchanged: 1}))
+		Expect(patched).To(Equal([]string{"config"}))
+	})
+
+	It("should apply again an object deleted behind its back", func() {
+		resources := []unstructured.Unstructured{newObject("ConfigMap", "config", "a")}
+		_, err := Apply(ctx, cl, cl, ns, "v1", resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cl.Delete(ctx, resources[0].DeepCopy())).To(Succeed())
+
+		result, err := Apply(ctx, cl, cl, ns, "v1", resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(Result{Applied: 1}))
+	})
+
+	It("should apply again an object edited behind its back", func() {
+		resources := []unstructured.Unstructured{newObject("ConfigMap", "config", "a")}
+		_, err := Apply(ctx, cl, cl, ns, "v1", resources)
+		Expect(err).NotTo(HaveOccurred())
+
+		// An edit dropping the annotations, as a replace with the original manifest would
+		cm := &corev1.ConfigMap{}
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(&resources[0]), cm)).To(Succeed())
+		Expect(cm.Annotations).To(HaveKey(HashAnnotation))
+		cm.Annotations = nil
+		cm.Data["value"] = "edited"
+		Expect(cl.Update(ctx, cm)).To(Succeed())
+
+		patched = nil
+		result, err := Apply(ctx, cl, cl, ns, "v1", resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(Result{Applied: 1}))
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(&resources[0]), cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKeyWithValue("value", "a"))
+	})
+
+	It("should apply again every object once the resync period elapsed", func() {
+		resources := []unstructured.Unstructured{newObject("ConfigMap", "config", "a"), newObject("Service", "svc", "")}
+		_, err := Apply(ctx, cl, cl, ns, "v1", resources)
+		Expect(err).NotTo(HaveOccurred())
+
+		cm := &corev1.ConfigMap{}
+		key := client.ObjectKey{Name: InventoryConfigMapName, Namespace: ns}
+		Expect(cl.Get(ctx, key, cm)).To(Succeed())
+		Expect(cm.Data).To(HaveKey(inventoryLastResyncedKey))
+		cm.Data[inventoryLastResyncedKey] = time.Now().Add(-ResyncPeriod).UTC().Format(time.RFC3339)
+		Expect(cl.Update(ctx, cm)).To(Succeed())
+
+		patched = nil
+		result, err := Apply(ctx, cl, cl, ns, "v1", resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(Result{Applied: 2}))
+		Expect(patched).To(ConsistOf("config", "svc"))
+
+		// The resync is recorded, the next apply skips the unchanged objects again
+		result, err = Apply(ctx, cl, cl, ns, "v1", resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(Result{Unchanged: 2}))
+	})
+
+	It("should prune the objects removed from the manifest", func() {
+		_, err := Apply(ctx, cl, cl, ns, "v1", []unstructured.Unstructured{
+			newObject("ConfigMap", "config", "a"), newObject("ConfigMap", "dropped", "a"),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		result, err := Apply(ctx, cl, cl, ns, "v2", []unstructured.Unstructured{newObject("ConfigMap", "config", "a")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(Result{Unchanged: 1, Pruned: 1}))
+		err = cl.Get(ctx, client.ObjectKey{Name: "dropped", Namespace: "ibm-spectrum-scale"}, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should report the failures per kind and not prune", func() {
+		_, err := Apply(ctx, cl, cl, ns, "v1", []unstructured.Unstructured{newObject("ConfigMap", "dropped", "a")})
+		Expect(err).NotTo(HaveOccurred())
+
+		failing = "Service"
+		resources := []unstructured.Unstructured{
+			newObject("ConfigMap", "config", "a"), newObject("Service", "svc1", ""), newObject("Service", "svc2", ""),
+		}
+		result, err := Apply(ctx, cl, cl, ns, "v2", resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Applied).To(Equal(1))
+		Expect(result.Pruned).To(BeZero())
+		Expect(result.Failures).To(Equal([]Failure{{
+			Kind:    "Service",
+			Objects: []string{"ibm-spectrum-scale/svc1", "ibm-spectrum-scale/svc2"},
+			Message: "admission denied",
+		}}))
+		Expect(result.Err()).To(MatchError(ContainSubstring("Service ibm-spectrum-scale/svc1, ibm-spectrum-scale/svc2")))
+		Expect(cl.Get(ctx, client.ObjectKey{Name: "dropped", Namespace: "ibm-spectrum-scale"}, &corev1.ConfigMap{})).To(Succeed())
+
+		// Once the failed objects are applied the dropped one is pruned
+		failing = ""
+		result, err = Apply(ctx, cl, cl, ns, "v2", resources)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(Result{Applied: 2, Unchanged: 1, Pruned: 1}))
+	})
+})
+
+func TestManifest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Manifest Suite")
+}
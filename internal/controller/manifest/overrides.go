@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/manifestival/manifestival"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
+)
+
+// Transformers returns the manifestival transformers applying the overrides of a FusionAccess
+func Transformers(overrides *fusionv1alpha1.ManifestOverrides) []manifestival.Transformer {
+	if overrides == nil {
+		return nil
+	}
+	var transformers []manifestival.Transformer
+	for _, patch := range overrides.Patches {
+		transformers = append(transformers, patchTransformer(patch))
+	}
+	if len(overrides.Images) > 0 {
+		transformers = append(transformers, imageTransformer(overrides.Images))
+	}
+	if len(overrides.Labels) > 0 {
+		transformers = append(transformers, labelTransformer(overrides.Labels))
+	}
+	return transformers
+}
+
+func targets(target fusionv1alpha1.ManifestPatchTarget, u *unstructured.Unstructured) bool {
+	gvk := u.GroupVersionKind()
+	return gvk.Kind == target.Kind &&
+		(target.Group == "" || gvk.Group == target.Group) &&
+		(target.Version == "" || gvk.Version == target.Version) &&
+		(target.Namespace == "" || u.GetNamespace() == target.Namespace) &&
+		(target.Name == "" || u.GetName() == target.Name)
+}
+
+func patchTransformer(patch fusionv1alpha1.ManifestPatch) manifestival.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if !targets(patch.Target, u) {
+			return nil
+		}
+		original, err := json.Marshal(u.Object)
+		if err != nil {
+			return err
+		}
+		patched, err := applyPatch(patch, original, u)
+		if err != nil {
+			return fmt.Errorf("failed to patch %s %s: %w", u.GetKind(), u.GetName(), err)
+		}
+		obj := map[string]any{}
+		if err := json.Unmarshal(patched, &obj); err != nil {
+			return err
+		}
+		u.SetUnstructuredContent(obj)
+		return nil
+	}
+}
+
+// applyPatch applies a manifest patch to the JSON of an object. The strategic merge patches of custom
+// resources are applied as JSON merge patches, like kubectl does.
+func applyPatch(patch fusionv1alpha1.ManifestPatch, original []byte, u *unstructured.Unstructured) ([]byte, error) {
+	patchJSON, err := yaml.ToJSON([]byte(patch.Patch))
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+
+	switch patch.Type {
+	case fusionv1alpha1.JSONPatch:
+		ops, err := jsonpatch.DecodePatch(patchJSON)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON patch: %w", err)
+		}
+		return ops.Apply(original)
+	case fusionv1alpha1.MergePatch:
+		return jsonpatch.MergePatch(original, patchJSON)
+	default:
+		typed, err := clientgoscheme.Scheme.New(u.GroupVersionKind())
+		if err != nil {
+			return jsonpatch.MergePatch(original, patchJSON)
+		}
+		return strategicpatch.StrategicMergePatch(original, patchJSON, typed)
+	}
+}
+
+// replaceImage returns the image with the source of the first matching override replaced by its mirror
+func replaceImage(image string, overrides []fusionv1alpha1.ImageOverride) string {
+	for _, o := range overrides {
+		if image == o.Source {
+			return o.Mirror
+		}
+		rest, found := strings.CutPrefix(image, o.Source)
+		if found && rest != "" && strings.ContainsRune("/:@", rune(rest[0])) {
+			return o.Mirror + rest
+		}
+	}
+	return image
+}
+
+// replaceImages replaces the values of the "image" fields found anywhere in the object
+func replaceImages(value any, overrides []fusionv1alpha1.ImageOverride) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if image, ok := field.(string); ok && key == "image" {
+				v[key] = replaceImage(image, overrides)
+				continue
+			}
+			replaceImages(field, overrides)
+		}
+	case []any:
+		for _, item := range v {
+			replaceImages(item, overrides)
+		}
+	}
+}
+
+func imageTransformer(overrides []fusionv1alpha1.ImageOverride) manifestival.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		if u.GetKind() == "ConfigMap" && u.GetName() == kernelmodule.IBMManagerConfigName {
+			return replaceManagerConfigImages(u, overrides)
+		}
+		replaceImages(u.Object["spec"], overrides)
+		return nil
+	}
+}
+
+// replaceManagerConfigImages replaces the images listed in the IBM Storage Scale manager configuration. The
+// configuration is edited line by line to keep it as it is otherwise.
+func replaceManagerConfigImages(u *unstructured.Unstructured, overrides []fusionv1alpha1.ImageOverride) error {
+	config, found, err := unstructured.NestedString(u.Object, "data", kernelmodule.IBMManagerConfigKey)
+	if err != nil || !found {
+		return err
+	}
+	lines := strings.Split(config, "\n")
+	for i, line := range lines {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		if replaced := replaceImage(strings.TrimSpace(value), overrides); replaced != strings.TrimSpace(value) {
+			lines[i] = key + ": " + replaced
+		}
+	}
+	return unstructured.SetNestedField(u.Object, strings.Join(lines, "\n"), "data", kernelmodule.IBMManagerConfigKey)
+}
+
+func labelTransformer(labels map[string]string) manifestival.Transformer {
+	return func(u *unstructured.Unstructured) error {
+		current := u.GetLabels()
+		if current == nil {
+			current = make(map[string]string, len(labels))
+		}
+		for key, value := range labels {
+			current[key] = value
+		}
+		u.SetLabels(current)
+		return nil
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package manifest
+
+import (
+	"github.com/manifestival/manifestival"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
+)
+
+var _ = Describe("Manifest overrides", func() {
+	var resources []unstructured.Unstructured
+
+	transform := func(overrides *fusionv1alpha1.ManifestOverrides) manifestival.Manifest {
+		m, err := manifestival.ManifestFrom(manifestival.Slice(resources))
+		Expect(err).NotTo(HaveOccurred())
+		m, err = m.Transform(Transformers(overrides)...)
+		Expect(err).NotTo(HaveOccurred())
+		return m
+	}
+
+	find := func(m manifestival.Manifest, kind, name string) *unstructured.Unstructured {
+		for _, u := range m.Resources() {
+			if u.GetKind() == kind && u.GetName() == name {
+				return &u
+			}
+		}
+		Fail("missing " + kind + " " + name)
+		return nil
+	}
+
+	BeforeEach(func() {
+		resources = []unstructured.Unstructured{
+			{Object: map[string]any{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata":   map[string]any{"name": "manager", "namespace": "ibm-spectrum-scale-operator"},
+				"spec": map[string]any{"template": map[string]any{"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "manager", "image": "icr.io/cpopen/ibm-spectrum-scale-operator@sha256:abc"},
+						map[string]any{"name": "proxy", "image": "registry.redhat.io/openshift4/ose-kube-rbac-proxy:v4"},
+					},
+				}}},
+			}},
+			{Object: map[string]any{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]any{"name": kernelmodule.IBMManagerConfigName, "namespace": "ibm-spectrum-scale-operator"},
+				"data":       map[string]any{kernelmodule.IBMManagerConfigKey: "images:\n  coreInit: cp.icr.io/cp/spectrum/scale/ibm-spectrum-scale-core-init@sha256:def\n"},
+			}},
+			{Object: map[string]any{
+				"apiVersion": "scale.spectrum.ibm.com/v1beta1",
+				"kind":       "Cluster",
+				"metadata":   map[string]any{"name": "ibm-spectrum-scale"},
+				"spec":       map[string]any{"daemon": map[string]any{"nodeSelector": map[string]any{"a": "b"}}},
+			}},
+		}
+	})
+
+	It("should apply a strategic merge patch to the targeted objects", func() {
+		m := transform(&fusionv1alpha1.ManifestOverrides{Patches: []fusionv1alpha1.ManifestPatch{{
+			Target: fusionv1alpha1.ManifestPatchTarget{Group: "apps", Kind: "Deployment", Name: "manager"},
+			Patch: `
+spec:
+  template:
+    spec:
+      tolerations:
+      - key: storage
+        operator: Exists
+      containers:
+      - name: manager
+        resources:
+          limits:
+            memory: 1Gi
+`,
+		}}})
+
+		deployment := find(m, "Deployment", "manager")
+		containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+		Expect(containers).To(HaveLen(2))
+		Expect(containers[0]).To(HaveKeyWithValue("resources", map[string]any{"limits": map[string]any{"memory": "1Gi"}}))
+		Expect(containers[0]).To(HaveKeyWithValue("image", "icr.io/cpopen/ibm-spectrum-scale-operator@sha256:abc"))
+		tolerations, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "tolerations")
+		Expect(tolerations).To(HaveLen(1))
+	})
+
+	It("should apply JSON and merge patches, also to custom resources", func() {
+		m := transform(&fusionv1alpha1.ManifestOverrides{Patches: []fusionv1alpha1.ManifestPatch{
+			{
+				Target: fusionv1alpha1.ManifestPatchTarget{Kind: "Deployment"},
+				Type:   fusionv1alpha1.JSONPatch,
+				Patch:  `[{"op": "replace", "path": "/spec/template/spec/containers/1/name", "value": "rbac"}]`,
+			},
+			{
+				Target: fusionv1alpha1.ManifestPatchTarget{Kind: "Cluster", Name: "ibm-spectrum-scale"},
+				Type:   fusionv1alpha1.StrategicMergePatch,
+				Patch:  `{"spec": {"daemon": {"nodeSelector": {"a": null, "c": "d"}}}}`,
+			},
+		}})
+
+		containers, _, _ := unstructured.NestedSlice(find(m, "Deployment", "manager").Object,
+			"spec", "template", "spec", "containers")
+		Expect(containers[1]).To(HaveKeyWithValue("name", "rbac"))
+		nodeSelector, _, _ := unstructured.NestedStringMap(find(m, "Cluster", "ibm-spectrum-scale").Object,
+			"spec", "daemon", "nodeSelector")
+		Expect(nodeSelector).To(Equal(map[string]string{"c": "d"}))
+	})
+
+	It("should fail on a patch that does not apply", func() {
+		m, err := manifestival.ManifestFrom(manifestival.Slice(resources))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = m.Transform(Transformers(&fusionv1alpha1.ManifestOverrides{Patches: []fusionv1alpha1.ManifestPatch{{
+			Target: fusionv1alpha1.ManifestPatchTarget{Kind: "Cluster"},
+			Type:   fusionv1alpha1.JSONPatch,
+			Patch:  `[{"op": "remove", "path": "/spec/missing"}]`,
+		}}})...)
+		Expect(err).To(MatchError(ContainSubstring("failed to patch Cluster ibm-spectrum-scale")))
+	})
+
+	It("should replace the images and add the labels", func() {
+		m := transform(&fusionv1alpha1.ManifestOverrides{
+			Images: []fusionv1alpha1.ImageOverride{
+				{Source: "icr.io/cpopen", Mirror: "mirror.example.com/cpopen"},
+				{Source: "cp.icr.io/cp/spectrum/scale", Mirror: "mirror.example.com/scale"},
+				// Only whole path segments are replaced
+				{Source: "registry.redhat.io/openshift", Mirror: "mirror.example.com/openshift"},
+			},
+			Labels: map[string]string{"cost-center": "storage"},
+		})
+
+		containers, _, _ := unstructured.NestedSlice(find(m, "Deployment", "manager").Object,
+			"spec", "template", "spec", "containers")
+		Expect(containers[0]).To(HaveKeyWithValue("image", "mirror.example.com/cpopen/ibm-spectrum-scale-operator@sha256:abc"))
+		Expect(containers[1]).To(HaveKeyWithValue("image", "registry.redhat.io/openshift4/ose-kube-rbac-proxy:v4"))
+
+		config, _, _ := unstructured.NestedString(find(m, "ConfigMap", kernelmodule.IBMManagerConfigName).Object,
+			"data", kernelmodule.IBMManagerConfigKey)
+		Expect(config).To(Equal("images:\n  coreInit: mirror.example.com/scale/ibm-spectrum-scale-core-init@sha256:def\n"))
+
+		for _, u := range m.Resources() {
+			Expect(u.GetLabels()).To(HaveKeyWithValue("cost-center", "storage"))
+		}
+	})
+})
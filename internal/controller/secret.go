@@ -130,3 +130,14 @@ func updateEntitlementPullSecrets(secret []byte, ctx context.Context, cl client.
 	}
 	return nil
 }
+
+// deleteEntitlementPullSecrets deletes the secrets created by updateEntitlementPullSecrets
+func deleteEntitlementPullSecrets(ctx context.Context, cl client.Client, ns string) error {
+	for _, destNamespace := range IbmEntitlementSecrets(ns) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: IBMENTITLEMENTNAME, Namespace: destNamespace}}
+		if err := cl.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete secret in deleteEntitlementPullSecrets: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	mfc "github.com/manifestival/controller-runtime-client"
+	"github.com/manifestival/manifestival"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/console"
+	fsccontroller "github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/filesystemclaim"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/localvolumediscovery"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/manifest"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/events"
+)
+
+const (
+	// teardownFinalizer is set while spec.teardownOnDelete is true, it holds the deletion of the FusionAccess
+	// until the resources created by the operator are gone
+	teardownFinalizer = "fusion.storage.openshift.io/teardown"
+
+	// teardownRequeueDelay is the delay between two checks of a blocked or waiting teardown
+	teardownRequeueDelay = 15 * time.Second
+
+	conditionTypeTeardown = "Teardown"
+
+	eventTopicTeardown = "Teardown"
+
+	// The Storage Scale core pods run the GPFS daemons on the storage nodes
+	storageScaleNamespace         = "ibm-spectrum-scale"
+	storageScaleCorePodLabel      = "app.kubernetes.io/name"
+	storageScaleCorePodLabelValue = "core"
+
+	EventReasonTeardownBlocked    = "TeardownBlocked"
+	EventReasonTeardownInProgress = "TeardownInProgress"
+	EventReasonTeardownFailed     = "TeardownFailed"
+	EventReasonTeardownCompleted  = "TeardownCompleted"
+)
+
+// teardownStep removes one kind of resource created by the operator. It returns false while the resources are
+// still going away.
+type teardownStep struct {
+	name string
+	run  func(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, ns string) (bool, error)
+}
+
+// teardownSteps are run in order, each waits for the previous one: the Storage Scale core pods run the daemons
+// using the kernel module, so they must be gone before the kernel module is unloaded, and the entitlement secrets
+// the images are pulled with are removed last.
+func (r *FusionAccessReconciler) teardownSteps() []teardownStep {
+	return []teardownStep{
+		{"LocalVolumeDiscovery", func(ctx context.Context, _ *fusionv1alpha1.FusionAccess, ns string) (bool, error) {
+			return localvolumediscovery.DeleteLocalVolumeDiscovery(ctx, localvolumediscovery.NewLocalVolumeDiscovery(ns), r.Client)
+		}},
+		{"ConsolePlugin", func(ctx context.Context, _ *fusionv1alpha1.FusionAccess, _ string) (bool, error) {
+			if err := console.DisablePlugin(ctx, r.Client); err != nil {
+				return false, err
+			}
+			return true, console.DeletePlugin(ctx, r.Client)
+		}},
+		{"StorageScaleManifest", func(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, ns string) (bool, error) {
+			_, installPath, err := getIbmManifest(fusionaccess.Spec)
+			if err != nil {
+				return false, err
+			}
+			installManifest, err := loadIbmManifest(fusionaccess.Spec, installPath, manifestival.UseClient(mfc.NewClient(r.Client)))
+			if err != nil {
+				return false, err
+			}
+			if err := installManifest.Delete(); err != nil {
+				return false, err
+			}
+			if gone, err := r.storageScaleCorePodsGone(ctx); err != nil || !gone {
+				return false, err
+			}
+			return true, manifest.DeleteInventory(ctx, r.Client, ns)
+		}},
+		{"KernelModule", func(ctx context.Context, _ *fusionv1alpha1.FusionAccess, _ string) (bool, error) {
+			return kernelmodule.DeleteKMMResources(ctx, r.Client)
+		}},
+		{"PodDisruptionBudget", func(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess, _ string) (bool, error) {
+			return true, r.deletePodDisruptionBudget(ctx, podDisruptionBudgetName, fusionaccess.Namespace)
+		}},
+		{"EntitlementSecrets", func(ctx context.Context, _ *fusionv1alpha1.FusionAccess, ns string) (bool, error) {
+			return true, deleteEntitlementPullSecrets(ctx, r.Client, ns)
+		}},
+	}
+}
+
+// storageScaleCorePodsGone checks that the Storage Scale core pods, which use the kernel module, were deleted
+func (r *FusionAccessReconciler) storageScaleCorePodsGone(ctx context.Context) (bool, error) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(storageScaleNamespace),
+		client.MatchingLabels{storageScaleCorePodLabel: storageScaleCorePodLabelValue}); err != nil {
+		return false, fmt.Errorf("failed to list the Storage Scale core pods: %w", err)
+	}
+	return len(pods.Items) == 0, nil
+}
+
+// ensureTeardownFinalizer adds the teardown finalizer when spec.teardownOnDelete is set and removes it otherwise.
+// Returns true when the FusionAccess was updated.
+func (r *FusionAccessReconciler) ensureTeardownFinalizer(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) (bool, error) {
+	var changed bool
+	if fusionaccess.Spec.TeardownOnDelete {
+		changed = controllerutil.AddFinalizer(fusionaccess, teardownFinalizer)
+	} else {
+		changed = controllerutil.RemoveFinalizer(fusionaccess, teardownFinalizer)
+	}
+	if !changed {
+		return false, nil
+	}
+	if err := r.Update(ctx, fusionaccess); err != nil {
+		return false, fmt.Errorf("failed to update the teardown finalizer: %w", err)
+	}
+	return true, nil
+}
+
+// handleTeardown removes the resources created by the operator once the FusionAccess is deleted, then releases
+// it. Nothing is removed while FileSystemClaims exist, nor while Filesystems or LocalDisks exist: removing the
+// Storage Scale manifest deletes their CRDs, and with them the Filesystems and LocalDisks created without a
+// FileSystemClaim. Clearing spec.teardownOnDelete releases the FusionAccess without removing anything else.
+func (r *FusionAccessReconciler) handleTeardown(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	ns string,
+) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(fusionaccess, teardownFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	if !fusionaccess.Spec.TeardownOnDelete {
+		return ctrl.Result{}, r.removeTeardownFinalizer(ctx, fusionaccess)
+	}
+
+	claims := &fusionv1alpha1.FileSystemClaimList{}
+	if err := r.List(ctx, claims); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list FileSystemClaims: %w", err)
+	}
+	if len(claims.Items) > 0 {
+		names := make([]string, 0, len(claims.Items))
+		for i := range claims.Items {
+			names = append(names, claims.Items[i].Namespace+"/"+claims.Items[i].Name)
+		}
+		msg := fmt.Sprintf("Teardown is waiting for %d FileSystemClaims to be deleted: %s",
+			len(names), strings.Join(names, ", "))
+		return r.blockTeardown(ctx, fusionaccess, msg)
+	}
+
+	storageScaleResources, err := r.listStorageScaleResources(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(storageScaleResources) > 0 {
+		msg := fmt.Sprintf("Teardown is waiting for %d Storage Scale resources to be deleted, "+
+			"removing the Storage Scale manifest would delete them: %s",
+			len(storageScaleResources), strings.Join(storageScaleResources, ", "))
+		return r.blockTeardown(ctx, fusionaccess, msg)
+	}
+
+	for _, step := range r.teardownSteps() {
+		log.Log.Info("Running teardown step", "step", step.name)
+		done, err := step.run(ctx, fusionaccess, ns)
+		if err != nil {
+			msg := fmt.Sprintf("Failed to remove %s: %v", step.name, err)
+			r.events.Report(events.NewEvent(eventTopicTeardown, EventReasonTeardownFailed, msg), fusionaccess)
+			if serr := r.setTeardownCondition(ctx, fusionaccess, v1.ConditionFalse, EventReasonTeardownFailed, msg); serr != nil {
+				log.Log.Error(serr, "Failed to update the teardown condition")
+			}
+			return ctrl.Result{}, err
+		}
+		if !done {
+			msg := fmt.Sprintf("Waiting for %s to be removed", step.name)
+			if err := r.setTeardownCondition(ctx, fusionaccess, v1.ConditionFalse, EventReasonTeardownInProgress, msg); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: teardownRequeueDelay}, nil
+		}
+	}
+
+	msg := "Resources created by the operator were removed"
+	if err := r.setTeardownCondition(ctx, fusionaccess, v1.ConditionTrue, EventReasonTeardownCompleted, msg); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.events.Report(events.NewSuccessEvent(eventTopicTeardown, EventReasonTeardownCompleted, msg), fusionaccess)
+	if err := r.removeTeardownFinalizer(ctx, fusionaccess); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.events.Forget(fusionaccess)
+	return ctrl.Result{}, nil
+}
+
+// blockTeardown reports why nothing is removed yet and checks again later
+func (r *FusionAccessReconciler) blockTeardown(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	msg string,
+) (ctrl.Result, error) {
+	r.events.Report(events.NewEvent(eventTopicTeardown, EventReasonTeardownBlocked, msg), fusionaccess)
+	if err := r.setTeardownCondition(ctx, fusionaccess, v1.ConditionFalse, EventReasonTeardownBlocked, msg); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: teardownRequeueDelay}, nil
+}
+
+// listStorageScaleResources returns the Filesystems and LocalDisks left, as "Kind name". None are left when their
+// CRDs are not installed.
+func (r *FusionAccessReconciler) listStorageScaleResources(ctx context.Context) ([]string, error) {
+	var names []string
+	for _, gvk := range []schema.GroupVersionKind{
+		{Group: fsccontroller.FileSystemGroup, Version: fsccontroller.FileSystemVersion, Kind: fsccontroller.FileSystemList},
+		{Group: fsccontroller.LocalDiskGroup, Version: fsccontroller.LocalDiskVersion, Kind: fsccontroller.LocalDiskList},
+	} {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+		if err := r.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) || kerrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+		}
+		kind := strings.TrimSuffix(gvk.Kind, "List")
+		for i := range list.Items {
+			names = append(names, kind+" "+list.Items[i].GetNamespace()+"/"+list.Items[i].GetName())
+		}
+	}
+	return names, nil
+}
+
+func (r *FusionAccessReconciler) removeTeardownFinalizer(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) error {
+	if !controllerutil.RemoveFinalizer(fusionaccess, teardownFinalizer) {
+		return nil
+	}
+	if err := r.Update(ctx, fusionaccess); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to remove the teardown finalizer: %w", err)
+	}
+	log.Log.Info("Released FusionAccess", "name", fusionaccess.Name)
+	return nil
+}
+
+// setTeardownCondition records the progress of the teardown, the status is only written when it changed
+func (r *FusionAccessReconciler) setTeardownCondition(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	status v1.ConditionStatus,
+	reason, message string,
+) error {
+	if !meta.SetStatusCondition(&fusionaccess.Status.Conditions,
+		v1.Condition{Type: conditionTypeTeardown, Status: status, Reason: reason, Message: message}) {
+		return nil
+	}
+	fusionaccess.Status.Status = "Terminating"
+	return r.Status().Update(ctx, fusionaccess)
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	consolev1 "github.com/openshift/api/console/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	kmmv1beta1 "github.com/rh-ecosystem-edge/kernel-module-management/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/console"
+	fsccontroller "github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/filesystemclaim"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
+)
+
+var _ = Describe("FusionAccess teardown", func() {
+	const ns = "ibm-fusion-access-operator"
+
+	var (
+		ctx         = context.Background()
+		scheme      = createFakeScheme()
+		cnsaVersion string
+	)
+
+	newFusionAccess := func(teardown, deleted bool) *fusionv1alpha.FusionAccess {
+		fa := &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: "fusionaccess-object", Namespace: ns},
+			Spec: fusionv1alpha.FusionAccessSpec{
+				StorageScaleVersion: fusionv1alpha.StorageScaleVersions(cnsaVersion),
+				TeardownOnDelete:    teardown,
+			},
+		}
+		if deleted {
+			now := metav1.Now()
+			fa.DeletionTimestamp = &now
+			fa.Finalizers = []string{teardownFinalizer}
+		}
+		return fa
+	}
+
+	newReconciler := func(objs ...client.Object) *FusionAccessReconciler {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&fusionv1alpha.FusionAccess{}).
+			Build()
+		return &FusionAccessReconciler{Client: c, Scheme: scheme}
+	}
+
+	reconcileOnce := func(r *FusionAccessReconciler) reconcile.Result {
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKey{Name: "fusionaccess-object", Namespace: ns}})
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	BeforeEach(func() {
+		b, _ := os.ReadFile("../../CNSA_VERSION.txt")
+		cnsaVersion = strings.TrimSpace(string(b))
+		os.Setenv("DEPLOYMENT_NAMESPACE", ns)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("DEPLOYMENT_NAMESPACE")
+	})
+
+	It("should only hold the FusionAccess when teardown is requested", func() {
+		fa := newFusionAccess(true, false)
+		r := newReconciler(fa)
+
+		changed, err := r.ensureTeardownFinalizer(ctx, fa)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(fa.Finalizers).To(ConsistOf(teardownFinalizer))
+
+		fa.Spec.TeardownOnDelete = false
+		changed, err = r.ensureTeardownFinalizer(ctx, fa)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(fa.Finalizers).To(BeEmpty())
+	})
+
+	It("should wait for the FileSystemClaims to be deleted", func() {
+		lvd := &fusionv1alpha.LocalVolumeDiscovery{ObjectMeta: metav1.ObjectMeta{Name: "auto-discover-devices", Namespace: ns}}
+		fsc := &fusionv1alpha.FileSystemClaim{ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "ibm-spectrum-scale"}}
+		r := newReconciler(newFusionAccess(true, true), lvd, fsc)
+
+		result := reconcileOnce(r)
+		Expect(result.RequeueAfter).To(Equal(teardownRequeueDelay))
+
+		current := &fusionv1alpha.FusionAccess{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(newFusionAccess(true, true)), current)).To(Succeed())
+		cond := meta.FindStatusCondition(current.Status.Conditions, conditionTypeTeardown)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal(EventReasonTeardownBlocked))
+		Expect(cond.Message).To(ContainSubstring("ibm-spectrum-scale/data"))
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(lvd), &fusionv1alpha.LocalVolumeDiscovery{})).To(Succeed())
+	})
+
+	It("should wait for the Filesystems and LocalDisks created without a FileSystemClaim", func() {
+		lvd := &fusionv1alpha.LocalVolumeDiscovery{ObjectMeta: metav1.ObjectMeta{Name: "auto-discover-devices", Namespace: ns}}
+		localDisk := &unstructured.Unstructured{}
+		localDisk.SetGroupVersionKind(schema.GroupVersionKind{
+			Group: fsccontroller.LocalDiskGroup, Version: fsccontroller.LocalDiskVersion, Kind: fsccontroller.LocalDiskKind,
+		})
+		localDisk.SetName("manual-disk")
+		localDisk.SetNamespace("ibm-spectrum-scale")
+		r := newReconciler(newFusionAccess(true, true), lvd, localDisk)
+
+		result := reconcileOnce(r)
+		Expect(result.RequeueAfter).To(Equal(teardownRequeueDelay))
+
+		current := &fusionv1alpha.FusionAccess{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(newFusionAccess(true, true)), current)).To(Succeed())
+		cond := meta.FindStatusCondition(current.Status.Conditions, conditionTypeTeardown)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal(EventReasonTeardownBlocked))
+		Expect(cond.Message).To(ContainSubstring("LocalDisk ibm-spectrum-scale/manual-disk"))
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(lvd), &fusionv1alpha.LocalVolumeDiscovery{})).To(Succeed())
+	})
+
+	It("should remove the resources created by the operator and release the FusionAccess", func() {
+		objs := []client.Object{
+			newFusionAccess(true, true),
+			&fusionv1alpha.LocalVolumeDiscovery{ObjectMeta: metav1.ObjectMeta{Name: "auto-discover-devices", Namespace: ns}},
+			&consolev1.ConsolePlugin{ObjectMeta: metav1.ObjectMeta{Name: console.PluginName}},
+			&operatorv1.Console{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Spec:       operatorv1.ConsoleSpec{Plugins: []string{"other-plugin", console.PluginName}},
+			},
+			&kmmv1beta1.Module{ObjectMeta: metav1.ObjectMeta{Name: kernelmodule.KMMModuleName, Namespace: ns}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: kernelmodule.ConfigMapName, Namespace: ns}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: kernelmodule.KMMRegistryPushPullSecretName, Namespace: ns}},
+			&policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: podDisruptionBudgetName, Namespace: ns}},
+		}
+		for _, secretNs := range IbmEntitlementSecrets(ns) {
+			objs = append(objs, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: IBMENTITLEMENTNAME, Namespace: secretNs}})
+		}
+		r := newReconciler(objs...)
+
+		// Each waiting step takes a reconcile
+		for range 5 {
+			reconcileOnce(r)
+		}
+
+		err := r.Get(ctx, client.ObjectKey{Name: "fusionaccess-object", Namespace: ns}, &fusionv1alpha.FusionAccess{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		for _, obj := range objs[1:] {
+			if _, ok := obj.(*operatorv1.Console); ok {
+				continue
+			}
+			err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object))
+			Expect(errors.IsNotFound(err)).To(BeTrue(), "%T %s was not deleted", obj, obj.GetName())
+		}
+
+		clusterConsole := &operatorv1.Console{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "cluster"}, clusterConsole)).To(Succeed())
+		Expect(clusterConsole.Spec.Plugins).To(Equal([]string{"other-plugin"}))
+	})
+
+	It("should keep the kernel module until the Storage Scale core pods are gone", func() {
+		module := &kmmv1beta1.Module{ObjectMeta: metav1.ObjectMeta{Name: kernelmodule.KMMModuleName, Namespace: ns}}
+		corePod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "worker-0",
+			Namespace: storageScaleNamespace,
+			Labels:    map[string]string{storageScaleCorePodLabel: storageScaleCorePodLabelValue},
+		}}
+		r := newReconciler(newFusionAccess(true, true), module, corePod)
+
+		for range 5 {
+			reconcileOnce(r)
+		}
+
+		current := &fusionv1alpha.FusionAccess{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(newFusionAccess(true, true)), current)).To(Succeed())
+		cond := meta.FindStatusCondition(current.Status.Conditions, conditionTypeTeardown)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal(EventReasonTeardownInProgress))
+		Expect(cond.Message).To(ContainSubstring("StorageScaleManifest"))
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(module), &kmmv1beta1.Module{})).To(Succeed())
+
+		Expect(r.Delete(ctx, corePod)).To(Succeed())
+		for range 3 {
+			reconcileOnce(r)
+		}
+
+		Expect(errors.IsNotFound(r.Get(ctx, client.ObjectKeyFromObject(module), &kmmv1beta1.Module{}))).To(BeTrue())
+		err := r.Get(ctx, client.ObjectKey{Name: "fusionaccess-object", Namespace: ns}, &fusionv1alpha.FusionAccess{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should release the FusionAccess without teardown once it is no longer requested", func() {
+		lvd := &fusionv1alpha.LocalVolumeDiscovery{ObjectMeta: metav1.ObjectMeta{Name: "auto-discover-devices", Namespace: ns}}
+		r := newReconciler(newFusionAccess(false, true), lvd)
+
+		reconcileOnce(r)
+
+		err := r.Get(ctx, client.ObjectKey{Name: "fusionaccess-object", Namespace: ns}, &fusionv1alpha.FusionAccess{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(lvd), &fusionv1alpha.LocalVolumeDiscovery{})).To(Succeed())
+	})
+})
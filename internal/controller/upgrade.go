@@ -0,0 +1,314 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/manifestival/manifestival"
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	fusionv1alpha1 "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	fsccontroller "github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/filesystemclaim"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/controller/kernelmodule"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/events"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/utils"
+)
+
+const (
+	// upgradePreflightInterval is the delay before failed preflight checks are run again
+	upgradePreflightInterval = time.Minute
+
+	conditionTypeUpgradeProgressing = "UpgradeProgressing"
+	conditionTypeRollbackBlocked    = "RollbackBlocked"
+
+	// Preflight checks run before an upgrade
+	preflightCheckOpenShift        = "OpenShiftCompatibility"
+	preflightCheckFilesystemHealth = "FilesystemHealth"
+	preflightCheckKernelModule     = "KernelModuleImage"
+
+	eventTopicUpgrade = "StorageScaleUpgrade"
+
+	EventReasonUpgradePathBlocked      = "UpgradePathNotSupported"
+	EventReasonUpgradePreflightFailed  = "UpgradePreflightFailed"
+	EventReasonUpgradeAwaitingApproval = "UpgradeAwaitingApproval"
+	EventReasonUpgrading               = "Upgrading"
+	EventReasonUpgradeCompleted        = "UpgradeCompleted"
+	EventReasonUpgradeApplied          = "UpgradeApplied"
+)
+
+// reconcileUpgrade drives the upgrade of Storage Scale from spec.storageScaleVersion to the version bundled with
+// the operator. The upgrade path must be supported and the preflight checks must pass, then the upgrade waits
+// for spec.approvedStorageScaleVersion. Once approved, the PDB protecting the kernel module build is created and
+// spec.storageScaleVersion is moved to the new version, the manifest is applied by the next reconcile.
+// Nothing else is reconciled until the upgrade is approved: the operator only ships the manifest of the new version.
+func (r *FusionAccessReconciler) reconcileUpgrade(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	ns, version, installPath string,
+) (ctrl.Result, error) {
+	from := string(fusionaccess.Spec.StorageScaleVersion)
+	upgrade := fusionaccess.Status.Upgrade
+	if upgrade == nil || upgrade.FromVersion != from || upgrade.ToVersion != version {
+		upgrade = &fusionv1alpha1.StorageScaleUpgradeStatus{FromVersion: from, ToVersion: version}
+	}
+
+	if err := utils.IsStorageScaleUpgradeAllowed(from, version); err != nil {
+		upgrade.Phase = fusionv1alpha1.UpgradePhaseBlocked
+		upgrade.Message = err.Error()
+		r.events.Report(events.NewEvent(eventTopicUpgrade, EventReasonUpgradePathBlocked, err.Error()), fusionaccess)
+		return ctrl.Result{}, r.setUpgradeStatus(ctx, fusionaccess, upgrade, v1.ConditionFalse, EventReasonUpgradePathBlocked)
+	}
+
+	approved := string(fusionaccess.Spec.ApprovedStorageScaleVersion) == version
+
+	// The checks are run again when they failed and right before an approved upgrade starts
+	if upgrade.Phase != fusionv1alpha1.UpgradePhaseAwaitingApproval || approved {
+		upgrade.PreflightChecks = r.runUpgradePreflight(ctx, fusionaccess.Spec, ns, version, installPath)
+	}
+	if failed := failedPreflightChecks(upgrade.PreflightChecks); len(failed) > 0 {
+		upgrade.Phase = fusionv1alpha1.UpgradePhasePreflightFailed
+		upgrade.Message = fmt.Sprintf("Upgrade from %s to %s failed preflight checks: %s", from, version, strings.Join(failed, "; "))
+		r.events.Report(events.NewEvent(eventTopicUpgrade, EventReasonUpgradePreflightFailed, upgrade.Message), fusionaccess)
+		if err := r.setUpgradeStatus(ctx, fusionaccess, upgrade, v1.ConditionFalse, EventReasonUpgradePreflightFailed); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: upgradePreflightInterval}, nil
+	}
+
+	if !approved {
+		upgrade.Phase = fusionv1alpha1.UpgradePhaseAwaitingApproval
+		upgrade.Message = fmt.Sprintf("Upgrade from %s to %s passed the preflight checks, set spec.approvedStorageScaleVersion to %s to start it",
+			from, version, version)
+		r.events.Report(events.NewSuccessEvent(eventTopicUpgrade, EventReasonUpgradeAwaitingApproval, upgrade.Message), fusionaccess)
+		return ctrl.Result{}, r.setUpgradeStatus(ctx, fusionaccess, upgrade, v1.ConditionFalse, EventReasonUpgradeAwaitingApproval)
+	}
+
+	now := v1.Now()
+	upgrade.Phase = fusionv1alpha1.UpgradePhaseUpgrading
+	upgrade.Message = fmt.Sprintf("Upgrading from %s to %s", from, version)
+	upgrade.StartTime = &now
+	if err := r.setUpgradeStatus(ctx, fusionaccess, upgrade, v1.ConditionTrue, EventReasonUpgrading); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.events.Report(events.NewSuccessEvent(eventTopicUpgrade, EventReasonUpgrading, upgrade.Message), fusionaccess)
+	return r.updateStorageScaleVersion(ctx, fusionaccess, version)
+}
+
+// runUpgradePreflight checks that the new version supports this OpenShift version, that the Filesystems are
+// healthy and that the core image the kernel module is built from can be pulled
+func (r *FusionAccessReconciler) runUpgradePreflight(
+	ctx context.Context,
+	spec fusionv1alpha1.FusionAccessSpec,
+	ns, version, installPath string,
+) []fusionv1alpha1.UpgradePreflightCheck {
+	checks := []fusionv1alpha1.UpgradePreflightCheck{
+		{Name: preflightCheckOpenShift},
+		{Name: preflightCheckFilesystemHealth},
+		{Name: preflightCheckKernelModule},
+	}
+	checks[0].Passed, checks[0].Message = r.checkOpenShiftCompatibility(ctx, version)
+	checks[1].Passed, checks[1].Message = r.checkFilesystemHealth(ctx)
+	checks[2].Passed, checks[2].Message = r.checkKernelModuleImage(ctx, spec, ns, installPath)
+	for _, check := range checks {
+		log.Log.Info("Upgrade preflight check", "check", check.Name, "passed", check.Passed, "message", check.Message)
+	}
+	return checks
+}
+
+func (r *FusionAccessReconciler) checkOpenShiftCompatibility(ctx context.Context, version string) (bool, string) {
+	clusterVersion := &configv1.ClusterVersion{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "version"}, clusterVersion); err != nil {
+		return false, fmt.Sprintf("failed to get the ClusterVersion: %v", err)
+	}
+	ocpVersion, err := utils.GetCurrentClusterVersion(clusterVersion)
+	if err != nil {
+		return false, err.Error()
+	}
+	if !utils.IsOpenShiftSupported(version, *ocpVersion) {
+		return false, fmt.Sprintf("IBM Storage Scale %s does not support OpenShift %s", version, ocpVersion)
+	}
+	return true, fmt.Sprintf("IBM Storage Scale %s supports OpenShift %s", version, ocpVersion)
+}
+
+func (r *FusionAccessReconciler) checkFilesystemHealth(ctx context.Context) (bool, string) {
+	filesystems := &unstructured.UnstructuredList{}
+	filesystems.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   fsccontroller.FileSystemGroup,
+		Version: fsccontroller.FileSystemVersion,
+		Kind:    fsccontroller.FileSystemList,
+	})
+	if err := r.List(ctx, filesystems); err != nil {
+		if meta.IsNoMatchError(err) || kerrors.IsNotFound(err) {
+			return true, "No Filesystem found"
+		}
+		return false, fmt.Sprintf("failed to list Filesystems: %v", err)
+	}
+
+	var unhealthy []string
+	for i := range filesystems.Items {
+		if msg := filesystemHealthProblem(&filesystems.Items[i]); msg != "" {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (%s)", filesystems.Items[i].GetName(), msg))
+		}
+	}
+	if len(unhealthy) > 0 {
+		return false, fmt.Sprintf("Filesystems are not healthy: %s", strings.Join(unhealthy, ", "))
+	}
+	return true, fmt.Sprintf("%d Filesystems are healthy", len(filesystems.Items))
+}
+
+// filesystemHealthProblem returns why a Filesystem is not Success=True and Healthy=True, empty when it is
+func filesystemHealthProblem(fs *unstructured.Unstructured) string {
+	conds, _, _ := unstructured.NestedSlice(fs.Object, "status", "conditions")
+	status := make(map[string]string, len(conds))
+	for _, c := range conds {
+		if cond, ok := c.(map[string]any); ok {
+			condType, _ := cond["type"].(string)
+			condStatus, _ := cond["status"].(string)
+			status[condType] = condStatus
+		}
+	}
+	for _, condType := range []string{"Success", "Healthy"} {
+		if status[condType] != string(v1.ConditionTrue) {
+			return fmt.Sprintf("%s is not True", condType)
+		}
+	}
+	return ""
+}
+
+func (r *FusionAccessReconciler) checkKernelModuleImage(
+	ctx context.Context,
+	spec fusionv1alpha1.FusionAccessSpec,
+	ns, installPath string,
+) (bool, string) {
+	image, err := coreImageOfManifest(spec, installPath)
+	if err != nil {
+		return false, err.Error()
+	}
+	ok, err := r.CanPullImage(ctx, r.Client, ns, image, IBMENTITLEMENTNAME)
+	if !ok {
+		msg := fmt.Sprintf("core image %s cannot be pulled", image)
+		if err != nil {
+			msg = fmt.Sprintf("%s: %v", msg, err)
+		}
+		return false, msg
+	}
+	return true, fmt.Sprintf("core image %s can be pulled", image)
+}
+
+// coreImageOfManifest returns the core init image the kernel module of a Storage Scale manifest is built from,
+// once the overrides are applied
+func coreImageOfManifest(spec fusionv1alpha1.FusionAccessSpec, installPath string) (string, error) {
+	installManifest, err := loadIbmManifest(spec, installPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read manifest %s: %w", installPath, err)
+	}
+	configMaps := installManifest.Filter(
+		manifestival.ByKind("ConfigMap"),
+		manifestival.ByName(kernelmodule.IBMManagerConfigName),
+		func(u *unstructured.Unstructured) bool {
+			return u.GetNamespace() == kernelmodule.IBMManagerConfigNamespace
+		},
+	).Resources()
+	if len(configMaps) == 0 {
+		return "", fmt.Errorf("no ConfigMap %s in manifest %s", kernelmodule.IBMManagerConfigName, installPath)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(configMaps[0].Object, cm); err != nil {
+		return "", err
+	}
+	return kernelmodule.GetIBMCoreImageFromConfigMap(cm)
+}
+
+func failedPreflightChecks(checks []fusionv1alpha1.UpgradePreflightCheck) []string {
+	var failed []string
+	for _, check := range checks {
+		if !check.Passed {
+			failed = append(failed, fmt.Sprintf("%s: %s", check.Name, check.Message))
+		}
+	}
+	return failed
+}
+
+// syncUpgradeProgress follows an approved upgrade once its manifest is applied. Rollback is blocked from then
+// on, and the upgrade completes when the PDB protecting the kernel module build is gone.
+func (r *FusionAccessReconciler) syncUpgradeProgress(ctx context.Context, fusionaccess *fusionv1alpha1.FusionAccess) error {
+	upgrade := fusionaccess.Status.Upgrade
+	if upgrade == nil || upgrade.Phase != fusionv1alpha1.UpgradePhaseUpgrading ||
+		upgrade.ToVersion != string(fusionaccess.Spec.StorageScaleVersion) {
+		return nil
+	}
+
+	meta.SetStatusCondition(&fusionaccess.Status.Conditions, v1.Condition{
+		Type:    conditionTypeRollbackBlocked,
+		Status:  v1.ConditionTrue,
+		Reason:  EventReasonUpgradeApplied,
+		Message: fmt.Sprintf("IBM Storage Scale %s was applied, going back to %s is not supported", upgrade.ToVersion, upgrade.FromVersion),
+	})
+
+	pdb := &policyv1.PodDisruptionBudget{}
+	err := r.Get(ctx, client.ObjectKey{Name: podDisruptionBudgetName, Namespace: fusionaccess.Namespace}, pdb)
+	switch {
+	case err == nil:
+		upgrade.Message = fmt.Sprintf("Waiting for the kernel module of %s to be built", upgrade.ToVersion)
+		return r.setUpgradeStatus(ctx, fusionaccess, upgrade, v1.ConditionTrue, EventReasonUpgrading)
+	case !kerrors.IsNotFound(err):
+		return err
+	}
+
+	now := v1.Now()
+	upgrade.Phase = fusionv1alpha1.UpgradePhaseCompleted
+	upgrade.Message = fmt.Sprintf("Upgraded from %s to %s", upgrade.FromVersion, upgrade.ToVersion)
+	upgrade.CompletionTime = &now
+	if err := r.setUpgradeStatus(ctx, fusionaccess, upgrade, v1.ConditionFalse, EventReasonUpgradeCompleted); err != nil {
+		return err
+	}
+	r.events.Report(events.NewSuccessEvent(eventTopicUpgrade, EventReasonUpgradeCompleted, upgrade.Message), fusionaccess)
+	return nil
+}
+
+// setUpgradeStatus records the upgrade and its UpgradeProgressing condition
+func (r *FusionAccessReconciler) setUpgradeStatus(
+	ctx context.Context,
+	fusionaccess *fusionv1alpha1.FusionAccess,
+	upgrade *fusionv1alpha1.StorageScaleUpgradeStatus,
+	status v1.ConditionStatus,
+	reason string,
+) error {
+	fusionaccess.Status.Upgrade = upgrade
+	meta.SetStatusCondition(&fusionaccess.Status.Conditions, v1.Condition{
+		Type:    conditionTypeUpgradeProgressing,
+		Status:  status,
+		Reason:  reason,
+		Message: upgrade.Message,
+	})
+	return r.Status().Update(ctx, fusionaccess)
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	fusionv1alpha "github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+)
+
+var _ = Describe("Storage Scale upgrade", func() {
+	const (
+		ns        = "ibm-fusion-access-operator"
+		installed = "v5.2.3.1"
+	)
+
+	var (
+		ctx         = context.Background()
+		scheme      = createFakeScheme()
+		cnsaVersion string
+		installPath string
+		pullable    bool
+	)
+
+	newFusionAccess := func(approved string) *fusionv1alpha.FusionAccess {
+		return &fusionv1alpha.FusionAccess{
+			ObjectMeta: metav1.ObjectMeta{Name: "fusionaccess-object", Namespace: ns},
+			Spec: fusionv1alpha.FusionAccessSpec{
+				StorageScaleVersion:         installed,
+				ApprovedStorageScaleVersion: fusionv1alpha.StorageScaleVersions(approved),
+			},
+		}
+	}
+
+	newReconciler := func(objs ...client.Object) *FusionAccessReconciler {
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&fusionv1alpha.FusionAccess{}).
+			Build()
+		return &FusionAccessReconciler{
+			Client: c,
+			Scheme: scheme,
+			CanPullImage: func(_ context.Context, _ client.Client, _, image, _ string) (bool, error) {
+				if pullable {
+					return true, nil
+				}
+				return false, fmt.Errorf("cannot pull %s", image)
+			},
+		}
+	}
+
+	getFusionAccess := func(r *FusionAccessReconciler) *fusionv1alpha.FusionAccess {
+		fa := &fusionv1alpha.FusionAccess{}
+		Expect(r.Get(ctx, client.ObjectKey{Name: "fusionaccess-object", Namespace: ns}, fa)).To(Succeed())
+		return fa
+	}
+
+	BeforeEach(func() {
+		b, _ := os.ReadFile("../../CNSA_VERSION.txt")
+		cnsaVersion = strings.TrimSpace(string(b))
+		installPath = filepath.Join("../../files", cnsaVersion, "install.yaml")
+		pullable = true
+	})
+
+	It("should wait for approval once the preflight checks pass", func() {
+		r := newReconciler(newFusionAccess(""), newOCPVersion("4.18.3"))
+
+		result, err := r.reconcileUpgrade(ctx, getFusionAccess(r), ns, cnsaVersion, installPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeFalse())
+
+		fa := getFusionAccess(r)
+		Expect(string(fa.Spec.StorageScaleVersion)).To(Equal(installed))
+		Expect(fa.Status.Upgrade).NotTo(BeNil())
+		Expect(fa.Status.Upgrade.Phase).To(Equal(fusionv1alpha.UpgradePhaseAwaitingApproval))
+		Expect(fa.Status.Upgrade.PreflightChecks).To(HaveLen(3))
+		for _, check := range fa.Status.Upgrade.PreflightChecks {
+			Expect(check.Passed).To(BeTrue(), "%s: %s", check.Name, check.Message)
+		}
+		Expect(r.Get(ctx, client.ObjectKey{Name: podDisruptionBudgetName, Namespace: ns}, &policyv1.PodDisruptionBudget{})).NotTo(Succeed())
+	})
+
+	It("should not upgrade when a preflight check fails, even when approved", func() {
+		pullable = false
+		r := newReconciler(newFusionAccess(cnsaVersion), newOCPVersion("4.15.2"))
+
+		result, err := r.reconcileUpgrade(ctx, getFusionAccess(r), ns, cnsaVersion, installPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(upgradePreflightInterval))
+
+		fa := getFusionAccess(r)
+		Expect(string(fa.Spec.StorageScaleVersion)).To(Equal(installed))
+		Expect(fa.Status.Upgrade.Phase).To(Equal(fusionv1alpha.UpgradePhasePreflightFailed))
+		Expect(fa.Status.Upgrade.Message).To(ContainSubstring(preflightCheckOpenShift))
+		Expect(fa.Status.Upgrade.Message).To(ContainSubstring(preflightCheckKernelModule))
+	})
+
+	It("should block an unsupported upgrade path", func() {
+		fa := newFusionAccess("")
+		fa.Spec.StorageScaleVersion = "v5.2.2.1"
+		r := newReconciler(fa, newOCPVersion("4.18.3"))
+
+		_, err := r.reconcileUpgrade(ctx, fa, ns, cnsaVersion, installPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		current := getFusionAccess(r)
+		Expect(current.Status.Upgrade.Phase).To(Equal(fusionv1alpha.UpgradePhaseBlocked))
+		Expect(current.Status.Upgrade.PreflightChecks).To(BeEmpty())
+	})
+
+	It("should start an approved upgrade and complete it once the kernel module is built", func() {
+		r := newReconciler(newFusionAccess(cnsaVersion), newOCPVersion("4.18.3"))
+
+		result, err := r.reconcileUpgrade(ctx, getFusionAccess(r), ns, cnsaVersion, installPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Requeue).To(BeTrue())
+
+		fa := getFusionAccess(r)
+		Expect(string(fa.Spec.StorageScaleVersion)).To(Equal(cnsaVersion))
+		Expect(fa.Status.Upgrade.Phase).To(Equal(fusionv1alpha.UpgradePhaseUpgrading))
+		Expect(fa.Status.Upgrade.StartTime).NotTo(BeNil())
+		Expect(meta.IsStatusConditionTrue(fa.Status.Conditions, conditionTypeUpgradeProgressing)).To(BeTrue())
+		pdbKey := client.ObjectKey{Name: podDisruptionBudgetName, Namespace: ns}
+		Expect(r.Get(ctx, pdbKey, &policyv1.PodDisruptionBudget{})).To(Succeed())
+
+		// The manifest of the new version was applied and the kernel module is being built
+		Expect(r.syncUpgradeProgress(ctx, fa)).To(Succeed())
+		fa = getFusionAccess(r)
+		Expect(fa.Status.Upgrade.Phase).To(Equal(fusionv1alpha.UpgradePhaseUpgrading))
+		Expect(meta.IsStatusConditionTrue(fa.Status.Conditions, conditionTypeRollbackBlocked)).To(BeTrue())
+
+		Expect(r.deletePodDisruptionBudget(ctx, podDisruptionBudgetName, ns)).To(Succeed())
+		Expect(errors.IsNotFound(r.Get(ctx, pdbKey, &policyv1.PodDisruptionBudget{}))).To(BeTrue())
+		Expect(r.syncUpgradeProgress(ctx, fa)).To(Succeed())
+		fa = getFusionAccess(r)
+		Expect(fa.Status.Upgrade.Phase).To(Equal(fusionv1alpha.UpgradePhaseCompleted))
+		Expect(fa.Status.Upgrade.CompletionTime).NotTo(BeNil())
+		Expect(meta.IsStatusConditionFalse(fa.Status.Conditions, conditionTypeUpgradeProgressing)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(fa.Status.Conditions, conditionTypeRollbackBlocked)).To(BeTrue())
+	})
+
+	It("should report the Filesystems that are not healthy", func() {
+		fs := &unstructured.Unstructured{Object: map[string]any{
+			"status": map[string]any{
+				"conditions": []any{
+					map[string]any{"type": "Success", "status": "True"},
+					map[string]any{"type": "Healthy", "status": "False"},
+				},
+			},
+		}}
+		Expect(filesystemHealthProblem(fs)).To(Equal("Healthy is not True"))
+
+		unstructured.RemoveNestedField(fs.Object, "status", "conditions")
+		Expect(filesystemHealthProblem(fs)).To(Equal("Success is not True"))
+	})
+})
@@ -2,6 +2,7 @@ package devicefinder
 
 import (
 	"github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -13,6 +14,7 @@ type MockAPIUpdater struct {
 	MockUpdateDiscoveryResultStatus func(lvdr *v1alpha1.LocalVolumeDiscoveryResult) error
 	MockUpdateDiscoveryResult       func(lvdr *v1alpha1.LocalVolumeDiscoveryResult) error
 	MockGetLocalVolumeDiscovery     func(name, namespace string) (*v1alpha1.LocalVolumeDiscovery, error)
+	MockListLocalDisks              func() ([]unstructured.Unstructured, error)
 }
 
 var _ ApiUpdater = &MockAPIUpdater{}
@@ -65,3 +67,12 @@ func (f *MockAPIUpdater) GetLocalVolumeDiscovery(name, namespace string) (*v1alp
 
 	return &v1alpha1.LocalVolumeDiscovery{}, nil
 }
+
+// ListLocalDisks mocks ListLocalDisks
+func (f *MockAPIUpdater) ListLocalDisks() ([]unstructured.Unstructured, error) {
+	if f.MockListLocalDisks != nil {
+		return f.MockListLocalDisks()
+	}
+
+	return nil, nil
+}
@@ -6,7 +6,10 @@ import (
 	"os"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
@@ -20,6 +23,9 @@ import (
 
 const componentName = "local-storage-devicefinder"
 
+// localDiskListGVK lists the Storage Scale LocalDisks
+var localDiskListGVK = schema.GroupVersionKind{Group: "scale.spectrum.ibm.com", Version: "v1beta1", Kind: "LocalDiskList"}
+
 type ApiUpdater interface {
 	recordEvent(obj runtime.Object, e *DiskEvent)
 	CreateDiscoveryResult(lvdr *v1alpha1.LocalVolumeDiscoveryResult) error
@@ -27,6 +33,7 @@ type ApiUpdater interface {
 	UpdateDiscoveryResultStatus(lvdr *v1alpha1.LocalVolumeDiscoveryResult) error
 	UpdateDiscoveryResult(lvdr *v1alpha1.LocalVolumeDiscoveryResult) error
 	GetLocalVolumeDiscovery(name, namespace string) (*v1alpha1.LocalVolumeDiscovery, error)
+	ListLocalDisks() ([]unstructured.Unstructured, error)
 }
 
 type sdkAPIUpdater struct {
@@ -128,3 +135,16 @@ func (s *sdkAPIUpdater) GetLocalVolumeDiscovery(
 	)
 	return discoveryCR, err
 }
+
+// ListLocalDisks lists the LocalDisks of all namespaces, none when Storage Scale is not installed yet
+func (s *sdkAPIUpdater) ListLocalDisks() ([]unstructured.Unstructured, error) {
+	localDisks := &unstructured.UnstructuredList{}
+	localDisks.SetGroupVersionKind(localDiskListGVK)
+	if err := s.client.List(context.TODO(), localDisks); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return localDisks.Items, nil
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"reflect"
+	"strings"
 	"syscall"
 	"time"
 
@@ -25,6 +26,7 @@ const (
 	resultCRName                  = "discovery-result-%s"
 )
 
+// supportedDeviceTypes are the types discovered when the LocalVolumeDiscovery does not list any
 var supportedDeviceTypes = sets.NewString("mpath", "disk")
 
 // DeviceDiscovery instance
@@ -32,7 +34,9 @@ type DeviceDiscovery struct {
 	apiClient            devicefinder.ApiUpdater
 	eventSync            *devicefinder.EventReporter
 	disks                []v1alpha1.DiscoveredDevice
+	ignoredDisks         []v1alpha1.IgnoredDevice
 	localVolumeDiscovery *v1alpha1.LocalVolumeDiscovery
+	filter               *deviceFilter
 }
 
 // NewDeviceDiscovery returns a new DeviceDiscovery instance
@@ -62,6 +66,13 @@ func NewDeviceDiscovery() (*DeviceDiscovery, error) {
 		return &DeviceDiscovery{}, err
 	}
 	dd.localVolumeDiscovery = lvd
+
+	dd.filter, err = loadDeviceFilter()
+	if err != nil {
+		// Exiting would crash loop the daemon, the LocalVolumeDiscovery reports the invalid filters
+		klog.Error(err, "failed to load the discovery filters, no devices are discovered")
+		dd.filter = failClosedDeviceFilter(err)
+	}
 	return dd, nil
 }
 
@@ -131,13 +142,15 @@ func (discovery *DeviceDiscovery) discoverDevices() error {
 
 	klog.Infof("valid block devices: %+v", validDevices)
 
-	discoveredDisks := getDiscoverdDevices(validDevices)
+	discoveredDisks, ignoredDisks := getDiscoverdDevices(validDevices, discovery.filter)
+	discovery.markDevicesInUse(discoveredDisks)
 	klog.Infof("discovered devices: %+v", discoveredDisks)
 
 	// Update discovered devices in the  LocalVolumeDiscoveryResult resource
-	if !reflect.DeepEqual(discovery.disks, discoveredDisks) {
+	if !reflect.DeepEqual(discovery.disks, discoveredDisks) || !reflect.DeepEqual(discovery.ignoredDisks, ignoredDisks) {
 		klog.Info("device list updated. Updating LocalVolumeDiscoveryResult status...")
 		discovery.disks = discoveredDisks
+		discovery.ignoredDisks = ignoredDisks
 		err = discovery.updateStatus()
 		if err != nil {
 			message := "failed to update LocalVolumeDiscoveryResult status"
@@ -172,22 +185,33 @@ func getValidBlockDevices() ([]diskutils.BlockDevice, error) {
 	return lDevices.BlockDevices, nil
 }
 
-// getDiscoverdDevices creates v1alpha1.DiscoveredDevice from diskutil.BlockDevices
-func getDiscoverdDevices(blockDevices []diskutils.BlockDevice) []v1alpha1.DiscoveredDevice {
+// getDiscoverdDevices creates v1alpha1.DiscoveredDevice from diskutil.BlockDevices, keeping the ones the
+// filter selects. The other devices are returned as v1alpha1.IgnoredDevice.
+func getDiscoverdDevices(
+	blockDevices []diskutils.BlockDevice,
+	filter *deviceFilter,
+) ([]v1alpha1.DiscoveredDevice, []v1alpha1.IgnoredDevice) {
 	discoveredDevices := make([]v1alpha1.DiscoveredDevice, 0)
+	var ignoredDevices []v1alpha1.IgnoredDevice
+	mpathPaths := getMultipathPaths(blockDevices)
 	for idx := range blockDevices {
-		if ignoreDevices(&blockDevices[idx]) {
-			continue
-		}
+		reason, message := ignoreDevices(&blockDevices[idx], filter.deviceTypes)
 		deviceID, err := blockDevices[idx].GetPathByID()
 		if err != nil {
-			klog.Warningf(
-				"failed to get persistent ID for the device %q. Error %v",
-				blockDevices[idx].Name,
-				err,
-			)
+			if reason == "" {
+				klog.Warningf(
+					"failed to get persistent ID for the device %q. Error %v",
+					blockDevices[idx].Name,
+					err,
+				)
+			}
 			deviceID = ""
 		}
+		if reason == "" {
+			if message = filter.excludes(&blockDevices[idx], deviceID); message != "" {
+				reason = v1alpha1.IgnoredExcluded
+			}
+		}
 
 		path, err := blockDevices[idx].GetDevPath()
 		if err != nil {
@@ -197,6 +221,17 @@ func getDiscoverdDevices(blockDevices []diskutils.BlockDevice) []v1alpha1.Discov
 				err,
 			)
 		}
+		if reason != "" {
+			klog.Infof("ignoring device %q: %s", blockDevices[idx].Name, message)
+			ignoredDevices = append(ignoredDevices, v1alpha1.IgnoredDevice{
+				Path:     path,
+				DeviceID: deviceID,
+				WWN:      blockDevices[idx].WWN,
+				Reason:   reason,
+				Message:  message,
+			})
+			continue
+		}
 		discoveredDevice := v1alpha1.DiscoveredDevice{
 			Path:     path,
 			Model:    blockDevices[idx].Model,
@@ -206,9 +241,45 @@ func getDiscoverdDevices(blockDevices []diskutils.BlockDevice) []v1alpha1.Discov
 			Size:     blockDevices[idx].Size,
 			WWN:      blockDevices[idx].WWN,
 		}
+		setDeviceAttributes(&discoveredDevice, &blockDevices[idx], mpathPaths)
 		discoveredDevices = append(discoveredDevices, discoveredDevice)
 	}
-	return uniqueDevices(discoveredDevices)
+	return uniqueDevices(discoveredDevices), ignoredDevices
+}
+
+// setDeviceAttributes copies the attributes read by lsblk from sysfs to the discovered device
+func setDeviceAttributes(
+	device *v1alpha1.DiscoveredDevice,
+	dev *diskutils.BlockDevice,
+	mpathPaths map[string][]v1alpha1.MultipathPath,
+) {
+	device.Property = v1alpha1.NonRotational
+	if dev.Rotational {
+		device.Property = v1alpha1.Rotational
+	}
+	device.Transport = dev.Transport
+	device.Serial = strings.TrimSpace(dev.Serial)
+	device.LogicalSectorSize = dev.LogSec
+	device.PhysicalSectorSize = dev.PhySec
+	device.DiscardSupported = dev.DiscMax > 0
+	if dev.FSType == "mpath_member" && len(dev.Children) != 0 {
+		device.MultipathPaths = mpathPaths[dev.Children[0].KName]
+	}
+}
+
+// getMultipathPaths maps the kernel name of the multipath devices to their member paths. lsblk lists the
+// members at the top level, each with the multipath device as its child.
+func getMultipathPaths(blockDevices []diskutils.BlockDevice) map[string][]v1alpha1.MultipathPath {
+	mpathPaths := make(map[string][]v1alpha1.MultipathPath)
+	for idx := range blockDevices {
+		dev := &blockDevices[idx]
+		if dev.FSType != "mpath_member" || len(dev.Children) == 0 {
+			continue
+		}
+		mpath := dev.Children[0].KName
+		mpathPaths[mpath] = append(mpathPaths[mpath], v1alpha1.MultipathPath{Path: dev.Path, State: dev.State})
+	}
+	return mpathPaths
 }
 
 // uniqueDevices removes duplicate devices from the list using WWN as a key
@@ -228,76 +299,66 @@ func uniqueDevices(sample []v1alpha1.DiscoveredDevice) []v1alpha1.DiscoveredDevi
 	return unique
 }
 
-// ignoreDevices checks if a device should be ignored during discovery
-func ignoreDevices(dev *diskutils.BlockDevice) bool {
+// ignoreDevices checks if a device should be ignored during discovery, deviceTypes being the types to discover.
+// It returns the reason with an explanation, or an empty reason when the device is usable.
+func ignoreDevices(dev *diskutils.BlockDevice, deviceTypes sets.String) (v1alpha1.DeviceIgnoredReason, string) {
 	if dev.ReadOnly {
-		klog.Infof("ignoring read only device %q", dev.Name)
-		return true
+		return v1alpha1.IgnoredReadOnly, "read only device"
 	}
 
 	if dev.State == diskutils.StateSuspended {
-		klog.Infof("ignoring device %q with invalid state %q", dev.Name, dev.State)
-		return true
+		return v1alpha1.IgnoredSuspended, fmt.Sprintf("invalid state %q", dev.State)
 	}
 
-	if !supportedDeviceTypes.Has(dev.Type) {
-		klog.Infof("ignoring device %q with unsupported type %q", dev.Name, dev.Type)
-		return true
+	if !deviceTypes.Has(dev.Type) {
+		return v1alpha1.IgnoredUnsupportedType, fmt.Sprintf("unsupported type %q", dev.Type)
 	}
 
 	if dev.Removable {
-		klog.Infof("ignoring device %s with removable capability", dev.Name)
-		return true
+		return v1alpha1.IgnoredRemovable, "removable capability"
 	}
 
 	if dev.BiosPartition() {
-		klog.Infof("ignoring device %q with partion with bios/boot label", dev.Name)
-		return true
+		return v1alpha1.IgnoredBootDevice, "partition with bios/boot label"
 	}
 
 	if dev.Mountpoint != "" {
-		klog.Infof("ignoring device %q with at least one mountpoint", dev.Mountpoint)
-		return true
+		return v1alpha1.IgnoredMounted, fmt.Sprintf("mounted on %s", dev.Mountpoint)
 	}
 
 	if dev.Size == 0 {
-		klog.Infof("ignoring device %q with 0 size", dev.Name)
-		return true
+		return v1alpha1.IgnoredZeroSize, "0 size"
 	}
 
 	if dev.WWN == "" {
-		klog.Infof("ignoring device %q without WWN", dev.Name)
-		return true
+		return v1alpha1.IgnoredNoWWN, "no WWN"
 	}
 
 	if dev.FSType != "" && dev.FSType != "mpath_member" {
-		klog.Infof("ignoring device %q with FS", dev.FSType)
-		return true
+		return v1alpha1.IgnoredHasFilesystem, fmt.Sprintf("%s filesystem", dev.FSType)
 	}
 	// Ignore childrens which has partiton/fs on them
 	if dev.Children != nil {
 		for idx := range dev.Children {
 			if dev.Children[idx].Type == "part" {
-				klog.Infof("ignoring device %q with partitions", dev.Children[idx].Name)
-				return true
+				return v1alpha1.IgnoredHasPartitions, fmt.Sprintf("partition %s", dev.Children[idx].Name)
 			}
 			if dev.Children[idx].FSType != "" {
-				klog.Infof(
-					"ignoring device %q with filesystem %s",
-					dev.Children[idx].Name,
+				return v1alpha1.IgnoredHasFilesystem, fmt.Sprintf(
+					"%s filesystem on %s",
 					dev.Children[idx].FSType,
+					dev.Children[idx].Name,
 				)
-				return true
 			}
 			if dev.Children[idx].Children != nil {
 				for idx2 := range dev.Children[idx].Children {
-					return ignoreDevices(&dev.Children[idx].Children[idx2])
+					return ignoreDevices(&dev.Children[idx].Children[idx2], deviceTypes)
 				}
 			}
 		}
 	}
 
-	return false
+	return "", ""
 }
 
 func parseDeviceType(deviceType string) v1alpha1.DiscoveredDeviceType {
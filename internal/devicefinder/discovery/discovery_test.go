@@ -67,55 +67,83 @@ var _ = Describe("Device Discovery", func() {
 		It(
 			"should have the correct number of discovered disks with multipath (input data 1)",
 			func() {
-				discoveredDisks := getDiscoverdDevices(deviceList2Disk2MultiPath.BlockDevices)
+				discoveredDisks, _ := getDiscoverdDevices(deviceList2Disk2MultiPath.BlockDevices, defaultDeviceFilter())
 				Expect(discoveredDisks).To(HaveLen(4))
 			},
 		)
 		It("should have the correct disks with multipath (input data 1)", func() {
-			discoveredDisks := getDiscoverdDevices(deviceList2Disk2MultiPath.BlockDevices)
+			discoveredDisks, _ := getDiscoverdDevices(deviceList2Disk2MultiPath.BlockDevices, defaultDeviceFilter())
 
 			Expect(discoveredDisks).To(ContainElement(
 				v1alpha1.DiscoveredDevice{
-					DeviceID: "/dev/disk/by-id/dm-name-mpathb",
-					Path:     "/dev/dm-1",
-					Model:    "iscsi_disk1",
-					Type:     "disk",
-					Vendor:   "LIO-ORG ",
-					Size:     75161927680,
-					WWN:      "0x6001405c595842b2d484d0bb11e42179",
+					DeviceID:           "/dev/disk/by-id/dm-name-mpathb",
+					Path:               "/dev/dm-1",
+					Model:              "iscsi_disk1",
+					Type:               "disk",
+					Vendor:             "LIO-ORG ",
+					Size:               75161927680,
+					WWN:                "0x6001405c595842b2d484d0bb11e42179",
+					Property:           v1alpha1.Rotational,
+					Transport:          "iscsi",
+					Serial:             "c595842b-2d48-4d0b-b11e-42179674b55a",
+					LogicalSectorSize:  512,
+					PhysicalSectorSize: 512,
+					MultipathPaths: []v1alpha1.MultipathPath{
+						{Path: "/dev/sdf", State: "running"},
+						{Path: "/dev/sdh", State: "running"},
+					},
 				},
 			))
 			Expect(discoveredDisks).To(ContainElement(
 				v1alpha1.DiscoveredDevice{
-					DeviceID: "/dev/disk/by-id/dm-name-mpatha",
-					Path:     "/dev/dm-0",
-					Model:    "iscsi_disk2",
-					Type:     "disk",
-					Vendor:   "LIO-ORG ",
-					Size:     85899345920,
-					WWN:      "0x60014056ade16393c8f412da451430e4",
+					DeviceID:           "/dev/disk/by-id/dm-name-mpatha",
+					Path:               "/dev/dm-0",
+					Model:              "iscsi_disk2",
+					Type:               "disk",
+					Vendor:             "LIO-ORG ",
+					Size:               85899345920,
+					WWN:                "0x60014056ade16393c8f412da451430e4",
+					Property:           v1alpha1.Rotational,
+					Transport:          "iscsi",
+					Serial:             "6ade1639-3c8f-412d-a451-430e41087461",
+					LogicalSectorSize:  512,
+					PhysicalSectorSize: 512,
+					MultipathPaths: []v1alpha1.MultipathPath{
+						{Path: "/dev/sdg", State: "running"},
+						{Path: "/dev/sdi", State: "running"},
+					},
 				},
 			))
 			Expect(discoveredDisks).To(ContainElement(
 				v1alpha1.DiscoveredDevice{
-					DeviceID: "/dev/disk/by-id/scsi-35000c50015ff75aa",
-					Path:     "/dev/sdb",
-					Model:    "QEMU HARDDISK",
-					Type:     "disk",
-					Vendor:   "QEMU    ",
-					Size:     10737418240,
-					WWN:      "0x5000c50015ff75aa",
+					DeviceID:           "/dev/disk/by-id/scsi-35000c50015ff75aa",
+					Path:               "/dev/sdb",
+					Model:              "QEMU HARDDISK",
+					Type:               "disk",
+					Vendor:             "QEMU    ",
+					Size:               10737418240,
+					WWN:                "0x5000c50015ff75aa",
+					Property:           v1alpha1.Rotational,
+					Serial:             "thirddisk",
+					LogicalSectorSize:  512,
+					PhysicalSectorSize: 512,
+					DiscardSupported:   true,
 				},
 			))
 			Expect(discoveredDisks).To(ContainElement(
 				v1alpha1.DiscoveredDevice{
-					DeviceID: "/dev/disk/by-id/scsi-35000c50015ea75bb",
-					Path:     "/dev/sde",
-					Model:    "QEMU HARDDISK",
-					Type:     "disk",
-					Vendor:   "QEMU    ",
-					Size:     53687091200,
-					WWN:      "0x5000c50015ea75bb",
+					DeviceID:           "/dev/disk/by-id/scsi-35000c50015ea75bb",
+					Path:               "/dev/sde",
+					Model:              "QEMU HARDDISK",
+					Type:               "disk",
+					Vendor:             "QEMU    ",
+					Size:               53687091200,
+					WWN:                "0x5000c50015ea75bb",
+					Property:           v1alpha1.Rotational,
+					Serial:             "seconddisk",
+					LogicalSectorSize:  512,
+					PhysicalSectorSize: 512,
+					DiscardSupported:   true,
 				},
 			))
 
@@ -124,7 +152,7 @@ var _ = Describe("Device Discovery", func() {
 		It(
 			"should have the correct number of discovered disks with multipath (input data 2)",
 			func() {
-				discoveredDisks := getDiscoverdDevices(deviceList0Disk0MultiPath.BlockDevices)
+				discoveredDisks, _ := getDiscoverdDevices(deviceList0Disk0MultiPath.BlockDevices, defaultDeviceFilter())
 				Expect(discoveredDisks).To(BeEmpty())
 			},
 		)
@@ -132,44 +160,56 @@ var _ = Describe("Device Discovery", func() {
 		It(
 			"should have the correct number of discovered disks without multipath (input data 3)",
 			func() {
-				discoveredDisks := getDiscoverdDevices(deviceList7Disk.BlockDevices)
+				discoveredDisks, _ := getDiscoverdDevices(deviceList7Disk.BlockDevices, defaultDeviceFilter())
 				Expect(discoveredDisks).To(HaveLen(2))
 			},
 		)
 
 		It("should have the correct disks without multipath (input data 3)", func() {
-			discoveredDisks := getDiscoverdDevices(deviceList7Disk.BlockDevices)
+			discoveredDisks, _ := getDiscoverdDevices(deviceList7Disk.BlockDevices, defaultDeviceFilter())
 			Expect(discoveredDisks).To(ContainElement(
 				v1alpha1.DiscoveredDevice{
-					DeviceID: "",
-					Path:     "/dev/sdk",
-					Model:    "LUN C-Mode",
-					Type:     "disk",
-					Vendor:   "NETAPP  ",
-					Size:     4294967296,
-					WWN:      "0x600a098038304437415d4b6a5968624d",
+					DeviceID:           "",
+					Path:               "/dev/sdk",
+					Model:              "LUN C-Mode",
+					Type:               "disk",
+					Vendor:             "NETAPP  ",
+					Size:               4294967296,
+					WWN:                "0x600a098038304437415d4b6a5968624d",
+					Property:           v1alpha1.NonRotational,
+					Transport:          "fc",
+					Serial:             "80D7A\\x5dKjYhbM",
+					LogicalSectorSize:  512,
+					PhysicalSectorSize: 4096,
+					DiscardSupported:   true,
 				},
 			))
 			Expect(discoveredDisks).To(ContainElement(
 				v1alpha1.DiscoveredDevice{
-					DeviceID: "",
-					Path:     "/dev/sdj",
-					Model:    "LUN C-Mode",
-					Type:     "disk",
-					Vendor:   "NETAPP  ",
-					Size:     1288490188800,
-					WWN:      "0x600a098038304437415d4b6a5968624f",
+					DeviceID:           "",
+					Path:               "/dev/sdj",
+					Model:              "LUN C-Mode",
+					Type:               "disk",
+					Vendor:             "NETAPP  ",
+					Size:               1288490188800,
+					WWN:                "0x600a098038304437415d4b6a5968624f",
+					Property:           v1alpha1.NonRotational,
+					Transport:          "fc",
+					Serial:             "80D7A\\x5dKjYhbO",
+					LogicalSectorSize:  512,
+					PhysicalSectorSize: 4096,
+					DiscardSupported:   true,
 				},
 			))
 		})
 
 		It("should have the correct number of discovered disks (input data 4)", func() {
-			discoveredDisks := getDiscoverdDevices(deviceList0Disk.BlockDevices)
+			discoveredDisks, _ := getDiscoverdDevices(deviceList0Disk.BlockDevices, defaultDeviceFilter())
 			Expect(discoveredDisks).To(BeEmpty())
 		})
 
 		It("should have the correct number of discovered disks (san disk env)", func() {
-			discoveredDisks := getDiscoverdDevices(deviceListSanDisk.BlockDevices)
+			discoveredDisks, _ := getDiscoverdDevices(deviceListSanDisk.BlockDevices, defaultDeviceFilter())
 			Expect(discoveredDisks).To(BeEmpty())
 		})
 
@@ -180,10 +220,37 @@ var _ = Describe("Device Discovery", func() {
 			err = json.Unmarshal(LsblkOut0Disk0MultiPath0DM, &deviceList0Disk0MultiPath0DM)
 			Expect(err).To(Not(HaveOccurred()))
 
-			discoveredDisks := getDiscoverdDevices(deviceList0Disk0MultiPath0DM.BlockDevices)
+			discoveredDisks, _ := getDiscoverdDevices(deviceList0Disk0MultiPath0DM.BlockDevices, defaultDeviceFilter())
 			Expect(discoveredDisks).To(BeEmpty())
 
 		})
 
 	})
+
+	Context("When devices are not usable", func() {
+		It("should report them with the reason", func() {
+			blockDevices := []diskutils.BlockDevice{
+				{Name: "sda", KName: "sda", Path: "/dev/sda", Type: "disk", Size: 1 << 30, WWN: "0xa", Children: []diskutils.BlockDevice{
+					{Name: "sda1", KName: "sda1", Type: "part", Size: 1 << 20},
+				}},
+				{Name: "sdb", KName: "sdb", Path: "/dev/sdb", Type: "disk", Size: 1 << 30, WWN: "0xb", FSType: "xfs", PathByID: "wwn-0xb"},
+				{Name: "sdc", KName: "sdc", Path: "/dev/sdc", Type: "disk", Size: 1 << 30},
+				{Name: "sr0", KName: "sr0", Path: "/dev/sr0", Type: "rom", Size: 1 << 30, ReadOnly: true},
+				{Name: "sdd", KName: "sdd", Path: "/dev/sdd", Type: "disk", Size: 1 << 30, WWN: "0xd", PathByID: "wwn-0xd"},
+			}
+
+			discoveredDisks, ignoredDisks := getDiscoverdDevices(blockDevices, defaultDeviceFilter())
+			Expect(discoveredDisks).To(HaveLen(1))
+			Expect(discoveredDisks[0].WWN).To(Equal("0xd"))
+			Expect(ignoredDisks).To(Equal([]v1alpha1.IgnoredDevice{
+				{Path: "/dev/sda", WWN: "0xa", Reason: v1alpha1.IgnoredHasPartitions, Message: "partition sda1"},
+				{
+					Path: "/dev/sdb", DeviceID: "/dev/disk/by-id/wwn-0xb", WWN: "0xb",
+					Reason: v1alpha1.IgnoredHasFilesystem, Message: "xfs filesystem",
+				},
+				{Path: "/dev/sdc", Reason: v1alpha1.IgnoredNoWWN, Message: "no WWN"},
+				{Path: "/dev/sr0", Reason: v1alpha1.IgnoredReadOnly, Message: "read only device"},
+			}))
+		})
+	})
 })
@@ -0,0 +1,161 @@
+package discovery
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/diskutils"
+)
+
+// deviceFilter applies the inclusionSpec and exclusionSpec of the LocalVolumeDiscovery
+type deviceFilter struct {
+	deviceTypes   sets.String
+	excludedTypes sets.String
+	minSize       int64
+	maxSize       int64
+	vendor        *regexp.Regexp
+	model         *regexp.Regexp
+	deviceIDs     []string
+	excludeVendor *regexp.Regexp
+	excludeModel  *regexp.Regexp
+	excludeIDs    []string
+	// invalid is why the filters could not be loaded, every device is excluded then
+	invalid string
+}
+
+// defaultDeviceFilter discovers the disks and multipath devices
+func defaultDeviceFilter() *deviceFilter {
+	return &deviceFilter{deviceTypes: supportedDeviceTypes, excludedTypes: sets.NewString()}
+}
+
+// failClosedDeviceFilter excludes every device: the devices invalid filters were meant to exclude, e.g. LUNs
+// reserved for another product, must not be published
+func failClosedDeviceFilter(err error) *deviceFilter {
+	f := defaultDeviceFilter()
+	f.invalid = err.Error()
+	return f
+}
+
+// loadDeviceFilter reads the filters passed to the daemonset by the LocalVolumeDiscovery controller
+func loadDeviceFilter() (*deviceFilter, error) {
+	filters := os.Getenv(common.DiscoveryFiltersEnv)
+	if filters == "" {
+		return defaultDeviceFilter(), nil
+	}
+	spec := v1alpha1.LocalVolumeDiscoverySpec{}
+	if err := json.Unmarshal([]byte(filters), &spec); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", common.DiscoveryFiltersEnv, err)
+	}
+	return newDeviceFilter(spec.InclusionSpec, spec.ExclusionSpec)
+}
+
+// newDeviceFilter builds the filter of an inclusion and an exclusion spec, both optional
+func newDeviceFilter(inclusion *v1alpha1.DeviceInclusionSpec, exclusion *v1alpha1.DeviceExclusionSpec) (*deviceFilter, error) {
+	f := defaultDeviceFilter()
+	var err error
+	if inclusion != nil {
+		if len(inclusion.DeviceTypes) > 0 {
+			f.deviceTypes = sets.NewString()
+			for _, t := range inclusion.DeviceTypes {
+				f.deviceTypes.Insert(string(t))
+			}
+		}
+		if inclusion.MinSize != nil {
+			f.minSize = inclusion.MinSize.Value()
+		}
+		if inclusion.MaxSize != nil {
+			f.maxSize = inclusion.MaxSize.Value()
+		}
+		if f.vendor, err = compileRegexp("inclusionSpec.vendor", inclusion.Vendor); err != nil {
+			return nil, err
+		}
+		if f.model, err = compileRegexp("inclusionSpec.model", inclusion.Model); err != nil {
+			return nil, err
+		}
+		if f.deviceIDs, err = checkPatterns("inclusionSpec.deviceIDs", inclusion.DeviceIDs); err != nil {
+			return nil, err
+		}
+	}
+	if exclusion != nil {
+		for _, t := range exclusion.DeviceTypes {
+			f.excludedTypes.Insert(string(t))
+		}
+		if f.excludeVendor, err = compileRegexp("exclusionSpec.vendor", exclusion.Vendor); err != nil {
+			return nil, err
+		}
+		if f.excludeModel, err = compileRegexp("exclusionSpec.model", exclusion.Model); err != nil {
+			return nil, err
+		}
+		if f.excludeIDs, err = checkPatterns("exclusionSpec.deviceIDs", exclusion.DeviceIDs); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func compileRegexp(field, expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	return re, nil
+}
+
+func checkPatterns(field string, patterns []string) ([]string, error) {
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", field, pattern, err)
+		}
+	}
+	return patterns, nil
+}
+
+func matchesAny(patterns []string, deviceID string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, deviceID); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// excludes checks the size, vendor, model and persistent name of a device against the filter. It returns why
+// the device is excluded, an empty string when it is kept.
+func (f *deviceFilter) excludes(dev *diskutils.BlockDevice, deviceID string) string {
+	// lsblk pads the vendor and the model with spaces
+	vendor, model := strings.TrimSpace(dev.Vendor), strings.TrimSpace(dev.Model)
+	switch {
+	case f.invalid != "":
+		return fmt.Sprintf("invalid discovery filters: %s", f.invalid)
+	case f.excludedTypes.Has(dev.Type):
+		return fmt.Sprintf("excluded type %q", dev.Type)
+	case f.minSize > 0 && dev.Size < f.minSize:
+		return fmt.Sprintf("smaller than %d bytes", f.minSize)
+	case f.maxSize > 0 && dev.Size > f.maxSize:
+		return fmt.Sprintf("larger than %d bytes", f.maxSize)
+	case f.vendor != nil && !f.vendor.MatchString(vendor):
+		return fmt.Sprintf("vendor %q not included", vendor)
+	case f.model != nil && !f.model.MatchString(model):
+		return fmt.Sprintf("model %q not included", model)
+	case len(f.deviceIDs) > 0 && !matchesAny(f.deviceIDs, deviceID):
+		return fmt.Sprintf("persistent name %q not included", deviceID)
+	case f.excludeVendor != nil && f.excludeVendor.MatchString(vendor):
+		return fmt.Sprintf("excluded vendor %q", vendor)
+	case f.excludeModel != nil && f.excludeModel.MatchString(model):
+		return fmt.Sprintf("excluded model %q", model)
+	case matchesAny(f.excludeIDs, deviceID):
+		return fmt.Sprintf("excluded persistent name %q", deviceID)
+	}
+	return ""
+}
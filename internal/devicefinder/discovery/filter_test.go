@@ -0,0 +1,89 @@
+package discovery
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/common"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/diskutils"
+)
+
+var _ = Describe("Device filter", func() {
+	newDisk := func(name, vendor, model string, size int64) diskutils.BlockDevice {
+		return diskutils.BlockDevice{
+			Name:     name,
+			KName:    name,
+			Type:     "disk",
+			Vendor:   vendor,
+			Model:    model,
+			Size:     size,
+			WWN:      "0x" + name,
+			PathByID: "wwn-0x" + name,
+		}
+	}
+
+	names := func(devices []v1alpha1.DiscoveredDevice, _ []v1alpha1.IgnoredDevice) []string {
+		var paths []string
+		for _, d := range devices {
+			paths = append(paths, d.DeviceID)
+		}
+		return paths
+	}
+
+	blockDevices := []diskutils.BlockDevice{
+		newDisk("small", "IBM     ", "2145", 10<<30),
+		newDisk("large", "IBM     ", "2145", 500<<30),
+		newDisk("netapp", "NETAPP  ", "LUN C-Mode", 500<<30),
+		newDisk("reserved", "IBM     ", "2145", 500<<30),
+	}
+
+	It("should keep the default rules without filters", func() {
+		Expect(names(getDiscoverdDevices(blockDevices, defaultDeviceFilter()))).To(HaveLen(4))
+
+		filter, err := newDeviceFilter(&v1alpha1.DeviceInclusionSpec{DeviceTypes: []v1alpha1.DiscoveredDeviceType{v1alpha1.MultiPathType}}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(getDiscoverdDevices(blockDevices, filter))).To(BeEmpty())
+	})
+
+	It("should apply the inclusion and exclusion specs", func() {
+		minSize := resource.MustParse("100Gi")
+		filter, err := newDeviceFilter(
+			&v1alpha1.DeviceInclusionSpec{MinSize: &minSize, Vendor: "^IBM$"},
+			&v1alpha1.DeviceExclusionSpec{DeviceIDs: []string{"/dev/disk/by-id/wwn-0xreserved*"}},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(getDiscoverdDevices(blockDevices, filter))).To(Equal([]string{"/dev/disk/by-id/wwn-0xlarge"}))
+
+		filter, err = newDeviceFilter(nil, &v1alpha1.DeviceExclusionSpec{Model: "C-Mode"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(getDiscoverdDevices(blockDevices, filter))).NotTo(ContainElement("/dev/disk/by-id/wwn-0xnetapp"))
+		Expect(names(getDiscoverdDevices(blockDevices, filter))).To(HaveLen(3))
+	})
+
+	It("should load the filters passed to the daemonset", func() {
+		GinkgoT().Setenv(common.DiscoveryFiltersEnv, `{"inclusionSpec": {"maxSize": "100Gi"}}`)
+		filter, err := loadDeviceFilter()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names(getDiscoverdDevices(blockDevices, filter))).To(Equal([]string{"/dev/disk/by-id/wwn-0xsmall"}))
+
+		GinkgoT().Setenv(common.DiscoveryFiltersEnv, `{"exclusionSpec": {"vendor": "(IBM"}}`)
+		_, err = loadDeviceFilter()
+		Expect(err).To(MatchError(ContainSubstring("invalid exclusionSpec.vendor")))
+	})
+
+	It("should never expose the devices of an invalid exclusion", func() {
+		GinkgoT().Setenv(common.DiscoveryFiltersEnv, `{"exclusionSpec": {"deviceIDs": ["/dev/disk/by-id/wwn-0xreserved"], "model": "(2145"}}`)
+		_, err := loadDeviceFilter()
+		Expect(err).To(HaveOccurred())
+
+		devices, ignored := getDiscoverdDevices(blockDevices, failClosedDeviceFilter(err))
+		Expect(devices).To(BeEmpty())
+		Expect(ignored).To(HaveLen(len(blockDevices)))
+		for _, d := range ignored {
+			Expect(d.Reason).To(Equal(v1alpha1.IgnoredExcluded))
+			Expect(d.Message).To(ContainSubstring("invalid discovery filters"))
+		}
+	})
+})
@@ -0,0 +1,74 @@
+package discovery
+
+import (
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/diskutils"
+)
+
+// hasNSDSignature reads the header of a device, replaced in tests
+var hasNSDSignature = diskutils.HasNSDSignature
+
+// markDevicesInUse sets InUse on the discovered devices that a LocalDisk uses or whose header carries a GPFS
+// NSD signature
+func (discovery *DeviceDiscovery) markDevicesInUse(devices []v1alpha1.DiscoveredDevice) {
+	localDisks, err := discovery.apiClient.ListLocalDisks()
+	if err != nil {
+		klog.Warningf("failed to list LocalDisks, only NSD signatures tell which devices are in use. Error %v", err)
+	}
+	nodeName := os.Getenv("MY_NODE_NAME")
+	for idx := range devices {
+		devices[idx].InUse = getDeviceUsage(&devices[idx], nodeName, localDisks)
+	}
+}
+
+// getDeviceUsage returns what uses a discovered device in Storage Scale, nil when it is not used
+func getDeviceUsage(
+	device *v1alpha1.DiscoveredDevice,
+	nodeName string,
+	localDisks []unstructured.Unstructured,
+) *v1alpha1.DeviceUsage {
+	var usage *v1alpha1.DeviceUsage
+	if ld := findLocalDisk(localDisks, device, nodeName); ld != nil {
+		filesystem, _, _ := unstructured.NestedString(ld.Object, "status", "filesystem")
+		usage = &v1alpha1.DeviceUsage{LocalDisk: ld.GetName(), Filesystem: filesystem}
+	}
+
+	signed, err := hasNSDSignature(device.Path)
+	if err != nil {
+		klog.Warningf("failed to check the NSD signature of the device %q. Error %v", device.Path, err)
+	}
+	if signed {
+		if usage == nil {
+			usage = &v1alpha1.DeviceUsage{}
+		}
+		usage.NSDSignature = true
+	}
+	return usage
+}
+
+// findLocalDisk returns the LocalDisk of a device. LocalDisks are named after the WWN of their device, which
+// finds the ones created from another node; LocalDisks named otherwise are found by their node and device path.
+func findLocalDisk(
+	localDisks []unstructured.Unstructured,
+	device *v1alpha1.DiscoveredDevice,
+	nodeName string,
+) *unstructured.Unstructured {
+	for idx := range localDisks {
+		ld := &localDisks[idx]
+		if device.WWN != "" && strings.EqualFold(ld.GetName(), device.WWN) {
+			return ld
+		}
+		node, _, _ := unstructured.NestedString(ld.Object, "spec", "node")
+		path, _, _ := unstructured.NestedString(ld.Object, "spec", "device")
+		if node == nodeName && path != "" && (path == device.Path || path == device.DeviceID) {
+			return ld
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package discovery
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/api/v1alpha1"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/devicefinder"
+	"github.com/openshift-storage-scale/openshift-fusion-access-operator/internal/diskutils"
+)
+
+var _ = Describe("Devices in use", func() {
+	const wwn = "uuid.5f2a3b1c-0000-4000-8000-000000000001"
+
+	var signed map[string]bool
+
+	newLocalDisk := func(name, node, device, filesystem string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "scale.spectrum.ibm.com/v1beta1",
+			"kind":       "LocalDisk",
+			"metadata":   map[string]any{"name": name, "namespace": "ibm-spectrum-scale"},
+			"spec":       map[string]any{"node": node, "device": device},
+			"status":     map[string]any{"filesystem": filesystem},
+		}}
+	}
+
+	BeforeEach(func() {
+		signed = map[string]bool{}
+		hasNSDSignature = func(path string) (bool, error) {
+			if path == "/dev/sdz" {
+				return false, errors.New("no such device")
+			}
+			return signed[path], nil
+		}
+		DeferCleanup(func() { hasNSDSignature = diskutils.HasNSDSignature })
+	})
+
+	It("finds the LocalDisk of a device by WWN, whatever its node", func() {
+		device := &v1alpha1.DiscoveredDevice{Path: "/dev/sdc", WWN: wwn}
+		localDisks := []unstructured.Unstructured{newLocalDisk(wwn, "node2", "/dev/sdb", "fs1")}
+
+		Expect(getDeviceUsage(device, "node1", localDisks)).To(Equal(&v1alpha1.DeviceUsage{LocalDisk: wwn, Filesystem: "fs1"}))
+	})
+
+	It("finds the LocalDisk of a device by its path on the node", func() {
+		device := &v1alpha1.DiscoveredDevice{Path: "/dev/sdb", DeviceID: "/dev/disk/by-id/wwn-0x5000", WWN: "0x5000"}
+		localDisks := []unstructured.Unstructured{
+			newLocalDisk("legacy-1", "node2", "/dev/sdb", "fs1"),
+			newLocalDisk("legacy-2", "node1", "/dev/disk/by-id/wwn-0x5000", "fs2"),
+		}
+
+		Expect(getDeviceUsage(device, "node1", localDisks)).To(Equal(&v1alpha1.DeviceUsage{LocalDisk: "legacy-2", Filesystem: "fs2"}))
+		Expect(getDeviceUsage(device, "node3", localDisks)).To(BeNil())
+	})
+
+	It("marks the devices carrying an NSD signature", func() {
+		signed["/dev/sdb"] = true
+		Expect(getDeviceUsage(&v1alpha1.DiscoveredDevice{Path: "/dev/sdb", WWN: wwn}, "node1", nil)).To(
+			Equal(&v1alpha1.DeviceUsage{NSDSignature: true}))
+		Expect(getDeviceUsage(&v1alpha1.DiscoveredDevice{Path: "/dev/sdz", WWN: wwn}, "node1", nil)).To(BeNil())
+	})
+
+	It("marks the discovered devices even when LocalDisks cannot be listed", func() {
+		signed["/dev/sdb"] = true
+		discovery := &DeviceDiscovery{apiClient: &devicefinder.MockAPIUpdater{
+			MockListLocalDisks: func() ([]unstructured.Unstructured, error) { return nil, errors.New("forbidden") },
+		}}
+		devices := []v1alpha1.DiscoveredDevice{{Path: "/dev/sdb", WWN: wwn}, {Path: "/dev/sdc", WWN: "0x5000"}}
+
+		discovery.markDevicesInUse(devices)
+		Expect(devices[0].InUse).To(Equal(&v1alpha1.DeviceUsage{NSDSignature: true}))
+		Expect(devices[1].InUse).To(BeNil())
+	})
+})
@@ -1,7 +1,11 @@
 package diskutils
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"strings"
 
@@ -19,8 +23,14 @@ func init() {
 const (
 	// StateSuspended is a possible value of BlockDevice.State
 	StateSuspended = "suspended"
+
+	// nsdHeaderSize is the size of the device header holding the GPFS NSD descriptor, in its second sector
+	nsdHeaderSize = 4096
 )
 
+// nsdSignature is the text GPFS writes in the NSD descriptor of the disks it formats
+var nsdSignature = []byte("NSD descriptor for ")
+
 type CommandExecutor interface {
 	Execute(name string, args ...string) Command
 }
@@ -58,6 +68,12 @@ type BlockDevice struct {
 	WWN        string        `json:"wwn,omitempty"`
 	Children   []BlockDevice `json:"children,omitempty"`
 	Mountpoint string        `json:"mountpoint,omitempty"`
+	Rotational bool          `json:"rota,omitempty"`
+	Transport  string        `json:"tran,omitempty"`
+	Serial     string        `json:"serial,omitempty"`
+	LogSec     int64         `json:"log-sec,omitempty"`
+	PhySec     int64         `json:"phy-sec,omitempty"`
+	DiscMax    int64         `json:"disc-max,omitempty"`
 }
 
 func (b *BlockDevice) BiosPartition() bool {
@@ -117,3 +133,20 @@ func GetBlockDevices() ([]byte, error) {
 	}
 	return output, err
 }
+
+// HasNSDSignature reads the header of the device at path and reports whether it carries a GPFS NSD descriptor,
+// which tells that the device is, or was, an NSD of a Storage Scale cluster
+func HasNSDSignature(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	header := make([]byte, nsdHeaderSize)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return false, fmt.Errorf("failed to read the header of %s: %w", path, err)
+	}
+	return bytes.Contains(header[:n], nsdSignature), nil
+}
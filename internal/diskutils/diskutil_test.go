@@ -2,6 +2,8 @@ package diskutils
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -144,3 +146,30 @@ var _ = Describe("GetBlockDevices", func() {
 		Expect(output).To(BeEmpty())
 	})
 })
+
+var _ = Describe("HasNSDSignature", func() {
+	writeDevice := func(header []byte) string {
+		path := filepath.Join(GinkgoT().TempDir(), "sdb")
+		Expect(os.WriteFile(path, header, 0o600)).To(Succeed())
+		return path
+	}
+
+	It("finds the NSD descriptor in the second sector", func() {
+		header := make([]byte, 8192)
+		copy(header[512:], "NSD descriptor for /dev/sdb created by GPFS Tue Mar  4 10:12:31 2025")
+		found, err := HasNSDSignature(writeDevice(header))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+	})
+
+	It("returns false for a blank device, even smaller than the header", func() {
+		found, err := HasNSDSignature(writeDevice(make([]byte, 1024)))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("returns an error when the device cannot be read", func() {
+		_, err := HasNSDSignature("/nonexistent/sdb")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package events records Kubernetes events for the operator reconcilers
+package events
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Event is a single event about a topic of an object, e.g. one of its conditions
+type Event struct {
+	Topic     string
+	EventType string
+	Reason    string
+	Message   string
+}
+
+// NewEvent returns a new event of type warning
+func NewEvent(topic, reason, message string) *Event {
+	return &Event{Topic: topic, EventType: corev1.EventTypeWarning, Reason: reason, Message: message}
+}
+
+// NewSuccessEvent returns a normal event type
+func NewSuccessEvent(topic, reason, message string) *Event {
+	return &Event{Topic: topic, EventType: corev1.EventTypeNormal, Reason: reason, Message: message}
+}
+
+// Reporter records events and drops duplicates like the devicefinder EventReporter. Reconcilers retry
+// the same step many times, so an event is only recorded when it differs from the last one recorded
+// for the same object and topic: the events of an object are the history of its transitions.
+type Reporter struct {
+	mux            sync.Mutex
+	recorder       record.EventRecorder
+	reportedEvents map[string]string
+}
+
+// NewReporter returns a new event reporter. A nil recorder disables the events.
+func NewReporter(recorder record.EventRecorder) *Reporter {
+	return &Reporter{recorder: recorder, reportedEvents: make(map[string]string)}
+}
+
+// Report an event, unless it repeats the last event of the same object and topic
+func (reporter *Reporter) Report(e *Event, obj client.Object) {
+	if reporter == nil || reporter.recorder == nil {
+		return
+	}
+	reporter.mux.Lock()
+	defer reporter.mux.Unlock()
+
+	topicKey := fmt.Sprintf("%s/%s", obj.GetUID(), e.Topic)
+	eventKey := fmt.Sprintf("%s:%s", e.EventType, e.Reason)
+	if reporter.reportedEvents[topicKey] == eventKey {
+		return
+	}
+
+	reporter.recorder.Event(obj, e.EventType, e.Reason, e.Message)
+	reporter.reportedEvents[topicKey] = eventKey
+}
+
+// Forget drops the reported events of an object that is gone
+func (reporter *Reporter) Forget(obj client.Object) {
+	if reporter == nil {
+		return
+	}
+	reporter.mux.Lock()
+	defer reporter.mux.Unlock()
+
+	prefix := fmt.Sprintf("%s/", obj.GetUID())
+	for key := range reporter.reportedEvents {
+		if strings.HasPrefix(key, prefix) {
+			delete(reporter.reportedEvents, key)
+		}
+	}
+}
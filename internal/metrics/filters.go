@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	authenticationclient "k8s.io/client-go/kubernetes/typed/authentication/v1"
+	authorizationclient "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/rest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// allowedCacheTTL is how long a token allowed to read a path is trusted without asking the API server
+// again. Prometheus scrapes every 30s, so its reviews are only created every few minutes.
+const allowedCacheTTL = 2 * time.Minute
+
+// WithAuthenticationAndAuthorization protects the metrics endpoint like the controller-runtime filter of the
+// same name: the bearer token of a request is authenticated with a TokenReview, and its user must be allowed
+// the HTTP verb on the path by a SubjectAccessReview, e.g. get on the /metrics non-resource URL.
+func WithAuthenticationAndAuthorization(config *rest.Config, httpClient *http.Client) (metricsserver.Filter, error) {
+	authnClient, err := authenticationclient.NewForConfigAndClient(config, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the TokenReview client: %w", err)
+	}
+	authzClient, err := authorizationclient.NewForConfigAndClient(config, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the SubjectAccessReview client: %w", err)
+	}
+	a := &authFilter{
+		tokenReviews:         authnClient.TokenReviews(),
+		subjectAccessReviews: authzClient.SubjectAccessReviews(),
+		allowed:              map[string]time.Time{},
+	}
+	return a.filter, nil
+}
+
+type authFilter struct {
+	tokenReviews         authenticationclient.TokenReviewInterface
+	subjectAccessReviews authorizationclient.SubjectAccessReviewInterface
+
+	mux     sync.Mutex
+	allowed map[string]time.Time
+}
+
+func (a *authFilter) filter(log logr.Logger, handler http.Handler) (http.Handler, error) {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		verb := strings.ToLower(req.Method)
+		key := verb + " " + req.URL.Path + " " + token
+		if a.isAllowed(key) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		review, err := a.tokenReviews.Create(req.Context(), &authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{Token: token},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			log.Error(err, "failed to authenticate the metrics request")
+			http.Error(w, "Authentication failed", http.StatusInternalServerError)
+			return
+		}
+		if !review.Status.Authenticated {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		user := review.Status.User
+		extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+		for k, v := range user.Extra {
+			extra[k] = authorizationv1.ExtraValue(v)
+		}
+		access, err := a.subjectAccessReviews.Create(req.Context(), &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				User:                  user.Username,
+				UID:                   user.UID,
+				Groups:                user.Groups,
+				Extra:                 extra,
+				NonResourceAttributes: &authorizationv1.NonResourceAttributes{Path: req.URL.Path, Verb: verb},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			log.Error(err, "failed to authorize the metrics request", "user", user.Username)
+			http.Error(w, "Authorization failed", http.StatusInternalServerError)
+			return
+		}
+		if !access.Status.Allowed {
+			http.Error(w, fmt.Sprintf("Authorization denied for user %s", user.Username), http.StatusForbidden)
+			return
+		}
+
+		a.allow(key)
+		handler.ServeHTTP(w, req)
+	}), nil
+}
+
+func (a *authFilter) isAllowed(key string) bool {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	expiry, ok := a.allowed[key]
+	return ok && time.Now().Before(expiry)
+}
+
+func (a *authFilter) allow(key string) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	now := time.Now()
+	// Drop the expired entries so the tokens of the past scrapes do not pile up
+	for k, expiry := range a.allowed {
+		if !now.Before(expiry) {
+			delete(a.allowed, k)
+		}
+	}
+	a.allowed[key] = now.Add(allowedCacheTTL)
+}
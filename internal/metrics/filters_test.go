@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/rest"
+)
+
+// fakeAPIServer answers the TokenReviews of the tokens it knows and allows the users it is given get /metrics
+func fakeAPIServer(users map[string]string, allowed map[string]bool, reviews *atomic.Int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		defer GinkgoRecover()
+		reviews.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/apis/authentication.k8s.io/v1/tokenreviews":
+			review := &authenticationv1.TokenReview{}
+			Expect(json.NewDecoder(req.Body).Decode(review)).To(Succeed())
+			user, ok := users[review.Spec.Token]
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: ok,
+				User:          authenticationv1.UserInfo{Username: user},
+			}
+			Expect(json.NewEncoder(w).Encode(review)).To(Succeed())
+		case "/apis/authorization.k8s.io/v1/subjectaccessreviews":
+			review := &authorizationv1.SubjectAccessReview{}
+			Expect(json.NewDecoder(req.Body).Decode(review)).To(Succeed())
+			attrs := review.Spec.NonResourceAttributes
+			review.Status.Allowed = allowed[review.Spec.User] && attrs != nil &&
+				attrs.Path == "/metrics" && attrs.Verb == "get"
+			Expect(json.NewEncoder(w).Encode(review)).To(Succeed())
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+}
+
+var _ = Describe("WithAuthenticationAndAuthorization", func() {
+	var (
+		apiServer *httptest.Server
+		reviews   atomic.Int32
+		handler   http.Handler
+	)
+
+	BeforeEach(func() {
+		reviews.Store(0)
+		apiServer = fakeAPIServer(
+			map[string]string{"prometheus-token": "prometheus", "other-token": "other"},
+			map[string]bool{"prometheus": true},
+			&reviews,
+		)
+		DeferCleanup(apiServer.Close)
+
+		config := &rest.Config{Host: apiServer.URL, ContentConfig: rest.ContentConfig{ContentType: "application/json"}}
+		filter, err := WithAuthenticationAndAuthorization(config, apiServer.Client())
+		Expect(err).NotTo(HaveOccurred())
+		handler, err = filter(logr.Discard(), http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("fusion_access_metric 1\n"))
+		}))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	scrape := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	It("rejects the requests without a valid token", func() {
+		Expect(scrape("").Code).To(Equal(http.StatusUnauthorized))
+		Expect(scrape("unknown-token").Code).To(Equal(http.StatusUnauthorized))
+	})
+
+	It("forbids the users not allowed to get /metrics", func() {
+		Expect(scrape("other-token").Code).To(Equal(http.StatusForbidden))
+	})
+
+	It("serves the metrics to the allowed users and caches the decision", func() {
+		rec := scrape("prometheus-token")
+		Expect(rec.Code).To(Equal(http.StatusOK))
+		Expect(rec.Body.String()).To(ContainSubstring("fusion_access_metric"))
+		Expect(reviews.Load()).To(Equal(int32(2)))
+
+		Expect(scrape("prometheus-token").Code).To(Equal(http.StatusOK))
+		Expect(reviews.Load()).To(Equal(int32(2)))
+	})
+})
@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics holds the operator's Prometheus metrics. They are registered with the
+// controller-runtime registry, so they are served by the manager's metrics server next to
+// the built-in controller metrics.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "fusion_access"
+
+// Result label values
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// FileSystemClaim phases reported by fusion_access_filesystemclaim_phase
+const (
+	PhaseProvisioning = "Provisioning"
+	PhaseReady        = "Ready"
+	PhaseFailed       = "Failed"
+	PhaseDeleting     = "Deleting"
+)
+
+// Phases lists every FileSystemClaim phase, so that the inactive ones are reported as 0
+var Phases = []string{PhaseProvisioning, PhaseReady, PhaseFailed, PhaseDeleting}
+
+var (
+	// FileSystemClaimPhase is 1 for the current phase of a FileSystemClaim and 0 for the others
+	FileSystemClaimPhase = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "filesystemclaim_phase",
+		Help:      "Current phase of the FileSystemClaim (1 for the current phase, 0 otherwise).",
+	}, []string{"namespace", "name", "phase"})
+
+	// FileSystemClaimCondition is 1 for the current status of each FileSystemClaim condition and 0 for the others
+	FileSystemClaimCondition = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "filesystemclaim_condition",
+		Help:      "Status of the FileSystemClaim conditions (1 for the current status, 0 otherwise).",
+	}, []string{"namespace", "name", "type", "status"})
+
+	// FileSystemClaimLocalDisks counts the LocalDisks of a FileSystemClaim by state
+	FileSystemClaimLocalDisks = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "filesystemclaim_localdisks",
+		Help:      "Number of LocalDisks owned by the FileSystemClaim, by state.",
+	}, []string{"namespace", "name", "state"})
+
+	// FileSystemClaimDeletionBlockedSeconds is how long the deletion of a FileSystemClaim has been blocked
+	FileSystemClaimDeletionBlockedSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "filesystemclaim_deletion_blocked_seconds",
+		Help:      "Time since the deletion of the FileSystemClaim was blocked, by reason.",
+	}, []string{"namespace", "name", "reason"})
+
+	// FileSystemClaimDeletionRetrySeconds is the backoff before the next deletion attempt of a FileSystemClaim
+	FileSystemClaimDeletionRetrySeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "filesystemclaim_deletion_retry_seconds",
+		Help:      "Backoff before the next attempt to delete a blocked FileSystemClaim, by reason.",
+	}, []string{"namespace", "name", "reason"})
+
+	// ManifestApplyTotal counts the Storage Scale manifest applies by result
+	ManifestApplyTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "manifest_apply_total",
+		Help:      "Number of Storage Scale manifest applies, by result.",
+	}, []string{"result"})
+
+	// ImagePullCheckTotal counts the image pull checks by result
+	ImagePullCheckTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "image_pull_check_total",
+		Help:      "Number of checks that the protected Storage Scale images can be pulled, by result.",
+	}, []string{"result"})
+
+	// ImagePullCheckSuccess is the result of the last image pull check
+	ImagePullCheckSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "image_pull_check_success",
+		Help:      "Whether the last check that the protected Storage Scale images can be pulled succeeded (1) or failed (0).",
+	})
+
+	// KMMModuleBuilds counts the kernel module builds by phase
+	KMMModuleBuilds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "kmm_module_builds",
+		Help:      "Number of kernel module builds in the operator namespace, by phase.",
+	}, []string{"phase"})
+
+	// KMMModuleLoaderNodes reports the nodes targeted by the kernel module and those where it is loaded
+	KMMModuleLoaderNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "kmm_module_loader_nodes",
+		Help:      "Number of nodes for the kernel module loader, by state (desired or available).",
+	}, []string{"module", "state"})
+
+	// DiscoveredDevices counts the devices discovered on each node by type
+	DiscoveredDevices = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "discovered_devices",
+		Help:      "Number of usable devices discovered on the node, by device type.",
+	}, []string{"node", "type"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		FileSystemClaimPhase,
+		FileSystemClaimCondition,
+		FileSystemClaimLocalDisks,
+		FileSystemClaimDeletionBlockedSeconds,
+		FileSystemClaimDeletionRetrySeconds,
+		ManifestApplyTotal,
+		ImagePullCheckTotal,
+		ImagePullCheckSuccess,
+		KMMModuleBuilds,
+		KMMModuleLoaderNodes,
+		DiscoveredDevices,
+	)
+}
+
+// RecordDeletionBlocked records that the deletion of a FileSystemClaim has been blocked for
+// blockedFor and is retried after retryAfter
+func RecordDeletionBlocked(ns, name, reason string, blockedFor, retryAfter time.Duration) {
+	labels := prometheus.Labels{"namespace": ns, "name": name}
+	// Only the current blocker is reported
+	FileSystemClaimDeletionBlockedSeconds.DeletePartialMatch(labels)
+	FileSystemClaimDeletionRetrySeconds.DeletePartialMatch(labels)
+	FileSystemClaimDeletionBlockedSeconds.WithLabelValues(ns, name, reason).Set(blockedFor.Seconds())
+	FileSystemClaimDeletionRetrySeconds.WithLabelValues(ns, name, reason).Set(retryAfter.Seconds())
+}
+
+// RecordImagePullCheck records the result of an image pull check
+func RecordImagePullCheck(succeeded bool) {
+	if succeeded {
+		ImagePullCheckTotal.WithLabelValues(ResultSuccess).Inc()
+		ImagePullCheckSuccess.Set(1)
+		return
+	}
+	ImagePullCheckTotal.WithLabelValues(ResultFailure).Inc()
+	ImagePullCheckSuccess.Set(0)
+}
+
+// RecordManifestApply records the result of a manifest apply
+func RecordManifestApply(err error) {
+	if err != nil {
+		ManifestApplyTotal.WithLabelValues(ResultFailure).Inc()
+		return
+	}
+	ManifestApplyTotal.WithLabelValues(ResultSuccess).Inc()
+}
+
+// DeleteFileSystemClaim removes every series of a FileSystemClaim that no longer exists
+func DeleteFileSystemClaim(ns, name string) {
+	labels := prometheus.Labels{"namespace": ns, "name": name}
+	FileSystemClaimPhase.DeletePartialMatch(labels)
+	FileSystemClaimCondition.DeletePartialMatch(labels)
+	FileSystemClaimLocalDisks.DeletePartialMatch(labels)
+	FileSystemClaimDeletionBlockedSeconds.DeletePartialMatch(labels)
+	FileSystemClaimDeletionRetrySeconds.DeletePartialMatch(labels)
+}
@@ -17,11 +17,14 @@ limitations under the License.
 package utils
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,14 +46,16 @@ const (
 
 // Taken from https://www.ibm.com/docs/en/scalecontainernative/5.2.2?topic=planning-software-requirements
 type FusionAccessData struct {
-	CSIVersion                string   `json:"csi_version"`
-	Architecture              []string `json:"architecture"`
-	RemoteStorageClusterLevel string   `json:"remote_storage_cluster_level"`
-	FileSystemVersion         string   `json:"file_system_version"`
-	OpenShiftLevels           []string `json:"openshift_levels"`
+	CSIVersion                string   `json:"csi_version" yaml:"csi_version"`
+	Architecture              []string `json:"architecture" yaml:"architecture"`
+	RemoteStorageClusterLevel string   `json:"remote_storage_cluster_level" yaml:"remote_storage_cluster_level"`
+	FileSystemVersion         string   `json:"file_system_version" yaml:"file_system_version"`
+	OpenShiftLevels           []string `json:"openshift_levels" yaml:"openshift_levels"`
 }
 
-// The dict key is the IBM Fusion Access Container Native version
+// The dict key is the IBM Fusion Access Container Native version. The bundled versions ship their own
+// compatibility.yaml next to install.yaml, this table only covers releases that are no longer bundled
+// but can still be installed on a cluster.
 var storageScaleTable = map[string]FusionAccessData{
 	"5.2.2.0": {
 		"2.13.0",
@@ -73,16 +78,108 @@ var storageScaleTable = map[string]FusionAccessData{
 		"36.00",
 		[]string{"4.16", "4.17", "4.18"},
 	},
+	"5.2.3.1": {
+		"2.13.1",
+		[]string{"x86_64", "ppc64le", "s390x"},
+		"5.1.9.0+",
+		"36.00",
+		[]string{"4.16", "4.17", "4.18", "4.19"},
+	},
 }
 
-func IsOpenShiftSupported(ibmFusionAccessVersion string, openShiftVersion semver.Version) bool {
-	// Strip the leading "v" from the IBM Fusion Access version
-	ibmVer := ibmFusionAccessVersion
-	if strings.HasPrefix(ibmFusionAccessVersion, "v") {
-		ibmVer = ibmFusionAccessVersion[1:] // Remove the first character
+// storageScaleUpgradePaths lists for each IBM Storage Scale release the releases it can be upgraded from.
+// Rebuilds of the installed release are always allowed, downgrades never are.
+var storageScaleUpgradePaths = map[string][]string{
+	"5.2.3.5": {"5.2.3.1"},
+}
+
+// StorageScaleRelease returns the release of a Storage Scale version, without the leading "v" and the
+// build suffix: v5.2.3.5-2025.11.03.15.59.23 and v5.2.3.1.dev3 are the 5.2.3.5 and 5.2.3.1 releases
+func StorageScaleRelease(version string) string {
+	release := strings.TrimPrefix(version, "v")
+	release, _, _ = strings.Cut(release, "-")
+	if parts := strings.Split(release, "."); len(parts) > 4 {
+		release = strings.Join(parts[:4], ".")
 	}
+	return release
+}
+
+// compareStorageScaleReleases compares two releases number by number, like strings.Compare
+func compareStorageScaleReleases(a, b string) (int, error) {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	if len(aParts) != len(bParts) {
+		return 0, fmt.Errorf("cannot compare Storage Scale releases %s and %s", a, b)
+	}
+	for i := range aParts {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+		if aErr != nil || bErr != nil {
+			return 0, fmt.Errorf("cannot compare Storage Scale releases %s and %s", a, b)
+		}
+		if aNum != bNum {
+			return cmp.Compare(aNum, bNum), nil
+		}
+	}
+	return 0, nil
+}
 
-	data, exists := storageScaleTable[ibmVer]
+// IsStorageScaleUpgradeAllowed returns an error when the upgrade from one Storage Scale version to another is
+// not a supported upgrade path
+func IsStorageScaleUpgradeAllowed(from, to string) error {
+	fromRelease, toRelease := StorageScaleRelease(from), StorageScaleRelease(to)
+	order, err := compareStorageScaleReleases(fromRelease, toRelease)
+	if err != nil {
+		return err
+	}
+	switch {
+	case order == 0:
+		return nil
+	case order > 0:
+		return fmt.Errorf("downgrading IBM Storage Scale from %s to %s is not supported", from, to)
+	case slices.Contains(storageScaleUpgradePaths[toRelease], fromRelease):
+		return nil
+	default:
+		return fmt.Errorf("upgrading IBM Storage Scale from %s to %s is not supported, %s can be upgraded from: %v",
+			from, to, toRelease, storageScaleUpgradePaths[toRelease])
+	}
+}
+
+// GetStorageScaleCompatibility returns the compatibility data of an IBM Storage Scale release, from the
+// compatibility.yaml of the bundled version when there is one
+func GetStorageScaleCompatibility(version string) (FusionAccessData, bool) {
+	release := StorageScaleRelease(version)
+	if versions, err := GetBundledStorageScaleVersions(); err == nil {
+		for _, bundled := range slices.Backward(versions) {
+			if StorageScaleRelease(bundled) != release {
+				continue
+			}
+			if data, err := readStorageScaleCompatibility(bundled); err == nil {
+				return data, true
+			}
+		}
+	}
+	data, exists := storageScaleTable[release]
+	return data, exists
+}
+
+func readStorageScaleCompatibility(version string) (FusionAccessData, error) {
+	var data FusionAccessData
+	dir, err := storageScaleFilesDir()
+	if err != nil {
+		return data, err
+	}
+	b, err := os.ReadFile(path.Join(dir, version, "compatibility.yaml"))
+	if err != nil {
+		return data, err
+	}
+	if err := yaml.Unmarshal(b, &data); err != nil {
+		return data, fmt.Errorf("failed to parse the compatibility of %s: %w", version, err)
+	}
+	return data, nil
+}
+
+func IsOpenShiftSupported(ibmFusionAccessVersion string, openShiftVersion semver.Version) bool {
+	data, exists := GetStorageScaleCompatibility(ibmFusionAccessVersion)
 	if !exists {
 		return false
 	}
@@ -100,6 +197,37 @@ func IsOpenShiftSupported(ibmFusionAccessVersion string, openShiftVersion semver
 	return false
 }
 
+// nodeArchitectures maps the architecture reported by the nodes to the one used by IBM Storage Scale
+var nodeArchitectures = map[string]string{
+	"amd64": "x86_64",
+	"arm64": "aarch64",
+}
+
+// StorageScaleCompatibilityProblems lists why an IBM Storage Scale version cannot run on a cluster with
+// this OpenShift version and nodes of these architectures
+func StorageScaleCompatibilityProblems(version string, openShiftVersion semver.Version, architectures []string) []string {
+	data, exists := GetStorageScaleCompatibility(version)
+	if !exists {
+		return []string{fmt.Sprintf("no compatibility data for IBM Storage Scale %s", version)}
+	}
+
+	var problems []string
+	if !IsOpenShiftSupported(version, openShiftVersion) {
+		problems = append(problems, fmt.Sprintf("OpenShift %s is not supported, supported levels are %v",
+			openShiftVersion.String(), data.OpenShiftLevels))
+	}
+	for _, arch := range architectures {
+		if mapped, ok := nodeArchitectures[arch]; ok {
+			arch = mapped
+		}
+		if !slices.Contains(data.Architecture, arch) {
+			problems = append(problems, fmt.Sprintf("architecture %s is not supported, supported architectures are %v",
+				arch, data.Architecture))
+		}
+	}
+	return problems
+}
+
 // status:
 //  history:
 //   - completionTime: null
@@ -214,8 +342,8 @@ func ParseYAMLAndExtractTestImage(yamlContent string) (string, error) {
 
 // GetExternalTestImage returns the image to be used for testing external image pull.
 // FIXME(bandini): For now this is hardcoded, we should make sure this is
-func GetExternalTestImage() (string, error) {
-	_, manifestFile, err := GetStorageScaleVersion()
+func GetExternalTestImage(cnsaVersion string) (string, error) {
+	_, manifestFile, err := GetStorageScaleVersion(cnsaVersion)
 	if err != nil {
 		return "", err
 	}
@@ -331,10 +459,24 @@ func CanPullImage(
 	return pollStatusFunc(ctx, cl, namespace, podName)
 }
 
-func getSingleSubdirectory(dirPath string) (string, error) {
+// storageScaleFilesDirs are the locations of directory 'files', depending on the execution environment:
+// tests, running locally and running in the container
+var storageScaleFilesDirs = []string{"../../files", "./files", "/files"}
+
+// storageScaleFilesDir returns the first directory 'files' that contains at least one CNSA version
+func storageScaleFilesDir() (string, error) {
+	for _, dir := range storageScaleFilesDirs {
+		if versions, err := getSubdirectories(dir); err == nil && len(versions) > 0 {
+			return dir, nil
+		}
+	}
+	return "", fmt.Errorf("no CNSA version found in %v", storageScaleFilesDirs)
+}
+
+func getSubdirectories(dirPath string) ([]string, error) {
 	entries, err := os.ReadDir(dirPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to read directory %s: %w", dirPath, err)
+		return nil, fmt.Errorf("failed to read directory %s: %w", dirPath, err)
 	}
 
 	var subdirs []string
@@ -343,36 +485,64 @@ func getSingleSubdirectory(dirPath string) (string, error) {
 			subdirs = append(subdirs, entry.Name())
 		}
 	}
+	return subdirs, nil
+}
 
-	if len(subdirs) == 0 {
-		return "", fmt.Errorf("no subdirectories found in %s", dirPath)
+// GetBundledStorageScaleVersions returns the CNSA versions bundled in directory 'files', oldest first.
+// Each version is a subdirectory containing the install manifests in file install.yaml.
+func GetBundledStorageScaleVersions() ([]string, error) {
+	dir, err := storageScaleFilesDir()
+	if err != nil {
+		return nil, err
+	}
+	subdirs, err := getSubdirectories(dir)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(subdirs) > 1 {
-		return "", fmt.Errorf("expected exactly one subdirectory in %s, found %d: %v", dirPath, len(subdirs), subdirs)
+	var versions []string
+	for _, version := range subdirs {
+		if _, err := os.Stat(path.Join(dir, version, "install.yaml")); err == nil {
+			versions = append(versions, version)
+		}
 	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no install.yaml found under %s", dir)
+	}
+	slices.SortFunc(versions, func(a, b string) int {
+		order, err := compareStorageScaleReleases(StorageScaleRelease(a), StorageScaleRelease(b))
+		if err != nil || order == 0 {
+			return strings.Compare(a, b)
+		}
+		return order
+	})
+	return versions, nil
+}
 
-	return subdirs[0], nil
+// IsStorageScaleVersionBundled returns whether the install manifests of a CNSA version are bundled
+func IsStorageScaleVersionBundled(version string) bool {
+	versions, err := GetBundledStorageScaleVersions()
+	return err == nil && slices.Contains(versions, version)
 }
 
-// By convention there must be single subdirectory in directory 'files' (whose location is dependent on the execution environment)
-// containing the install manifests for CNSA in file install.yaml. The name of this directory is the CNSA version.
-// Returns the CNSA version and the path to the install.yaml file.
-func GetStorageScaleVersion() (cnsaVersion, installPath string, err error) {
-	// Install base path when running tests
-	if cnsaVersion, err := getSingleSubdirectory("../../files"); err == nil {
-		return cnsaVersion, path.Join("../../files", cnsaVersion, "install.yaml"), nil
-	}
-	// Install path when running locally
-	if cnsaVersion, err := getSingleSubdirectory("./files"); err == nil {
-		return cnsaVersion, path.Join("./files", cnsaVersion, "install.yaml"), nil
+// GetStorageScaleVersion selects one of the CNSA versions bundled in directory 'files' (whose location is
+// dependent on the execution environment): the requested version when it is bundled, the latest otherwise.
+// Returns the CNSA version and the path to its install.yaml file.
+func GetStorageScaleVersion(version string) (cnsaVersion, installPath string, err error) {
+	versions, err := GetBundledStorageScaleVersions()
+	if err != nil {
+		return "", "", fmt.Errorf("could not determine the CNSA version: %w", err)
 	}
-	// Install path when running in container
-	if cnsaVersion, err := getSingleSubdirectory("/files"); err == nil {
-		return cnsaVersion, path.Join("/files", cnsaVersion, "install.yaml"), nil
+	dir, err := storageScaleFilesDir()
+	if err != nil {
+		return "", "", err
 	}
 
-	return "", "", fmt.Errorf("could not determine the CNSA version: %w", err)
+	cnsaVersion = versions[len(versions)-1]
+	if slices.Contains(versions, version) {
+		cnsaVersion = version
+	}
+	return cnsaVersion, path.Join(dir, cnsaVersion, "install.yaml"), nil
 }
 
 func IsExternalManifestURLAllowed(url string) bool {
@@ -127,6 +127,7 @@ var _ = Describe("IsOpenShiftSupported", func() {
 		Entry("5.2.2.0 supports 4.15.17", "5.2.2.0", "4.15.17", true),
 		Entry("5.2.2.1 supports 4.18.1", "5.2.2.1", "4.18.1", true),
 		Entry("5.2.3.0 does not support 4.15.10", "5.2.3.0", "4.15.10", false),
+		Entry("a full CNSA version is looked up by release", "v5.2.3.5-2025.11.03.15.59.23", "4.19.2", true),
 	)
 
 	It("should return false for invalid IBM version", func() {
@@ -137,6 +138,112 @@ var _ = Describe("IsOpenShiftSupported", func() {
 	})
 })
 
+var _ = Describe("IsStorageScaleUpgradeAllowed", func() {
+	It("should strip the build of a CNSA version", func() {
+		Expect(StorageScaleRelease("v5.2.3.5-2025.11.03.15.59.23")).To(Equal("5.2.3.5"))
+		Expect(StorageScaleRelease("v5.2.3.1.dev3")).To(Equal("5.2.3.1"))
+	})
+
+	DescribeTable("upgrade paths",
+		func(from, to string, allowed bool) {
+			err := IsStorageScaleUpgradeAllowed(from, to)
+			if allowed {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("a rebuild of the same release", "v5.2.3.5-2025.10.01.00.00.00", "v5.2.3.5-2025.11.03.15.59.23", true),
+		Entry("a listed upgrade path", "v5.2.3.1", "v5.2.3.5-2025.11.03.15.59.23", true),
+		Entry("a downgrade", "v5.2.3.5-2025.11.03.15.59.23", "v5.2.3.1", false),
+		Entry("an unlisted upgrade path", "v5.2.2.1", "v5.2.3.5-2025.11.03.15.59.23", false),
+		Entry("an invalid version", "latest", "v5.2.3.5-2025.11.03.15.59.23", false),
+	)
+})
+
+var _ = Describe("Bundled Storage Scale versions", func() {
+	var origFilesDirs []string
+
+	writeVersion := func(dir, version string, openShiftLevels ...string) {
+		Expect(os.MkdirAll(filepath.Join(dir, version), 0o755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, version, "install.yaml"), []byte("---\n"), 0o600)).To(Succeed())
+		if len(openShiftLevels) > 0 {
+			compat, err := json.Marshal(FusionAccessData{Architecture: []string{"x86_64"}, OpenShiftLevels: openShiftLevels})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.WriteFile(filepath.Join(dir, version, "compatibility.yaml"), compat, 0o600)).To(Succeed())
+		}
+	}
+
+	BeforeEach(func() {
+		origFilesDirs = storageScaleFilesDirs
+		dir := GinkgoT().TempDir()
+		storageScaleFilesDirs = []string{dir}
+		writeVersion(dir, "v5.2.3.5-2025.11.03.15.59.23", "4.18", "4.19")
+		writeVersion(dir, "v5.2.3.10-2026.01.15.10.00.00", "4.19", "4.20")
+		writeVersion(dir, "v5.2.3.1")
+		// A directory without install.yaml is not a bundled version
+		Expect(os.MkdirAll(filepath.Join(dir, "v5.2.4.0"), 0o755)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		storageScaleFilesDirs = origFilesDirs
+	})
+
+	It("should list the bundled versions oldest first", func() {
+		versions, err := GetBundledStorageScaleVersions()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(versions).To(Equal([]string{"v5.2.3.1", "v5.2.3.5-2025.11.03.15.59.23", "v5.2.3.10-2026.01.15.10.00.00"}))
+	})
+
+	It("should select the requested version and default to the latest", func() {
+		version, installPath, err := GetStorageScaleVersion("v5.2.3.5-2025.11.03.15.59.23")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(version).To(Equal("v5.2.3.5-2025.11.03.15.59.23"))
+		Expect(installPath).To(Equal(filepath.Join(storageScaleFilesDirs[0], version, "install.yaml")))
+
+		for _, requested := range []string{"", "v5.2.2.1"} {
+			version, _, err = GetStorageScaleVersion(requested)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal("v5.2.3.10-2026.01.15.10.00.00"))
+		}
+	})
+
+	It("should load the compatibility of a version from the bundle", func() {
+		ocp419, err := semver.NewVersion("4.19.1")
+		Expect(err).NotTo(HaveOccurred())
+		ocp420, err := semver.NewVersion("4.20.0")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(IsOpenShiftSupported("v5.2.3.10-2026.01.15.10.00.00", *ocp420)).To(BeTrue())
+		Expect(IsOpenShiftSupported("v5.2.3.5-2025.11.03.15.59.23", *ocp420)).To(BeFalse())
+		// Without a compatibility.yaml the table of releases that are no longer bundled is used
+		Expect(IsOpenShiftSupported("v5.2.3.1", *ocp419)).To(BeTrue())
+	})
+
+	It("should list the compatibility problems of a version", func() {
+		ocp418, err := semver.NewVersion("4.18.5")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(StorageScaleCompatibilityProblems("v5.2.3.5-2025.11.03.15.59.23", *ocp418, []string{"amd64"})).To(BeEmpty())
+
+		compat, err := json.Marshal(FusionAccessData{Architecture: []string{"x86_64", "s390x"}, OpenShiftLevels: []string{"4.19"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(storageScaleFilesDirs[0], "v5.2.3.10-2026.01.15.10.00.00", "compatibility.yaml"),
+			compat, 0o600)).To(Succeed())
+		problems := StorageScaleCompatibilityProblems("v5.2.3.10-2026.01.15.10.00.00", *ocp418, []string{"amd64", "arm64"})
+		Expect(problems).To(HaveLen(2))
+		Expect(problems[0]).To(ContainSubstring("OpenShift 4.18.5"))
+		Expect(problems[1]).To(ContainSubstring("aarch64"))
+
+		Expect(StorageScaleCompatibilityProblems("v6.0.0.0", *ocp418, nil)).To(HaveLen(1))
+	})
+
+	It("should fail when no version is bundled", func() {
+		storageScaleFilesDirs = []string{GinkgoT().TempDir()}
+		_, _, err := GetStorageScaleVersion("")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
 var _ = Describe("Image Pull Checker", func() {
 	var (
 		cl                client.Client
@@ -1,22 +1,26 @@
 #!/bin/bash
 
-# This script gets the CNSA to be supported from the file CNSA_VERSION.txt.
-# It verifies the corresponding install.yaml file exists and
+# This script gets the CNSA versions to be supported from the directories in files/ and
+# the default one from the file CNSA_VERSION.txt.
+# It verifies the corresponding install.yaml files exist and
 # then it updates the API_GO_FILE in two places
 # It updates two specific lines for the metadata. If those lines are changed in the
 # go file this script needs to be amended as well
 
 CNSA_VERSION=$(cat CNSA_VERSION.txt)
+CNSA_VERSIONS=$(cd files && ls -d v*/ | tr -d /)
 
-if [[ $CNSA_VERSION != v* ]]; then
-    echo "the CNSA version $CNSA_VERSION must start with a 'v'"
-    exit 1
-fi
+for VERSION in ${CNSA_VERSIONS}; do
+    if [[ -f files/$VERSION/install.yaml ]]; then
+        echo "found CNSA version $VERSION install.yaml"
+    else
+        echo "couldn't find files/$VERSION/install.yaml"
+        exit 1
+    fi
+done
 
-if [[ -f files/$CNSA_VERSION/install.yaml ]]; then
-    echo "found CNSA version $CNSA_VERSION install.yaml"
-else
-    echo "couldn't find files/$CNSA_VERSION/install.yaml"
+if [[ ! " $(echo ${CNSA_VERSIONS}) " =~ " ${CNSA_VERSION} " ]]; then
+    echo "the default CNSA version $CNSA_VERSION must be one of: ${CNSA_VERSIONS}"
     exit 1
 fi
 
@@ -25,10 +29,10 @@ API_GO_FILE="api/v1alpha1/fusionaccess_types.go"
 TMP_FILE=$(mktemp)
 trap 'rm -f "${TMP_FILE}"' EXIT SIGINT SIGTERM
 
-TECTONIC_VERSIONS="{$(for d in ${CNSA_VERSION}; do echo "$d" | sed 's/.*/"urn:alm:descriptor:com.tectonic.ui:select:&"/'; done | paste -sd, -)}"
+TECTONIC_VERSIONS="{$(for d in ${CNSA_VERSIONS}; do echo "$d" | sed 's/.*/"urn:alm:descriptor:com.tectonic.ui:select:&"/'; done | paste -sd, -)}"
 echo $TECTONIC_VERSIONS
 
-ENUM_VERSIONS="$(IFS=\; ; echo "${CNSA_VERSION}")"
+ENUM_VERSIONS="$(echo ${CNSA_VERSIONS} | tr ' ' ';')"
 echo $ENUM_VERSIONS
 
 # This replaces the enum lines like below and stores it on a tmp file